@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/accessanalyzer"
 )
 
-func ListAccessanalyzerAnalyzer(client *Client) ([]Resource, error) {
+func ListAccessanalyzerAnalyzer(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Accessanalyzerconn.ListAnalyzersRequest(&accessanalyzer.ListAnalyzersInput{})
 
 	var result []Resource
 
 	p := accessanalyzer.NewListAnalyzersPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.Analyzers {
@@ -33,6 +33,8 @@ func ListAccessanalyzerAnalyzer(client *Client) ([]Resource, error) {
 				Tags:      tags,
 			})
 		}
+
+		progress.report("aws_accessanalyzer_analyzer", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/acm"
 )
 
-func ListAcmCertificate(client *Client) ([]Resource, error) {
+func ListAcmCertificate(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Acmconn.ListCertificatesRequest(&acm.ListCertificatesInput{})
 
 	var result []Resource
 
 	p := acm.NewListCertificatesPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.CertificateSummaryList {
@@ -27,6 +27,8 @@ func ListAcmCertificate(client *Client) ([]Resource, error) {
 				AccountID: client.AccountID,
 			})
 		}
+
+		progress.report("aws_acm_certificate", len(result))
 	}
 
 	if err := p.Err(); err != nil {
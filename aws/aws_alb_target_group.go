@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
 )
 
-func ListAlbTargetGroup(client *Client) ([]Resource, error) {
+func ListAlbTargetGroup(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Elasticloadbalancingv2conn.DescribeTargetGroupsRequest(&elasticloadbalancingv2.DescribeTargetGroupsInput{})
 
 	var result []Resource
 
 	p := elasticloadbalancingv2.NewDescribeTargetGroupsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.TargetGroups {
@@ -27,6 +27,8 @@ func ListAlbTargetGroup(client *Client) ([]Resource, error) {
 				AccountID: client.AccountID,
 			})
 		}
+
+		progress.report("aws_alb_target_group", len(result))
 	}
 
 	if err := p.Err(); err != nil {
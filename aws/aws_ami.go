@@ -9,12 +9,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 )
 
-func ListAmi(client *Client) ([]Resource, error) {
+func ListAmi(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Ec2conn.DescribeImagesRequest(&ec2.DescribeImagesInput{})
 
 	var result []Resource
 
-	resp, err := req.Send(context.Background())
+	resp, err := req.Send(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/apigateway"
 )
 
-func ListApiGatewayUsagePlan(client *Client) ([]Resource, error) {
+func ListApiGatewayUsagePlan(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Apigatewayconn.GetUsagePlansRequest(&apigateway.GetUsagePlansInput{})
 
 	var result []Resource
 
 	p := apigateway.NewGetUsagePlansPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.Items {
@@ -33,6 +33,8 @@ func ListApiGatewayUsagePlan(client *Client) ([]Resource, error) {
 				Tags:      tags,
 			})
 		}
+
+		progress.report("aws_api_gateway_usage_plan", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/apigateway"
 )
 
-func ListApiGatewayVpcLink(client *Client) ([]Resource, error) {
+func ListApiGatewayVpcLink(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Apigatewayconn.GetVpcLinksRequest(&apigateway.GetVpcLinksInput{})
 
 	var result []Resource
 
 	p := apigateway.NewGetVpcLinksPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.Items {
@@ -33,6 +33,8 @@ func ListApiGatewayVpcLink(client *Client) ([]Resource, error) {
 				Tags:      tags,
 			})
 		}
+
+		progress.report("aws_api_gateway_vpc_link", len(result))
 	}
 
 	if err := p.Err(); err != nil {
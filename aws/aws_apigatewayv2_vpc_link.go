@@ -8,12 +8,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
 )
 
-func ListApigatewayv2VpcLink(client *Client) ([]Resource, error) {
+func ListApigatewayv2VpcLink(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Apigatewayv2conn.GetVpcLinksRequest(&apigatewayv2.GetVpcLinksInput{})
 
 	var result []Resource
 
-	resp, err := req.Send(context.Background())
+	resp, err := req.Send(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/appmesh"
 )
 
-func ListAppmeshMesh(client *Client) ([]Resource, error) {
+func ListAppmeshMesh(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Appmeshconn.ListMeshesRequest(&appmesh.ListMeshesInput{})
 
 	var result []Resource
 
 	p := appmesh.NewListMeshesPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.Meshes {
@@ -27,6 +27,8 @@ func ListAppmeshMesh(client *Client) ([]Resource, error) {
 				AccountID: client.AccountID,
 			})
 		}
+
+		progress.report("aws_appmesh_mesh", len(result))
 	}
 
 	if err := p.Err(); err != nil {
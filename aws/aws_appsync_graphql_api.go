@@ -8,12 +8,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/appsync"
 )
 
-func ListAppsyncGraphqlApi(client *Client) ([]Resource, error) {
+func ListAppsyncGraphqlApi(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Appsyncconn.ListGraphqlApisRequest(&appsync.ListGraphqlApisInput{})
 
 	var result []Resource
 
-	resp, err := req.Send(context.Background())
+	resp, err := req.Send(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/athena"
 )
 
-func ListAthenaWorkgroup(client *Client) ([]Resource, error) {
+func ListAthenaWorkgroup(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Athenaconn.ListWorkGroupsRequest(&athena.ListWorkGroupsInput{})
 
 	var result []Resource
 
 	p := athena.NewListWorkGroupsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.WorkGroups {
@@ -30,6 +30,8 @@ func ListAthenaWorkgroup(client *Client) ([]Resource, error) {
 				CreatedAt: &t,
 			})
 		}
+
+		progress.report("aws_athena_workgroup", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
 )
 
-func ListAutoscalingGroup(client *Client) ([]Resource, error) {
+func ListAutoscalingGroup(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Autoscalingconn.DescribeAutoScalingGroupsRequest(&autoscaling.DescribeAutoScalingGroupsInput{})
 
 	var result []Resource
 
 	p := autoscaling.NewDescribeAutoScalingGroupsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.AutoScalingGroups {
@@ -34,6 +34,8 @@ func ListAutoscalingGroup(client *Client) ([]Resource, error) {
 				CreatedAt: &t,
 			})
 		}
+
+		progress.report("aws_autoscaling_group", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/backup"
 )
 
-func ListBackupPlan(client *Client) ([]Resource, error) {
+func ListBackupPlan(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Backupconn.ListBackupPlansRequest(&backup.ListBackupPlansInput{})
 
 	var result []Resource
 
 	p := backup.NewListBackupPlansPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.BackupPlansList {
@@ -30,6 +30,8 @@ func ListBackupPlan(client *Client) ([]Resource, error) {
 				CreatedAt: &t,
 			})
 		}
+
+		progress.report("aws_backup_plan", len(result))
 	}
 
 	if err := p.Err(); err != nil {
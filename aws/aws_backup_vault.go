@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/backup"
 )
 
-func ListBackupVault(client *Client) ([]Resource, error) {
+func ListBackupVault(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Backupconn.ListBackupVaultsRequest(&backup.ListBackupVaultsInput{})
 
 	var result []Resource
 
 	p := backup.NewListBackupVaultsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.BackupVaultList {
@@ -30,6 +30,8 @@ func ListBackupVault(client *Client) ([]Resource, error) {
 				CreatedAt: &t,
 			})
 		}
+
+		progress.report("aws_backup_vault", len(result))
 	}
 
 	if err := p.Err(); err != nil {
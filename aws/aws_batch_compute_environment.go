@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/batch"
 )
 
-func ListBatchComputeEnvironment(client *Client) ([]Resource, error) {
+func ListBatchComputeEnvironment(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Batchconn.DescribeComputeEnvironmentsRequest(&batch.DescribeComputeEnvironmentsInput{})
 
 	var result []Resource
 
 	p := batch.NewDescribeComputeEnvironmentsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.ComputeEnvironments {
@@ -27,6 +27,8 @@ func ListBatchComputeEnvironment(client *Client) ([]Resource, error) {
 				AccountID: client.AccountID,
 			})
 		}
+
+		progress.report("aws_batch_compute_environment", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/batch"
 )
 
-func ListBatchJobDefinition(client *Client) ([]Resource, error) {
+func ListBatchJobDefinition(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Batchconn.DescribeJobDefinitionsRequest(&batch.DescribeJobDefinitionsInput{})
 
 	var result []Resource
 
 	p := batch.NewDescribeJobDefinitionsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.JobDefinitions {
@@ -27,6 +27,8 @@ func ListBatchJobDefinition(client *Client) ([]Resource, error) {
 				AccountID: client.AccountID,
 			})
 		}
+
+		progress.report("aws_batch_job_definition", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/batch"
 )
 
-func ListBatchJobQueue(client *Client) ([]Resource, error) {
+func ListBatchJobQueue(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Batchconn.DescribeJobQueuesRequest(&batch.DescribeJobQueuesInput{})
 
 	var result []Resource
 
 	p := batch.NewDescribeJobQueuesPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.JobQueues {
@@ -27,6 +27,8 @@ func ListBatchJobQueue(client *Client) ([]Resource, error) {
 				AccountID: client.AccountID,
 			})
 		}
+
+		progress.report("aws_batch_job_queue", len(result))
 	}
 
 	if err := p.Err(); err != nil {
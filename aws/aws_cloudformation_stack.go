@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
 )
 
-func ListCloudformationStack(client *Client) ([]Resource, error) {
+func ListCloudformationStack(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Cloudformationconn.DescribeStacksRequest(&cloudformation.DescribeStacksInput{})
 
 	var result []Resource
 
 	p := cloudformation.NewDescribeStacksPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.Stacks {
@@ -34,6 +34,8 @@ func ListCloudformationStack(client *Client) ([]Resource, error) {
 				CreatedAt: &t,
 			})
 		}
+
+		progress.report("aws_cloudformation_stack", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
 )
 
-func ListCloudformationStackSet(client *Client) ([]Resource, error) {
+func ListCloudformationStackSet(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Cloudformationconn.ListStackSetsRequest(&cloudformation.ListStackSetsInput{})
 
 	var result []Resource
 
 	p := cloudformation.NewListStackSetsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.Summaries {
@@ -27,6 +27,8 @@ func ListCloudformationStackSet(client *Client) ([]Resource, error) {
 				AccountID: client.AccountID,
 			})
 		}
+
+		progress.report("aws_cloudformation_stack_set", len(result))
 	}
 
 	if err := p.Err(); err != nil {
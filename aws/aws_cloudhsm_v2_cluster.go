@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/cloudhsmv2"
 )
 
-func ListCloudhsmV2Cluster(client *Client) ([]Resource, error) {
+func ListCloudhsmV2Cluster(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Cloudhsmv2conn.DescribeClustersRequest(&cloudhsmv2.DescribeClustersInput{})
 
 	var result []Resource
 
 	p := cloudhsmv2.NewDescribeClustersPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.Clusters {
@@ -27,6 +27,8 @@ func ListCloudhsmV2Cluster(client *Client) ([]Resource, error) {
 				AccountID: client.AccountID,
 			})
 		}
+
+		progress.report("aws_cloudhsm_v2_cluster", len(result))
 	}
 
 	if err := p.Err(); err != nil {
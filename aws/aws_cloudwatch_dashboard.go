@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
 )
 
-func ListCloudwatchDashboard(client *Client) ([]Resource, error) {
+func ListCloudwatchDashboard(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Cloudwatchconn.ListDashboardsRequest(&cloudwatch.ListDashboardsInput{})
 
 	var result []Resource
 
 	p := cloudwatch.NewListDashboardsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.DashboardEntries {
@@ -27,6 +27,8 @@ func ListCloudwatchDashboard(client *Client) ([]Resource, error) {
 				AccountID: client.AccountID,
 			})
 		}
+
+		progress.report("aws_cloudwatch_dashboard", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,12 +8,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatchevents"
 )
 
-func ListCloudwatchEventRule(client *Client) ([]Resource, error) {
+func ListCloudwatchEventRule(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Cloudwatcheventsconn.ListRulesRequest(&cloudwatchevents.ListRulesInput{})
 
 	var result []Resource
 
-	resp, err := req.Send(context.Background())
+	resp, err := req.Send(ctx)
 	if err != nil {
 		return nil, err
 	}
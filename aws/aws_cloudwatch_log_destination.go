@@ -9,13 +9,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
 )
 
-func ListCloudwatchLogDestination(client *Client) ([]Resource, error) {
+func ListCloudwatchLogDestination(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Cloudwatchlogsconn.DescribeDestinationsRequest(&cloudwatchlogs.DescribeDestinationsInput{})
 
 	var result []Resource
 
 	p := cloudwatchlogs.NewDescribeDestinationsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.Destinations {
@@ -31,6 +31,8 @@ func ListCloudwatchLogDestination(client *Client) ([]Resource, error) {
 				CreatedAt: &t,
 			})
 		}
+
+		progress.report("aws_cloudwatch_log_destination", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -9,13 +9,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
 )
 
-func ListCloudwatchLogGroup(client *Client) ([]Resource, error) {
+func ListCloudwatchLogGroup(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Cloudwatchlogsconn.DescribeLogGroupsRequest(&cloudwatchlogs.DescribeLogGroupsInput{})
 
 	var result []Resource
 
 	p := cloudwatchlogs.NewDescribeLogGroupsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.LogGroups {
@@ -31,6 +31,8 @@ func ListCloudwatchLogGroup(client *Client) ([]Resource, error) {
 				CreatedAt: &t,
 			})
 		}
+
+		progress.report("aws_cloudwatch_log_group", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,12 +8,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/codebuild"
 )
 
-func ListCodebuildSourceCredential(client *Client) ([]Resource, error) {
+func ListCodebuildSourceCredential(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Codebuildconn.ListSourceCredentialsRequest(&codebuild.ListSourceCredentialsInput{})
 
 	var result []Resource
 
-	resp, err := req.Send(context.Background())
+	resp, err := req.Send(ctx)
 	if err != nil {
 		return nil, err
 	}
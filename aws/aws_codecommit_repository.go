@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/codecommit"
 )
 
-func ListCodecommitRepository(client *Client) ([]Resource, error) {
+func ListCodecommitRepository(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Codecommitconn.ListRepositoriesRequest(&codecommit.ListRepositoriesInput{})
 
 	var result []Resource
 
 	p := codecommit.NewListRepositoriesPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.Repositories {
@@ -27,6 +27,8 @@ func ListCodecommitRepository(client *Client) ([]Resource, error) {
 				AccountID: client.AccountID,
 			})
 		}
+
+		progress.report("aws_codecommit_repository", len(result))
 	}
 
 	if err := p.Err(); err != nil {
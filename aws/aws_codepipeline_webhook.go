@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/codepipeline"
 )
 
-func ListCodepipelineWebhook(client *Client) ([]Resource, error) {
+func ListCodepipelineWebhook(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Codepipelineconn.ListWebhooksRequest(&codepipeline.ListWebhooksInput{})
 
 	var result []Resource
 
 	p := codepipeline.NewListWebhooksPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.Webhooks {
@@ -33,6 +33,8 @@ func ListCodepipelineWebhook(client *Client) ([]Resource, error) {
 				Tags:      tags,
 			})
 		}
+
+		progress.report("aws_codepipeline_webhook", len(result))
 	}
 
 	if err := p.Err(); err != nil {
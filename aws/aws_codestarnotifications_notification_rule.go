@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/codestarnotifications"
 )
 
-func ListCodestarnotificationsNotificationRule(client *Client) ([]Resource, error) {
+func ListCodestarnotificationsNotificationRule(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Codestarnotificationsconn.ListNotificationRulesRequest(&codestarnotifications.ListNotificationRulesInput{})
 
 	var result []Resource
 
 	p := codestarnotifications.NewListNotificationRulesPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.NotificationRules {
@@ -27,6 +27,8 @@ func ListCodestarnotificationsNotificationRule(client *Client) ([]Resource, erro
 				AccountID: client.AccountID,
 			})
 		}
+
+		progress.report("aws_codestarnotifications_notification_rule", len(result))
 	}
 
 	if err := p.Err(); err != nil {
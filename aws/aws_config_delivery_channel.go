@@ -8,12 +8,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/configservice"
 )
 
-func ListConfigDeliveryChannel(client *Client) ([]Resource, error) {
+func ListConfigDeliveryChannel(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Configserviceconn.DescribeDeliveryChannelsRequest(&configservice.DescribeDeliveryChannelsInput{})
 
 	var result []Resource
 
-	resp, err := req.Send(context.Background())
+	resp, err := req.Send(ctx)
 	if err != nil {
 		return nil, err
 	}
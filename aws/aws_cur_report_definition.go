@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/costandusagereportservice"
 )
 
-func ListCurReportDefinition(client *Client) ([]Resource, error) {
+func ListCurReportDefinition(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Costandusagereportserviceconn.DescribeReportDefinitionsRequest(&costandusagereportservice.DescribeReportDefinitionsInput{})
 
 	var result []Resource
 
 	p := costandusagereportservice.NewDescribeReportDefinitionsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.ReportDefinitions {
@@ -27,6 +27,8 @@ func ListCurReportDefinition(client *Client) ([]Resource, error) {
 				AccountID: client.AccountID,
 			})
 		}
+
+		progress.report("aws_cur_report_definition", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/datasync"
 )
 
-func ListDatasyncAgent(client *Client) ([]Resource, error) {
+func ListDatasyncAgent(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Datasyncconn.ListAgentsRequest(&datasync.ListAgentsInput{})
 
 	var result []Resource
 
 	p := datasync.NewListAgentsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.Agents {
@@ -27,6 +27,8 @@ func ListDatasyncAgent(client *Client) ([]Resource, error) {
 				AccountID: client.AccountID,
 			})
 		}
+
+		progress.report("aws_datasync_agent", len(result))
 	}
 
 	if err := p.Err(); err != nil {
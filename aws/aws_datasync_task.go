@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/datasync"
 )
 
-func ListDatasyncTask(client *Client) ([]Resource, error) {
+func ListDatasyncTask(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Datasyncconn.ListTasksRequest(&datasync.ListTasksInput{})
 
 	var result []Resource
 
 	p := datasync.NewListTasksPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.Tasks {
@@ -27,6 +27,8 @@ func ListDatasyncTask(client *Client) ([]Resource, error) {
 				AccountID: client.AccountID,
 			})
 		}
+
+		progress.report("aws_datasync_task", len(result))
 	}
 
 	if err := p.Err(); err != nil {
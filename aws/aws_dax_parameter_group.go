@@ -8,12 +8,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/dax"
 )
 
-func ListDaxParameterGroup(client *Client) ([]Resource, error) {
+func ListDaxParameterGroup(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Daxconn.DescribeParameterGroupsRequest(&dax.DescribeParameterGroupsInput{})
 
 	var result []Resource
 
-	resp, err := req.Send(context.Background())
+	resp, err := req.Send(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/rds"
 )
 
-func ListDbEventSubscription(client *Client) ([]Resource, error) {
+func ListDbEventSubscription(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Rdsconn.DescribeEventSubscriptionsRequest(&rds.DescribeEventSubscriptionsInput{})
 
 	var result []Resource
 
 	p := rds.NewDescribeEventSubscriptionsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.EventSubscriptionsList {
@@ -27,6 +27,8 @@ func ListDbEventSubscription(client *Client) ([]Resource, error) {
 				AccountID: client.AccountID,
 			})
 		}
+
+		progress.report("aws_db_event_subscription", len(result))
 	}
 
 	if err := p.Err(); err != nil {
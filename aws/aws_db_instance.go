@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/rds"
 )
 
-func ListDbInstance(client *Client) ([]Resource, error) {
+func ListDbInstance(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Rdsconn.DescribeDBInstancesRequest(&rds.DescribeDBInstancesInput{})
 
 	var result []Resource
 
 	p := rds.NewDescribeDBInstancesPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.DBInstances {
@@ -30,6 +30,8 @@ func ListDbInstance(client *Client) ([]Resource, error) {
 				CreatedAt: &t,
 			})
 		}
+
+		progress.report("aws_db_instance", len(result))
 	}
 
 	if err := p.Err(); err != nil {
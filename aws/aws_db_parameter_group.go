@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/rds"
 )
 
-func ListDbParameterGroup(client *Client) ([]Resource, error) {
+func ListDbParameterGroup(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Rdsconn.DescribeDBParameterGroupsRequest(&rds.DescribeDBParameterGroupsInput{})
 
 	var result []Resource
 
 	p := rds.NewDescribeDBParameterGroupsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.DBParameterGroups {
@@ -27,6 +27,8 @@ func ListDbParameterGroup(client *Client) ([]Resource, error) {
 				AccountID: client.AccountID,
 			})
 		}
+
+		progress.report("aws_db_parameter_group", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/rds"
 )
 
-func ListDbSecurityGroup(client *Client) ([]Resource, error) {
+func ListDbSecurityGroup(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Rdsconn.DescribeDBSecurityGroupsRequest(&rds.DescribeDBSecurityGroupsInput{})
 
 	var result []Resource
 
 	p := rds.NewDescribeDBSecurityGroupsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.DBSecurityGroups {
@@ -30,6 +30,8 @@ func ListDbSecurityGroup(client *Client) ([]Resource, error) {
 				AccountID: client.AccountID,
 			})
 		}
+
+		progress.report("aws_db_security_group", len(result))
 	}
 
 	if err := p.Err(); err != nil {
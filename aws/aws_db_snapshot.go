@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/rds"
 )
 
-func ListDbSnapshot(client *Client) ([]Resource, error) {
+func ListDbSnapshot(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Rdsconn.DescribeDBSnapshotsRequest(&rds.DescribeDBSnapshotsInput{})
 
 	var result []Resource
 
 	p := rds.NewDescribeDBSnapshotsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.DBSnapshots {
@@ -30,6 +30,8 @@ func ListDbSnapshot(client *Client) ([]Resource, error) {
 				CreatedAt: &t,
 			})
 		}
+
+		progress.report("aws_db_snapshot", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/rds"
 )
 
-func ListDbSubnetGroup(client *Client) ([]Resource, error) {
+func ListDbSubnetGroup(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Rdsconn.DescribeDBSubnetGroupsRequest(&rds.DescribeDBSubnetGroupsInput{})
 
 	var result []Resource
 
 	p := rds.NewDescribeDBSubnetGroupsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.DBSubnetGroups {
@@ -27,6 +27,8 @@ func ListDbSubnetGroup(client *Client) ([]Resource, error) {
 				AccountID: client.AccountID,
 			})
 		}
+
+		progress.report("aws_db_subnet_group", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/devicefarm"
 )
 
-func ListDevicefarmProject(client *Client) ([]Resource, error) {
+func ListDevicefarmProject(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Devicefarmconn.ListProjectsRequest(&devicefarm.ListProjectsInput{})
 
 	var result []Resource
 
 	p := devicefarm.NewListProjectsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.Projects {
@@ -27,6 +27,8 @@ func ListDevicefarmProject(client *Client) ([]Resource, error) {
 				AccountID: client.AccountID,
 			})
 		}
+
+		progress.report("aws_devicefarm_project", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,12 +8,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/dlm"
 )
 
-func ListDlmLifecyclePolicy(client *Client) ([]Resource, error) {
+func ListDlmLifecyclePolicy(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Dlmconn.GetLifecyclePoliciesRequest(&dlm.GetLifecyclePoliciesInput{})
 
 	var result []Resource
 
-	resp, err := req.Send(context.Background())
+	resp, err := req.Send(ctx)
 	if err != nil {
 		return nil, err
 	}
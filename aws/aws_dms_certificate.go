@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/databasemigrationservice"
 )
 
-func ListDmsCertificate(client *Client) ([]Resource, error) {
+func ListDmsCertificate(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Databasemigrationserviceconn.DescribeCertificatesRequest(&databasemigrationservice.DescribeCertificatesInput{})
 
 	var result []Resource
 
 	p := databasemigrationservice.NewDescribeCertificatesPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.Certificates {
@@ -27,6 +27,8 @@ func ListDmsCertificate(client *Client) ([]Resource, error) {
 				AccountID: client.AccountID,
 			})
 		}
+
+		progress.report("aws_dms_certificate", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/databasemigrationservice"
 )
 
-func ListDmsEndpoint(client *Client) ([]Resource, error) {
+func ListDmsEndpoint(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Databasemigrationserviceconn.DescribeEndpointsRequest(&databasemigrationservice.DescribeEndpointsInput{})
 
 	var result []Resource
 
 	p := databasemigrationservice.NewDescribeEndpointsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.Endpoints {
@@ -27,6 +27,8 @@ func ListDmsEndpoint(client *Client) ([]Resource, error) {
 				AccountID: client.AccountID,
 			})
 		}
+
+		progress.report("aws_dms_endpoint", len(result))
 	}
 
 	if err := p.Err(); err != nil {
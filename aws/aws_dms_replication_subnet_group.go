@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/databasemigrationservice"
 )
 
-func ListDmsReplicationSubnetGroup(client *Client) ([]Resource, error) {
+func ListDmsReplicationSubnetGroup(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Databasemigrationserviceconn.DescribeReplicationSubnetGroupsRequest(&databasemigrationservice.DescribeReplicationSubnetGroupsInput{})
 
 	var result []Resource
 
 	p := databasemigrationservice.NewDescribeReplicationSubnetGroupsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.ReplicationSubnetGroups {
@@ -27,6 +27,8 @@ func ListDmsReplicationSubnetGroup(client *Client) ([]Resource, error) {
 				AccountID: client.AccountID,
 			})
 		}
+
+		progress.report("aws_dms_replication_subnet_group", len(result))
 	}
 
 	if err := p.Err(); err != nil {
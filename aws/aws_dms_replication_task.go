@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/databasemigrationservice"
 )
 
-func ListDmsReplicationTask(client *Client) ([]Resource, error) {
+func ListDmsReplicationTask(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Databasemigrationserviceconn.DescribeReplicationTasksRequest(&databasemigrationservice.DescribeReplicationTasksInput{})
 
 	var result []Resource
 
 	p := databasemigrationservice.NewDescribeReplicationTasksPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.ReplicationTasks {
@@ -27,6 +27,8 @@ func ListDmsReplicationTask(client *Client) ([]Resource, error) {
 				AccountID: client.AccountID,
 			})
 		}
+
+		progress.report("aws_dms_replication_task", len(result))
 	}
 
 	if err := p.Err(); err != nil {
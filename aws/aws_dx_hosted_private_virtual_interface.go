@@ -8,12 +8,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/directconnect"
 )
 
-func ListDxHostedPrivateVirtualInterface(client *Client) ([]Resource, error) {
+func ListDxHostedPrivateVirtualInterface(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Directconnectconn.DescribeVirtualInterfacesRequest(&directconnect.DescribeVirtualInterfacesInput{})
 
 	var result []Resource
 
-	resp, err := req.Send(context.Background())
+	resp, err := req.Send(ctx)
 	if err != nil {
 		return nil, err
 	}
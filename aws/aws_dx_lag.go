@@ -8,12 +8,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/directconnect"
 )
 
-func ListDxLag(client *Client) ([]Resource, error) {
+func ListDxLag(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Directconnectconn.DescribeLagsRequest(&directconnect.DescribeLagsInput{})
 
 	var result []Resource
 
-	resp, err := req.Send(context.Background())
+	resp, err := req.Send(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -8,12 +8,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 )
 
-func ListDynamodbGlobalTable(client *Client) ([]Resource, error) {
+func ListDynamodbGlobalTable(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Dynamodbconn.ListGlobalTablesRequest(&dynamodb.ListGlobalTablesInput{})
 
 	var result []Resource
 
-	resp, err := req.Send(context.Background())
+	resp, err := req.Send(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 )
 
-func ListEbsSnapshot(client *Client) ([]Resource, error) {
+func ListEbsSnapshot(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Ec2conn.DescribeSnapshotsRequest(&ec2.DescribeSnapshotsInput{})
 
 	var result []Resource
 
 	p := ec2.NewDescribeSnapshotsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.Snapshots {
@@ -36,6 +36,8 @@ func ListEbsSnapshot(client *Client) ([]Resource, error) {
 				CreatedAt: &t,
 			})
 		}
+
+		progress.report("aws_ebs_snapshot", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 )
 
-func ListEbsVolume(client *Client) ([]Resource, error) {
+func ListEbsVolume(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Ec2conn.DescribeVolumesRequest(&ec2.DescribeVolumesInput{})
 
 	var result []Resource
 
 	p := ec2.NewDescribeVolumesPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.Volumes {
@@ -34,6 +34,8 @@ func ListEbsVolume(client *Client) ([]Resource, error) {
 				CreatedAt: &t,
 			})
 		}
+
+		progress.report("aws_ebs_volume", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 )
 
-func ListEc2CapacityReservation(client *Client) ([]Resource, error) {
+func ListEc2CapacityReservation(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Ec2conn.DescribeCapacityReservationsRequest(&ec2.DescribeCapacityReservationsInput{})
 
 	var result []Resource
 
 	p := ec2.NewDescribeCapacityReservationsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.CapacityReservations {
@@ -36,6 +36,8 @@ func ListEc2CapacityReservation(client *Client) ([]Resource, error) {
 				CreatedAt: &t,
 			})
 		}
+
+		progress.report("aws_ec2_capacity_reservation", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -9,13 +9,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 )
 
-func ListEc2ClientVpnEndpoint(client *Client) ([]Resource, error) {
+func ListEc2ClientVpnEndpoint(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Ec2conn.DescribeClientVpnEndpointsRequest(&ec2.DescribeClientVpnEndpointsInput{})
 
 	var result []Resource
 
 	p := ec2.NewDescribeClientVpnEndpointsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.ClientVpnEndpoints {
@@ -38,6 +38,8 @@ func ListEc2ClientVpnEndpoint(client *Client) ([]Resource, error) {
 				CreatedAt: &t,
 			})
 		}
+
+		progress.report("aws_ec2_client_vpn_endpoint", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 )
 
-func ListEc2Fleet(client *Client) ([]Resource, error) {
+func ListEc2Fleet(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Ec2conn.DescribeFleetsRequest(&ec2.DescribeFleetsInput{})
 
 	var result []Resource
 
 	p := ec2.NewDescribeFleetsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.Fleets {
@@ -34,6 +34,8 @@ func ListEc2Fleet(client *Client) ([]Resource, error) {
 				CreatedAt: &t,
 			})
 		}
+
+		progress.report("aws_ec2_fleet", len(result))
 	}
 
 	if err := p.Err(); err != nil {
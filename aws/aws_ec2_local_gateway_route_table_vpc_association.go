@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 )
 
-func ListEc2LocalGatewayRouteTableVpcAssociation(client *Client) ([]Resource, error) {
+func ListEc2LocalGatewayRouteTableVpcAssociation(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Ec2conn.DescribeLocalGatewayRouteTableVpcAssociationsRequest(&ec2.DescribeLocalGatewayRouteTableVpcAssociationsInput{})
 
 	var result []Resource
 
 	p := ec2.NewDescribeLocalGatewayRouteTableVpcAssociationsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.LocalGatewayRouteTableVpcAssociations {
@@ -33,6 +33,8 @@ func ListEc2LocalGatewayRouteTableVpcAssociation(client *Client) ([]Resource, er
 				Tags:      tags,
 			})
 		}
+
+		progress.report("aws_ec2_local_gateway_route_table_vpc_association", len(result))
 	}
 
 	if err := p.Err(); err != nil {
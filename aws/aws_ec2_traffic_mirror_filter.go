@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 )
 
-func ListEc2TrafficMirrorFilter(client *Client) ([]Resource, error) {
+func ListEc2TrafficMirrorFilter(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Ec2conn.DescribeTrafficMirrorFiltersRequest(&ec2.DescribeTrafficMirrorFiltersInput{})
 
 	var result []Resource
 
 	p := ec2.NewDescribeTrafficMirrorFiltersPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.TrafficMirrorFilters {
@@ -33,6 +33,8 @@ func ListEc2TrafficMirrorFilter(client *Client) ([]Resource, error) {
 				Tags:      tags,
 			})
 		}
+
+		progress.report("aws_ec2_traffic_mirror_filter", len(result))
 	}
 
 	if err := p.Err(); err != nil {
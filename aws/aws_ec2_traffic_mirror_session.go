@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 )
 
-func ListEc2TrafficMirrorSession(client *Client) ([]Resource, error) {
+func ListEc2TrafficMirrorSession(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Ec2conn.DescribeTrafficMirrorSessionsRequest(&ec2.DescribeTrafficMirrorSessionsInput{})
 
 	var result []Resource
 
 	p := ec2.NewDescribeTrafficMirrorSessionsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.TrafficMirrorSessions {
@@ -35,6 +35,8 @@ func ListEc2TrafficMirrorSession(client *Client) ([]Resource, error) {
 				Tags:      tags,
 			})
 		}
+
+		progress.report("aws_ec2_traffic_mirror_session", len(result))
 	}
 
 	if err := p.Err(); err != nil {
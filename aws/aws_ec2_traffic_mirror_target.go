@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 )
 
-func ListEc2TrafficMirrorTarget(client *Client) ([]Resource, error) {
+func ListEc2TrafficMirrorTarget(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Ec2conn.DescribeTrafficMirrorTargetsRequest(&ec2.DescribeTrafficMirrorTargetsInput{})
 
 	var result []Resource
 
 	p := ec2.NewDescribeTrafficMirrorTargetsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.TrafficMirrorTargets {
@@ -35,6 +35,8 @@ func ListEc2TrafficMirrorTarget(client *Client) ([]Resource, error) {
 				Tags:      tags,
 			})
 		}
+
+		progress.report("aws_ec2_traffic_mirror_target", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 )
 
-func ListEc2TransitGateway(client *Client) ([]Resource, error) {
+func ListEc2TransitGateway(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Ec2conn.DescribeTransitGatewaysRequest(&ec2.DescribeTransitGatewaysInput{})
 
 	var result []Resource
 
 	p := ec2.NewDescribeTransitGatewaysPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.TransitGateways {
@@ -36,6 +36,8 @@ func ListEc2TransitGateway(client *Client) ([]Resource, error) {
 				CreatedAt: &t,
 			})
 		}
+
+		progress.report("aws_ec2_transit_gateway", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 )
 
-func ListEc2TransitGatewayPeeringAttachment(client *Client) ([]Resource, error) {
+func ListEc2TransitGatewayPeeringAttachment(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Ec2conn.DescribeTransitGatewayPeeringAttachmentsRequest(&ec2.DescribeTransitGatewayPeeringAttachmentsInput{})
 
 	var result []Resource
 
 	p := ec2.NewDescribeTransitGatewayPeeringAttachmentsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.TransitGatewayPeeringAttachments {
@@ -34,6 +34,8 @@ func ListEc2TransitGatewayPeeringAttachment(client *Client) ([]Resource, error)
 				CreatedAt: &t,
 			})
 		}
+
+		progress.report("aws_ec2_transit_gateway_peering_attachment", len(result))
 	}
 
 	if err := p.Err(); err != nil {
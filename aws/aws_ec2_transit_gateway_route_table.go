@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 )
 
-func ListEc2TransitGatewayRouteTable(client *Client) ([]Resource, error) {
+func ListEc2TransitGatewayRouteTable(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Ec2conn.DescribeTransitGatewayRouteTablesRequest(&ec2.DescribeTransitGatewayRouteTablesInput{})
 
 	var result []Resource
 
 	p := ec2.NewDescribeTransitGatewayRouteTablesPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.TransitGatewayRouteTables {
@@ -34,6 +34,8 @@ func ListEc2TransitGatewayRouteTable(client *Client) ([]Resource, error) {
 				CreatedAt: &t,
 			})
 		}
+
+		progress.report("aws_ec2_transit_gateway_route_table", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ecr"
 )
 
-func ListEcrRepository(client *Client) ([]Resource, error) {
+func ListEcrRepository(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Ecrconn.DescribeRepositoriesRequest(&ecr.DescribeRepositoriesInput{})
 
 	var result []Resource
 
 	p := ecr.NewDescribeRepositoriesPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.Repositories {
@@ -27,6 +27,8 @@ func ListEcrRepository(client *Client) ([]Resource, error) {
 				AccountID: client.AccountID,
 			})
 		}
+
+		progress.report("aws_ecr_repository", len(result))
 	}
 
 	if err := p.Err(); err != nil {
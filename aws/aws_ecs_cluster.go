@@ -8,12 +8,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ecs"
 )
 
-func ListEcsCluster(client *Client) ([]Resource, error) {
+func ListEcsCluster(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Ecsconn.DescribeClustersRequest(&ecs.DescribeClustersInput{})
 
 	var result []Resource
 
-	resp, err := req.Send(context.Background())
+	resp, err := req.Send(ctx)
 	if err != nil {
 		return nil, err
 	}
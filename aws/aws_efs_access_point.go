@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/efs"
 )
 
-func ListEfsAccessPoint(client *Client) ([]Resource, error) {
+func ListEfsAccessPoint(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Efsconn.DescribeAccessPointsRequest(&efs.DescribeAccessPointsInput{})
 
 	var result []Resource
 
 	p := efs.NewDescribeAccessPointsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.AccessPoints {
@@ -35,6 +35,8 @@ func ListEfsAccessPoint(client *Client) ([]Resource, error) {
 				Tags:      tags,
 			})
 		}
+
+		progress.report("aws_efs_access_point", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/efs"
 )
 
-func ListEfsFileSystem(client *Client) ([]Resource, error) {
+func ListEfsFileSystem(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Efsconn.DescribeFileSystemsRequest(&efs.DescribeFileSystemsInput{})
 
 	var result []Resource
 
 	p := efs.NewDescribeFileSystemsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.FileSystems {
@@ -36,6 +36,8 @@ func ListEfsFileSystem(client *Client) ([]Resource, error) {
 				CreatedAt: &t,
 			})
 		}
+
+		progress.report("aws_efs_file_system", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 )
 
-func ListEgressOnlyInternetGateway(client *Client) ([]Resource, error) {
+func ListEgressOnlyInternetGateway(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Ec2conn.DescribeEgressOnlyInternetGatewaysRequest(&ec2.DescribeEgressOnlyInternetGatewaysInput{})
 
 	var result []Resource
 
 	p := ec2.NewDescribeEgressOnlyInternetGatewaysPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.EgressOnlyInternetGateways {
@@ -33,6 +33,8 @@ func ListEgressOnlyInternetGateway(client *Client) ([]Resource, error) {
 				Tags:      tags,
 			})
 		}
+
+		progress.report("aws_egress_only_internet_gateway", len(result))
 	}
 
 	if err := p.Err(); err != nil {
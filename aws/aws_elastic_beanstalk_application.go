@@ -8,12 +8,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/elasticbeanstalk"
 )
 
-func ListElasticBeanstalkApplication(client *Client) ([]Resource, error) {
+func ListElasticBeanstalkApplication(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Elasticbeanstalkconn.DescribeApplicationsRequest(&elasticbeanstalk.DescribeApplicationsInput{})
 
 	var result []Resource
 
-	resp, err := req.Send(context.Background())
+	resp, err := req.Send(ctx)
 	if err != nil {
 		return nil, err
 	}
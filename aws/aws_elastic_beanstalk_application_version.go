@@ -8,12 +8,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/elasticbeanstalk"
 )
 
-func ListElasticBeanstalkApplicationVersion(client *Client) ([]Resource, error) {
+func ListElasticBeanstalkApplicationVersion(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Elasticbeanstalkconn.DescribeApplicationVersionsRequest(&elasticbeanstalk.DescribeApplicationVersionsInput{})
 
 	var result []Resource
 
-	resp, err := req.Send(context.Background())
+	resp, err := req.Send(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -8,12 +8,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/elasticbeanstalk"
 )
 
-func ListElasticBeanstalkEnvironment(client *Client) ([]Resource, error) {
+func ListElasticBeanstalkEnvironment(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Elasticbeanstalkconn.DescribeEnvironmentsRequest(&elasticbeanstalk.DescribeEnvironmentsInput{})
 
 	var result []Resource
 
-	resp, err := req.Send(context.Background())
+	resp, err := req.Send(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/elasticache"
 )
 
-func ListElasticacheReplicationGroup(client *Client) ([]Resource, error) {
+func ListElasticacheReplicationGroup(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Elasticacheconn.DescribeReplicationGroupsRequest(&elasticache.DescribeReplicationGroupsInput{})
 
 	var result []Resource
 
 	p := elasticache.NewDescribeReplicationGroupsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.ReplicationGroups {
@@ -27,6 +27,8 @@ func ListElasticacheReplicationGroup(client *Client) ([]Resource, error) {
 				AccountID: client.AccountID,
 			})
 		}
+
+		progress.report("aws_elasticache_replication_group", len(result))
 	}
 
 	if err := p.Err(); err != nil {
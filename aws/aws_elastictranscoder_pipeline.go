@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/elastictranscoder"
 )
 
-func ListElastictranscoderPipeline(client *Client) ([]Resource, error) {
+func ListElastictranscoderPipeline(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Elastictranscoderconn.ListPipelinesRequest(&elastictranscoder.ListPipelinesInput{})
 
 	var result []Resource
 
 	p := elastictranscoder.NewListPipelinesPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.Pipelines {
@@ -27,6 +27,8 @@ func ListElastictranscoderPipeline(client *Client) ([]Resource, error) {
 				AccountID: client.AccountID,
 			})
 		}
+
+		progress.report("aws_elastictranscoder_pipeline", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/elastictranscoder"
 )
 
-func ListElastictranscoderPreset(client *Client) ([]Resource, error) {
+func ListElastictranscoderPreset(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Elastictranscoderconn.ListPresetsRequest(&elastictranscoder.ListPresetsInput{})
 
 	var result []Resource
 
 	p := elastictranscoder.NewListPresetsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.Presets {
@@ -27,6 +27,8 @@ func ListElastictranscoderPreset(client *Client) ([]Resource, error) {
 				AccountID: client.AccountID,
 			})
 		}
+
+		progress.report("aws_elastictranscoder_preset", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing"
 )
 
-func ListElb(client *Client) ([]Resource, error) {
+func ListElb(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Elasticloadbalancingconn.DescribeLoadBalancersRequest(&elasticloadbalancing.DescribeLoadBalancersInput{})
 
 	var result []Resource
 
 	p := elasticloadbalancing.NewDescribeLoadBalancersPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.LoadBalancerDescriptions {
@@ -30,6 +30,8 @@ func ListElb(client *Client) ([]Resource, error) {
 				CreatedAt: &t,
 			})
 		}
+
+		progress.report("aws_elb", len(result))
 	}
 
 	if err := p.Err(); err != nil {
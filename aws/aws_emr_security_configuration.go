@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/emr"
 )
 
-func ListEmrSecurityConfiguration(client *Client) ([]Resource, error) {
+func ListEmrSecurityConfiguration(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Emrconn.ListSecurityConfigurationsRequest(&emr.ListSecurityConfigurationsInput{})
 
 	var result []Resource
 
 	p := emr.NewListSecurityConfigurationsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.SecurityConfigurations {
@@ -27,6 +27,8 @@ func ListEmrSecurityConfiguration(client *Client) ([]Resource, error) {
 				AccountID: client.AccountID,
 			})
 		}
+
+		progress.report("aws_emr_security_configuration", len(result))
 	}
 
 	if err := p.Err(); err != nil {
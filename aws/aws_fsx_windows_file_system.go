@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/fsx"
 )
 
-func ListFsxWindowsFileSystem(client *Client) ([]Resource, error) {
+func ListFsxWindowsFileSystem(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Fsxconn.DescribeFileSystemsRequest(&fsx.DescribeFileSystemsInput{})
 
 	var result []Resource
 
 	p := fsx.NewDescribeFileSystemsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.FileSystems {
@@ -36,6 +36,8 @@ func ListFsxWindowsFileSystem(client *Client) ([]Resource, error) {
 				CreatedAt: &t,
 			})
 		}
+
+		progress.report("aws_fsx_windows_file_system", len(result))
 	}
 
 	if err := p.Err(); err != nil {
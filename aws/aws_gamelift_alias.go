@@ -8,12 +8,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/gamelift"
 )
 
-func ListGameliftAlias(client *Client) ([]Resource, error) {
+func ListGameliftAlias(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Gameliftconn.ListAliasesRequest(&gamelift.ListAliasesInput{})
 
 	var result []Resource
 
-	resp, err := req.Send(context.Background())
+	resp, err := req.Send(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -8,12 +8,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/gamelift"
 )
 
-func ListGameliftBuild(client *Client) ([]Resource, error) {
+func ListGameliftBuild(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Gameliftconn.ListBuildsRequest(&gamelift.ListBuildsInput{})
 
 	var result []Resource
 
-	resp, err := req.Send(context.Background())
+	resp, err := req.Send(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -8,12 +8,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/gamelift"
 )
 
-func ListGameliftGameSessionQueue(client *Client) ([]Resource, error) {
+func ListGameliftGameSessionQueue(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Gameliftconn.DescribeGameSessionQueuesRequest(&gamelift.DescribeGameSessionQueuesInput{})
 
 	var result []Resource
 
-	resp, err := req.Send(context.Background())
+	resp, err := req.Send(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -8,12 +8,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/globalaccelerator"
 )
 
-func ListGlobalacceleratorAccelerator(client *Client) ([]Resource, error) {
+func ListGlobalacceleratorAccelerator(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Globalacceleratorconn.ListAcceleratorsRequest(&globalaccelerator.ListAcceleratorsInput{})
 
 	var result []Resource
 
-	resp, err := req.Send(context.Background())
+	resp, err := req.Send(ctx)
 	if err != nil {
 		return nil, err
 	}
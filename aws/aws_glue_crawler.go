@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/glue"
 )
 
-func ListGlueCrawler(client *Client) ([]Resource, error) {
+func ListGlueCrawler(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Glueconn.GetCrawlersRequest(&glue.GetCrawlersInput{})
 
 	var result []Resource
 
 	p := glue.NewGetCrawlersPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.Crawlers {
@@ -30,6 +30,8 @@ func ListGlueCrawler(client *Client) ([]Resource, error) {
 				CreatedAt: &t,
 			})
 		}
+
+		progress.report("aws_glue_crawler", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/glue"
 )
 
-func ListGlueJob(client *Client) ([]Resource, error) {
+func ListGlueJob(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Glueconn.GetJobsRequest(&glue.GetJobsInput{})
 
 	var result []Resource
 
 	p := glue.NewGetJobsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.Jobs {
@@ -27,6 +27,8 @@ func ListGlueJob(client *Client) ([]Resource, error) {
 				AccountID: client.AccountID,
 			})
 		}
+
+		progress.report("aws_glue_job", len(result))
 	}
 
 	if err := p.Err(); err != nil {
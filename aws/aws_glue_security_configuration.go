@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/glue"
 )
 
-func ListGlueSecurityConfiguration(client *Client) ([]Resource, error) {
+func ListGlueSecurityConfiguration(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Glueconn.GetSecurityConfigurationsRequest(&glue.GetSecurityConfigurationsInput{})
 
 	var result []Resource
 
 	p := glue.NewGetSecurityConfigurationsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.SecurityConfigurations {
@@ -27,6 +27,8 @@ func ListGlueSecurityConfiguration(client *Client) ([]Resource, error) {
 				AccountID: client.AccountID,
 			})
 		}
+
+		progress.report("aws_glue_security_configuration", len(result))
 	}
 
 	if err := p.Err(); err != nil {
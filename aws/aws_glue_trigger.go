@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/glue"
 )
 
-func ListGlueTrigger(client *Client) ([]Resource, error) {
+func ListGlueTrigger(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Glueconn.GetTriggersRequest(&glue.GetTriggersInput{})
 
 	var result []Resource
 
 	p := glue.NewGetTriggersPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.Triggers {
@@ -27,6 +27,8 @@ func ListGlueTrigger(client *Client) ([]Resource, error) {
 				AccountID: client.AccountID,
 			})
 		}
+
+		progress.report("aws_glue_trigger", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/iam"
 )
 
-func ListIamAccessKey(client *Client) ([]Resource, error) {
+func ListIamAccessKey(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Iamconn.ListAccessKeysRequest(&iam.ListAccessKeysInput{})
 
 	var result []Resource
 
 	p := iam.NewListAccessKeysPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.AccessKeyMetadata {
@@ -30,6 +30,8 @@ func ListIamAccessKey(client *Client) ([]Resource, error) {
 				CreatedAt: &t,
 			})
 		}
+
+		progress.report("aws_iam_access_key", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/iam"
 )
 
-func ListIamGroup(client *Client) ([]Resource, error) {
+func ListIamGroup(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Iamconn.ListGroupsRequest(&iam.ListGroupsInput{})
 
 	var result []Resource
 
 	p := iam.NewListGroupsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.Groups {
@@ -30,6 +30,8 @@ func ListIamGroup(client *Client) ([]Resource, error) {
 				CreatedAt: &t,
 			})
 		}
+
+		progress.report("aws_iam_group", len(result))
 	}
 
 	if err := p.Err(); err != nil {
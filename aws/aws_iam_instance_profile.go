@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/iam"
 )
 
-func ListIamInstanceProfile(client *Client) ([]Resource, error) {
+func ListIamInstanceProfile(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Iamconn.ListInstanceProfilesRequest(&iam.ListInstanceProfilesInput{})
 
 	var result []Resource
 
 	p := iam.NewListInstanceProfilesPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.InstanceProfiles {
@@ -30,6 +30,8 @@ func ListIamInstanceProfile(client *Client) ([]Resource, error) {
 				CreatedAt: &t,
 			})
 		}
+
+		progress.report("aws_iam_instance_profile", len(result))
 	}
 
 	if err := p.Err(); err != nil {
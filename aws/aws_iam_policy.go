@@ -8,7 +8,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/iam"
 )
 
-func ListIamPolicy(client *Client) ([]Resource, error) {
+func ListIamPolicy(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Iamconn.ListPoliciesRequest(&iam.ListPoliciesInput{
 		Scope: "Local",
 	})
@@ -16,7 +16,7 @@ func ListIamPolicy(client *Client) ([]Resource, error) {
 	var result []Resource
 
 	p := iam.NewListPoliciesPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.Policies {
@@ -32,6 +32,8 @@ func ListIamPolicy(client *Client) ([]Resource, error) {
 				CreatedAt: &t,
 			})
 		}
+
+		progress.report("aws_iam_policy", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/iam"
 )
 
-func ListIamServerCertificate(client *Client) ([]Resource, error) {
+func ListIamServerCertificate(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Iamconn.ListServerCertificatesRequest(&iam.ListServerCertificatesInput{})
 
 	var result []Resource
 
 	p := iam.NewListServerCertificatesPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.ServerCertificateMetadataList {
@@ -27,6 +27,8 @@ func ListIamServerCertificate(client *Client) ([]Resource, error) {
 				AccountID: client.AccountID,
 			})
 		}
+
+		progress.report("aws_iam_server_certificate", len(result))
 	}
 
 	if err := p.Err(); err != nil {
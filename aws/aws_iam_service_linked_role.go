@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/iam"
 )
 
-func ListIamServiceLinkedRole(client *Client) ([]Resource, error) {
+func ListIamServiceLinkedRole(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Iamconn.ListRolesRequest(&iam.ListRolesInput{})
 
 	var result []Resource
 
 	p := iam.NewListRolesPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.Roles {
@@ -34,6 +34,8 @@ func ListIamServiceLinkedRole(client *Client) ([]Resource, error) {
 				CreatedAt: &t,
 			})
 		}
+
+		progress.report("aws_iam_service_linked_role", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/iam"
 )
 
-func ListIamUser(client *Client) ([]Resource, error) {
+func ListIamUser(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Iamconn.ListUsersRequest(&iam.ListUsersInput{})
 
 	var result []Resource
 
 	p := iam.NewListUsersPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.Users {
@@ -34,6 +34,8 @@ func ListIamUser(client *Client) ([]Resource, error) {
 				CreatedAt: &t,
 			})
 		}
+
+		progress.report("aws_iam_user", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -10,13 +10,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 )
 
-func ListInstance(client *Client) ([]Resource, error) {
+func ListInstance(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Ec2conn.DescribeInstancesRequest(&ec2.DescribeInstancesInput{})
 
 	var result []Resource
 
 	p := ec2.NewDescribeInstancesPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, reservations := range page.Reservations {
@@ -42,6 +42,8 @@ func ListInstance(client *Client) ([]Resource, error) {
 				})
 			}
 		}
+
+		progress.report("aws_instance", len(result))
 	}
 
 	if err := p.Err(); err != nil {
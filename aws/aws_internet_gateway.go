@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 )
 
-func ListInternetGateway(client *Client) ([]Resource, error) {
+func ListInternetGateway(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Ec2conn.DescribeInternetGatewaysRequest(&ec2.DescribeInternetGatewaysInput{})
 
 	var result []Resource
 
 	p := ec2.NewDescribeInternetGatewaysPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.InternetGateways {
@@ -35,6 +35,8 @@ func ListInternetGateway(client *Client) ([]Resource, error) {
 				Tags:      tags,
 			})
 		}
+
+		progress.report("aws_internet_gateway", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,12 +8,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/iot"
 )
 
-func ListIotThing(client *Client) ([]Resource, error) {
+func ListIotThing(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Iotconn.ListThingsRequest(&iot.ListThingsInput{})
 
 	var result []Resource
 
-	resp, err := req.Send(context.Background())
+	resp, err := req.Send(ctx)
 	if err != nil {
 		return nil, err
 	}
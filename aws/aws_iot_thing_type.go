@@ -8,12 +8,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/iot"
 )
 
-func ListIotThingType(client *Client) ([]Resource, error) {
+func ListIotThingType(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Iotconn.ListThingTypesRequest(&iot.ListThingTypesInput{})
 
 	var result []Resource
 
-	resp, err := req.Send(context.Background())
+	resp, err := req.Send(ctx)
 	if err != nil {
 		return nil, err
 	}
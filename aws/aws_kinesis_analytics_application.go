@@ -8,12 +8,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/kinesisanalytics"
 )
 
-func ListKinesisAnalyticsApplication(client *Client) ([]Resource, error) {
+func ListKinesisAnalyticsApplication(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Kinesisanalyticsconn.ListApplicationsRequest(&kinesisanalytics.ListApplicationsInput{})
 
 	var result []Resource
 
-	resp, err := req.Send(context.Background())
+	resp, err := req.Send(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/kms"
 )
 
-func ListKmsAlias(client *Client) ([]Resource, error) {
+func ListKmsAlias(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Kmsconn.ListAliasesRequest(&kms.ListAliasesInput{})
 
 	var result []Resource
 
 	p := kms.NewListAliasesPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.Aliases {
@@ -27,6 +27,8 @@ func ListKmsAlias(client *Client) ([]Resource, error) {
 				AccountID: client.AccountID,
 			})
 		}
+
+		progress.report("aws_kms_alias", len(result))
 	}
 
 	if err := p.Err(); err != nil {
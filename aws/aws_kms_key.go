@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/kms"
 )
 
-func ListKmsKey(client *Client) ([]Resource, error) {
+func ListKmsKey(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Kmsconn.ListKeysRequest(&kms.ListKeysInput{})
 
 	var result []Resource
 
 	p := kms.NewListKeysPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.Keys {
@@ -27,6 +27,8 @@ func ListKmsKey(client *Client) ([]Resource, error) {
 				AccountID: client.AccountID,
 			})
 		}
+
+		progress.report("aws_kms_key", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/lambda"
 )
 
-func ListLambdaEventSourceMapping(client *Client) ([]Resource, error) {
+func ListLambdaEventSourceMapping(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Lambdaconn.ListEventSourceMappingsRequest(&lambda.ListEventSourceMappingsInput{})
 
 	var result []Resource
 
 	p := lambda.NewListEventSourceMappingsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.EventSourceMappings {
@@ -27,6 +27,8 @@ func ListLambdaEventSourceMapping(client *Client) ([]Resource, error) {
 				AccountID: client.AccountID,
 			})
 		}
+
+		progress.report("aws_lambda_event_source_mapping", len(result))
 	}
 
 	if err := p.Err(); err != nil {
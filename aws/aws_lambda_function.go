@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/lambda"
 )
 
-func ListLambdaFunction(client *Client) ([]Resource, error) {
+func ListLambdaFunction(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Lambdaconn.ListFunctionsRequest(&lambda.ListFunctionsInput{})
 
 	var result []Resource
 
 	p := lambda.NewListFunctionsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.Functions {
@@ -27,6 +27,8 @@ func ListLambdaFunction(client *Client) ([]Resource, error) {
 				AccountID: client.AccountID,
 			})
 		}
+
+		progress.report("aws_lambda_function", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
 )
 
-func ListLaunchConfiguration(client *Client) ([]Resource, error) {
+func ListLaunchConfiguration(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Autoscalingconn.DescribeLaunchConfigurationsRequest(&autoscaling.DescribeLaunchConfigurationsInput{})
 
 	var result []Resource
 
 	p := autoscaling.NewDescribeLaunchConfigurationsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.LaunchConfigurations {
@@ -30,6 +30,8 @@ func ListLaunchConfiguration(client *Client) ([]Resource, error) {
 				CreatedAt: &t,
 			})
 		}
+
+		progress.report("aws_launch_configuration", len(result))
 	}
 
 	if err := p.Err(); err != nil {
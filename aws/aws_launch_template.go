@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 )
 
-func ListLaunchTemplate(client *Client) ([]Resource, error) {
+func ListLaunchTemplate(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Ec2conn.DescribeLaunchTemplatesRequest(&ec2.DescribeLaunchTemplatesInput{})
 
 	var result []Resource
 
 	p := ec2.NewDescribeLaunchTemplatesPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.LaunchTemplates {
@@ -34,6 +34,8 @@ func ListLaunchTemplate(client *Client) ([]Resource, error) {
 				CreatedAt: &t,
 			})
 		}
+
+		progress.report("aws_launch_template", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,12 +8,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/licensemanager"
 )
 
-func ListLicensemanagerLicenseConfiguration(client *Client) ([]Resource, error) {
+func ListLicensemanagerLicenseConfiguration(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Licensemanagerconn.ListLicenseConfigurationsRequest(&licensemanager.ListLicenseConfigurationsInput{})
 
 	var result []Resource
 
-	resp, err := req.Send(context.Background())
+	resp, err := req.Send(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -8,12 +8,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/lightsail"
 )
 
-func ListLightsailDomain(client *Client) ([]Resource, error) {
+func ListLightsailDomain(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Lightsailconn.GetDomainsRequest(&lightsail.GetDomainsInput{})
 
 	var result []Resource
 
-	resp, err := req.Send(context.Background())
+	resp, err := req.Send(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -8,12 +8,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/lightsail"
 )
 
-func ListLightsailInstance(client *Client) ([]Resource, error) {
+func ListLightsailInstance(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Lightsailconn.GetInstancesRequest(&lightsail.GetInstancesInput{})
 
 	var result []Resource
 
-	resp, err := req.Send(context.Background())
+	resp, err := req.Send(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/mediaconvert"
 )
 
-func ListMediaConvertQueue(client *Client) ([]Resource, error) {
+func ListMediaConvertQueue(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Mediaconvertconn.ListQueuesRequest(&mediaconvert.ListQueuesInput{})
 
 	var result []Resource
 
 	p := mediaconvert.NewListQueuesPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.Queues {
@@ -27,6 +27,8 @@ func ListMediaConvertQueue(client *Client) ([]Resource, error) {
 				AccountID: client.AccountID,
 			})
 		}
+
+		progress.report("aws_media_convert_queue", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/mediapackage"
 )
 
-func ListMediaPackageChannel(client *Client) ([]Resource, error) {
+func ListMediaPackageChannel(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Mediapackageconn.ListChannelsRequest(&mediapackage.ListChannelsInput{})
 
 	var result []Resource
 
 	p := mediapackage.NewListChannelsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.Channels {
@@ -33,6 +33,8 @@ func ListMediaPackageChannel(client *Client) ([]Resource, error) {
 				Tags:      tags,
 			})
 		}
+
+		progress.report("aws_media_package_channel", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/mediastore"
 )
 
-func ListMediaStoreContainer(client *Client) ([]Resource, error) {
+func ListMediaStoreContainer(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Mediastoreconn.ListContainersRequest(&mediastore.ListContainersInput{})
 
 	var result []Resource
 
 	p := mediastore.NewListContainersPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.Containers {
@@ -30,6 +30,8 @@ func ListMediaStoreContainer(client *Client) ([]Resource, error) {
 				CreatedAt: &t,
 			})
 		}
+
+		progress.report("aws_media_store_container", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,12 +8,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/mq"
 )
 
-func ListMqBroker(client *Client) ([]Resource, error) {
+func ListMqBroker(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Mqconn.ListBrokersRequest(&mq.ListBrokersInput{})
 
 	var result []Resource
 
-	resp, err := req.Send(context.Background())
+	resp, err := req.Send(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/kafka"
 )
 
-func ListMskCluster(client *Client) ([]Resource, error) {
+func ListMskCluster(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Kafkaconn.ListClustersRequest(&kafka.ListClustersInput{})
 
 	var result []Resource
 
 	p := kafka.NewListClustersPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.ClusterInfoList {
@@ -34,6 +34,8 @@ func ListMskCluster(client *Client) ([]Resource, error) {
 				CreatedAt: &t,
 			})
 		}
+
+		progress.report("aws_msk_cluster", len(result))
 	}
 
 	if err := p.Err(); err != nil {
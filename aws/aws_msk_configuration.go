@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/kafka"
 )
 
-func ListMskConfiguration(client *Client) ([]Resource, error) {
+func ListMskConfiguration(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Kafkaconn.ListConfigurationsRequest(&kafka.ListConfigurationsInput{})
 
 	var result []Resource
 
 	p := kafka.NewListConfigurationsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.Configurations {
@@ -30,6 +30,8 @@ func ListMskConfiguration(client *Client) ([]Resource, error) {
 				CreatedAt: &t,
 			})
 		}
+
+		progress.report("aws_msk_configuration", len(result))
 	}
 
 	if err := p.Err(); err != nil {
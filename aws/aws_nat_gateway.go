@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 )
 
-func ListNatGateway(client *Client) ([]Resource, error) {
+func ListNatGateway(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Ec2conn.DescribeNatGatewaysRequest(&ec2.DescribeNatGatewaysInput{})
 
 	var result []Resource
 
 	p := ec2.NewDescribeNatGatewaysPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.NatGateways {
@@ -34,6 +34,8 @@ func ListNatGateway(client *Client) ([]Resource, error) {
 				CreatedAt: &t,
 			})
 		}
+
+		progress.report("aws_nat_gateway", len(result))
 	}
 
 	if err := p.Err(); err != nil {
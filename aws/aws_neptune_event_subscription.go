@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/neptune"
 )
 
-func ListNeptuneEventSubscription(client *Client) ([]Resource, error) {
+func ListNeptuneEventSubscription(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Neptuneconn.DescribeEventSubscriptionsRequest(&neptune.DescribeEventSubscriptionsInput{})
 
 	var result []Resource
 
 	p := neptune.NewDescribeEventSubscriptionsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.EventSubscriptionsList {
@@ -27,6 +27,8 @@ func ListNeptuneEventSubscription(client *Client) ([]Resource, error) {
 				AccountID: client.AccountID,
 			})
 		}
+
+		progress.report("aws_neptune_event_subscription", len(result))
 	}
 
 	if err := p.Err(); err != nil {
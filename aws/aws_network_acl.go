@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 )
 
-func ListNetworkAcl(client *Client) ([]Resource, error) {
+func ListNetworkAcl(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Ec2conn.DescribeNetworkAclsRequest(&ec2.DescribeNetworkAclsInput{})
 
 	var result []Resource
 
 	p := ec2.NewDescribeNetworkAclsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.NetworkAcls {
@@ -35,6 +35,8 @@ func ListNetworkAcl(client *Client) ([]Resource, error) {
 				Tags:      tags,
 			})
 		}
+
+		progress.report("aws_network_acl", len(result))
 	}
 
 	if err := p.Err(); err != nil {
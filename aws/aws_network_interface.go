@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 )
 
-func ListNetworkInterface(client *Client) ([]Resource, error) {
+func ListNetworkInterface(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Ec2conn.DescribeNetworkInterfacesRequest(&ec2.DescribeNetworkInterfacesInput{})
 
 	var result []Resource
 
 	p := ec2.NewDescribeNetworkInterfacesPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.NetworkInterfaces {
@@ -30,6 +30,8 @@ func ListNetworkInterface(client *Client) ([]Resource, error) {
 				AccountID: client.AccountID,
 			})
 		}
+
+		progress.report("aws_network_interface", len(result))
 	}
 
 	if err := p.Err(); err != nil {
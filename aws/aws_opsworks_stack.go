@@ -8,12 +8,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/opsworks"
 )
 
-func ListOpsworksStack(client *Client) ([]Resource, error) {
+func ListOpsworksStack(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Opsworksconn.DescribeStacksRequest(&opsworks.DescribeStacksInput{})
 
 	var result []Resource
 
-	resp, err := req.Send(context.Background())
+	resp, err := req.Send(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/qldb"
 )
 
-func ListQldbLedger(client *Client) ([]Resource, error) {
+func ListQldbLedger(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Qldbconn.ListLedgersRequest(&qldb.ListLedgersInput{})
 
 	var result []Resource
 
 	p := qldb.NewListLedgersPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.Ledgers {
@@ -27,6 +27,8 @@ func ListQldbLedger(client *Client) ([]Resource, error) {
 				AccountID: client.AccountID,
 			})
 		}
+
+		progress.report("aws_qldb_ledger", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/rds"
 )
 
-func ListRdsGlobalCluster(client *Client) ([]Resource, error) {
+func ListRdsGlobalCluster(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Rdsconn.DescribeGlobalClustersRequest(&rds.DescribeGlobalClustersInput{})
 
 	var result []Resource
 
 	p := rds.NewDescribeGlobalClustersPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.GlobalClusters {
@@ -27,6 +27,8 @@ func ListRdsGlobalCluster(client *Client) ([]Resource, error) {
 				AccountID: client.AccountID,
 			})
 		}
+
+		progress.report("aws_rds_global_cluster", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/redshift"
 )
 
-func ListRedshiftCluster(client *Client) ([]Resource, error) {
+func ListRedshiftCluster(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Redshiftconn.DescribeClustersRequest(&redshift.DescribeClustersInput{})
 
 	var result []Resource
 
 	p := redshift.NewDescribeClustersPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.Clusters {
@@ -33,6 +33,8 @@ func ListRedshiftCluster(client *Client) ([]Resource, error) {
 				Tags:      tags,
 			})
 		}
+
+		progress.report("aws_redshift_cluster", len(result))
 	}
 
 	if err := p.Err(); err != nil {
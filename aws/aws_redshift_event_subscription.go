@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/redshift"
 )
 
-func ListRedshiftEventSubscription(client *Client) ([]Resource, error) {
+func ListRedshiftEventSubscription(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Redshiftconn.DescribeEventSubscriptionsRequest(&redshift.DescribeEventSubscriptionsInput{})
 
 	var result []Resource
 
 	p := redshift.NewDescribeEventSubscriptionsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.EventSubscriptionsList {
@@ -33,6 +33,8 @@ func ListRedshiftEventSubscription(client *Client) ([]Resource, error) {
 				Tags:      tags,
 			})
 		}
+
+		progress.report("aws_redshift_event_subscription", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,12 +8,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/redshift"
 )
 
-func ListRedshiftSnapshotCopyGrant(client *Client) ([]Resource, error) {
+func ListRedshiftSnapshotCopyGrant(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Redshiftconn.DescribeSnapshotCopyGrantsRequest(&redshift.DescribeSnapshotCopyGrantsInput{})
 
 	var result []Resource
 
-	resp, err := req.Send(context.Background())
+	resp, err := req.Send(ctx)
 	if err != nil {
 		return nil, err
 	}
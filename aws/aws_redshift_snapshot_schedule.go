@@ -8,12 +8,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/redshift"
 )
 
-func ListRedshiftSnapshotSchedule(client *Client) ([]Resource, error) {
+func ListRedshiftSnapshotSchedule(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Redshiftconn.DescribeSnapshotSchedulesRequest(&redshift.DescribeSnapshotSchedulesInput{})
 
 	var result []Resource
 
-	resp, err := req.Send(context.Background())
+	resp, err := req.Send(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/route53"
 )
 
-func ListRoute53HealthCheck(client *Client) ([]Resource, error) {
+func ListRoute53HealthCheck(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Route53conn.ListHealthChecksRequest(&route53.ListHealthChecksInput{})
 
 	var result []Resource
 
 	p := route53.NewListHealthChecksPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.HealthChecks {
@@ -27,6 +27,8 @@ func ListRoute53HealthCheck(client *Client) ([]Resource, error) {
 				AccountID: client.AccountID,
 			})
 		}
+
+		progress.report("aws_route53_health_check", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -9,13 +9,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/route53resolver"
 )
 
-func ListRoute53ResolverEndpoint(client *Client) ([]Resource, error) {
+func ListRoute53ResolverEndpoint(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Route53resolverconn.ListResolverEndpointsRequest(&route53resolver.ListResolverEndpointsInput{})
 
 	var result []Resource
 
 	p := route53resolver.NewListResolverEndpointsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.ResolverEndpoints {
@@ -34,6 +34,8 @@ func ListRoute53ResolverEndpoint(client *Client) ([]Resource, error) {
 				CreatedAt: &t,
 			})
 		}
+
+		progress.report("aws_route53_resolver_endpoint", len(result))
 	}
 
 	if err := p.Err(); err != nil {
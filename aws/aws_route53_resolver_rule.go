@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/route53resolver"
 )
 
-func ListRoute53ResolverRule(client *Client) ([]Resource, error) {
+func ListRoute53ResolverRule(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Route53resolverconn.ListResolverRulesRequest(&route53resolver.ListResolverRulesInput{})
 
 	var result []Resource
 
 	p := route53resolver.NewListResolverRulesPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.ResolverRules {
@@ -30,6 +30,8 @@ func ListRoute53ResolverRule(client *Client) ([]Resource, error) {
 				AccountID: client.AccountID,
 			})
 		}
+
+		progress.report("aws_route53_resolver_rule", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/route53resolver"
 )
 
-func ListRoute53ResolverRuleAssociation(client *Client) ([]Resource, error) {
+func ListRoute53ResolverRuleAssociation(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Route53resolverconn.ListResolverRuleAssociationsRequest(&route53resolver.ListResolverRuleAssociationsInput{})
 
 	var result []Resource
 
 	p := route53resolver.NewListResolverRuleAssociationsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.ResolverRuleAssociations {
@@ -27,6 +27,8 @@ func ListRoute53ResolverRuleAssociation(client *Client) ([]Resource, error) {
 				AccountID: client.AccountID,
 			})
 		}
+
+		progress.report("aws_route53_resolver_rule_association", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/route53"
 )
 
-func ListRoute53Zone(client *Client) ([]Resource, error) {
+func ListRoute53Zone(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Route53conn.ListHostedZonesRequest(&route53.ListHostedZonesInput{})
 
 	var result []Resource
 
 	p := route53.NewListHostedZonesPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.HostedZones {
@@ -27,6 +27,8 @@ func ListRoute53Zone(client *Client) ([]Resource, error) {
 				AccountID: client.AccountID,
 			})
 		}
+
+		progress.report("aws_route53_zone", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 )
 
-func ListRouteTable(client *Client) ([]Resource, error) {
+func ListRouteTable(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Ec2conn.DescribeRouteTablesRequest(&ec2.DescribeRouteTablesInput{})
 
 	var result []Resource
 
 	p := ec2.NewDescribeRouteTablesPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.RouteTables {
@@ -35,6 +35,8 @@ func ListRouteTable(client *Client) ([]Resource, error) {
 				Tags:      tags,
 			})
 		}
+
+		progress.report("aws_route_table", len(result))
 	}
 
 	if err := p.Err(); err != nil {
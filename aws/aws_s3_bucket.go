@@ -8,12 +8,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 )
 
-func ListS3Bucket(client *Client) ([]Resource, error) {
+func ListS3Bucket(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.S3conn.ListBucketsRequest(&s3.ListBucketsInput{})
 
 	var result []Resource
 
-	resp, err := req.Send(context.Background())
+	resp, err := req.Send(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/sagemaker"
 )
 
-func ListSagemakerEndpoint(client *Client) ([]Resource, error) {
+func ListSagemakerEndpoint(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Sagemakerconn.ListEndpointsRequest(&sagemaker.ListEndpointsInput{})
 
 	var result []Resource
 
 	p := sagemaker.NewListEndpointsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.Endpoints {
@@ -30,6 +30,8 @@ func ListSagemakerEndpoint(client *Client) ([]Resource, error) {
 				CreatedAt: &t,
 			})
 		}
+
+		progress.report("aws_sagemaker_endpoint", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/sagemaker"
 )
 
-func ListSagemakerModel(client *Client) ([]Resource, error) {
+func ListSagemakerModel(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Sagemakerconn.ListModelsRequest(&sagemaker.ListModelsInput{})
 
 	var result []Resource
 
 	p := sagemaker.NewListModelsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.Models {
@@ -30,6 +30,8 @@ func ListSagemakerModel(client *Client) ([]Resource, error) {
 				CreatedAt: &t,
 			})
 		}
+
+		progress.report("aws_sagemaker_model", len(result))
 	}
 
 	if err := p.Err(); err != nil {
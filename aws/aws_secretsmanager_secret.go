@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
 )
 
-func ListSecretsmanagerSecret(client *Client) ([]Resource, error) {
+func ListSecretsmanagerSecret(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Secretsmanagerconn.ListSecretsRequest(&secretsmanager.ListSecretsInput{})
 
 	var result []Resource
 
 	p := secretsmanager.NewListSecretsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.SecretList {
@@ -33,6 +33,8 @@ func ListSecretsmanagerSecret(client *Client) ([]Resource, error) {
 				Tags:      tags,
 			})
 		}
+
+		progress.report("aws_secretsmanager_secret", len(result))
 	}
 
 	if err := p.Err(); err != nil {
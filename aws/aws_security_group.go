@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 )
 
-func ListSecurityGroup(client *Client) ([]Resource, error) {
+func ListSecurityGroup(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Ec2conn.DescribeSecurityGroupsRequest(&ec2.DescribeSecurityGroupsInput{})
 
 	var result []Resource
 
 	p := ec2.NewDescribeSecurityGroupsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.SecurityGroups {
@@ -35,6 +35,8 @@ func ListSecurityGroup(client *Client) ([]Resource, error) {
 				Tags:      tags,
 			})
 		}
+
+		progress.report("aws_security_group", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/servicediscovery"
 )
 
-func ListServiceDiscoveryService(client *Client) ([]Resource, error) {
+func ListServiceDiscoveryService(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Servicediscoveryconn.ListServicesRequest(&servicediscovery.ListServicesInput{})
 
 	var result []Resource
 
 	p := servicediscovery.NewListServicesPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.Services {
@@ -30,6 +30,8 @@ func ListServiceDiscoveryService(client *Client) ([]Resource, error) {
 				CreatedAt: &t,
 			})
 		}
+
+		progress.report("aws_service_discovery_service", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/servicecatalog"
 )
 
-func ListServicecatalogPortfolio(client *Client) ([]Resource, error) {
+func ListServicecatalogPortfolio(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Servicecatalogconn.ListPortfoliosRequest(&servicecatalog.ListPortfoliosInput{})
 
 	var result []Resource
 
 	p := servicecatalog.NewListPortfoliosPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.PortfolioDetails {
@@ -30,6 +30,8 @@ func ListServicecatalogPortfolio(client *Client) ([]Resource, error) {
 				CreatedAt: &t,
 			})
 		}
+
+		progress.report("aws_servicecatalog_portfolio", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,12 +8,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ses"
 )
 
-func ListSesConfigurationSet(client *Client) ([]Resource, error) {
+func ListSesConfigurationSet(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Sesconn.ListConfigurationSetsRequest(&ses.ListConfigurationSetsInput{})
 
 	var result []Resource
 
-	resp, err := req.Send(context.Background())
+	resp, err := req.Send(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -8,12 +8,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ses"
 )
 
-func ListSesReceiptFilter(client *Client) ([]Resource, error) {
+func ListSesReceiptFilter(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Sesconn.ListReceiptFiltersRequest(&ses.ListReceiptFiltersInput{})
 
 	var result []Resource
 
-	resp, err := req.Send(context.Background())
+	resp, err := req.Send(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -8,12 +8,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ses"
 )
 
-func ListSesReceiptRuleSet(client *Client) ([]Resource, error) {
+func ListSesReceiptRuleSet(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Sesconn.ListReceiptRuleSetsRequest(&ses.ListReceiptRuleSetsInput{})
 
 	var result []Resource
 
-	resp, err := req.Send(context.Background())
+	resp, err := req.Send(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -8,12 +8,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ses"
 )
 
-func ListSesTemplate(client *Client) ([]Resource, error) {
+func ListSesTemplate(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Sesconn.ListTemplatesRequest(&ses.ListTemplatesInput{})
 
 	var result []Resource
 
-	resp, err := req.Send(context.Background())
+	resp, err := req.Send(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/sfn"
 )
 
-func ListSfnActivity(client *Client) ([]Resource, error) {
+func ListSfnActivity(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Sfnconn.ListActivitiesRequest(&sfn.ListActivitiesInput{})
 
 	var result []Resource
 
 	p := sfn.NewListActivitiesPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.Activities {
@@ -30,6 +30,8 @@ func ListSfnActivity(client *Client) ([]Resource, error) {
 				CreatedAt: &t,
 			})
 		}
+
+		progress.report("aws_sfn_activity", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/sfn"
 )
 
-func ListSfnStateMachine(client *Client) ([]Resource, error) {
+func ListSfnStateMachine(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Sfnconn.ListStateMachinesRequest(&sfn.ListStateMachinesInput{})
 
 	var result []Resource
 
 	p := sfn.NewListStateMachinesPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.StateMachines {
@@ -30,6 +30,8 @@ func ListSfnStateMachine(client *Client) ([]Resource, error) {
 				CreatedAt: &t,
 			})
 		}
+
+		progress.report("aws_sfn_state_machine", len(result))
 	}
 
 	if err := p.Err(); err != nil {
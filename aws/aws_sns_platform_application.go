@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/sns"
 )
 
-func ListSnsPlatformApplication(client *Client) ([]Resource, error) {
+func ListSnsPlatformApplication(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Snsconn.ListPlatformApplicationsRequest(&sns.ListPlatformApplicationsInput{})
 
 	var result []Resource
 
 	p := sns.NewListPlatformApplicationsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.PlatformApplications {
@@ -27,6 +27,8 @@ func ListSnsPlatformApplication(client *Client) ([]Resource, error) {
 				AccountID: client.AccountID,
 			})
 		}
+
+		progress.report("aws_sns_platform_application", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/sns"
 )
 
-func ListSnsTopic(client *Client) ([]Resource, error) {
+func ListSnsTopic(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Snsconn.ListTopicsRequest(&sns.ListTopicsInput{})
 
 	var result []Resource
 
 	p := sns.NewListTopicsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.Topics {
@@ -27,6 +27,8 @@ func ListSnsTopic(client *Client) ([]Resource, error) {
 				AccountID: client.AccountID,
 			})
 		}
+
+		progress.report("aws_sns_topic", len(result))
 	}
 
 	if err := p.Err(); err != nil {
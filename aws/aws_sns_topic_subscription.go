@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/sns"
 )
 
-func ListSnsTopicSubscription(client *Client) ([]Resource, error) {
+func ListSnsTopicSubscription(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Snsconn.ListSubscriptionsRequest(&sns.ListSubscriptionsInput{})
 
 	var result []Resource
 
 	p := sns.NewListSubscriptionsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.Subscriptions {
@@ -27,6 +27,8 @@ func ListSnsTopicSubscription(client *Client) ([]Resource, error) {
 				AccountID: client.AccountID,
 			})
 		}
+
+		progress.report("aws_sns_topic_subscription", len(result))
 	}
 
 	if err := p.Err(); err != nil {
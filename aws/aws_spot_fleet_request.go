@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 )
 
-func ListSpotFleetRequest(client *Client) ([]Resource, error) {
+func ListSpotFleetRequest(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Ec2conn.DescribeSpotFleetRequestsRequest(&ec2.DescribeSpotFleetRequestsInput{})
 
 	var result []Resource
 
 	p := ec2.NewDescribeSpotFleetRequestsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.SpotFleetRequestConfigs {
@@ -34,6 +34,8 @@ func ListSpotFleetRequest(client *Client) ([]Resource, error) {
 				CreatedAt: &t,
 			})
 		}
+
+		progress.report("aws_spot_fleet_request", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 )
 
-func ListSpotInstanceRequest(client *Client) ([]Resource, error) {
+func ListSpotInstanceRequest(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Ec2conn.DescribeSpotInstanceRequestsRequest(&ec2.DescribeSpotInstanceRequestsInput{})
 
 	var result []Resource
 
 	p := ec2.NewDescribeSpotInstanceRequestsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.SpotInstanceRequests {
@@ -34,6 +34,8 @@ func ListSpotInstanceRequest(client *Client) ([]Resource, error) {
 				CreatedAt: &t,
 			})
 		}
+
+		progress.report("aws_spot_instance_request", len(result))
 	}
 
 	if err := p.Err(); err != nil {
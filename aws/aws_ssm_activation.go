@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ssm"
 )
 
-func ListSsmActivation(client *Client) ([]Resource, error) {
+func ListSsmActivation(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Ssmconn.DescribeActivationsRequest(&ssm.DescribeActivationsInput{})
 
 	var result []Resource
 
 	p := ssm.NewDescribeActivationsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.ActivationList {
@@ -33,6 +33,8 @@ func ListSsmActivation(client *Client) ([]Resource, error) {
 				Tags:      tags,
 			})
 		}
+
+		progress.report("aws_ssm_activation", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ssm"
 )
 
-func ListSsmAssociation(client *Client) ([]Resource, error) {
+func ListSsmAssociation(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Ssmconn.ListAssociationsRequest(&ssm.ListAssociationsInput{})
 
 	var result []Resource
 
 	p := ssm.NewListAssociationsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.Associations {
@@ -27,6 +27,8 @@ func ListSsmAssociation(client *Client) ([]Resource, error) {
 				AccountID: client.AccountID,
 			})
 		}
+
+		progress.report("aws_ssm_association", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ssm"
 )
 
-func ListSsmDocument(client *Client) ([]Resource, error) {
+func ListSsmDocument(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Ssmconn.ListDocumentsRequest(&ssm.ListDocumentsInput{})
 
 	var result []Resource
 
 	p := ssm.NewListDocumentsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.DocumentIdentifiers {
@@ -33,6 +33,8 @@ func ListSsmDocument(client *Client) ([]Resource, error) {
 				Tags:      tags,
 			})
 		}
+
+		progress.report("aws_ssm_document", len(result))
 	}
 
 	if err := p.Err(); err != nil {
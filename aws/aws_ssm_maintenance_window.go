@@ -8,12 +8,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ssm"
 )
 
-func ListSsmMaintenanceWindow(client *Client) ([]Resource, error) {
+func ListSsmMaintenanceWindow(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Ssmconn.DescribeMaintenanceWindowsRequest(&ssm.DescribeMaintenanceWindowsInput{})
 
 	var result []Resource
 
-	resp, err := req.Send(context.Background())
+	resp, err := req.Send(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ssm"
 )
 
-func ListSsmParameter(client *Client) ([]Resource, error) {
+func ListSsmParameter(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Ssmconn.DescribeParametersRequest(&ssm.DescribeParametersInput{})
 
 	var result []Resource
 
 	p := ssm.NewDescribeParametersPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.Parameters {
@@ -27,6 +27,8 @@ func ListSsmParameter(client *Client) ([]Resource, error) {
 				AccountID: client.AccountID,
 			})
 		}
+
+		progress.report("aws_ssm_parameter", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/storagegateway"
 )
 
-func ListStoragegatewayGateway(client *Client) ([]Resource, error) {
+func ListStoragegatewayGateway(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Storagegatewayconn.ListGatewaysRequest(&storagegateway.ListGatewaysInput{})
 
 	var result []Resource
 
 	p := storagegateway.NewListGatewaysPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.Gateways {
@@ -27,6 +27,8 @@ func ListStoragegatewayGateway(client *Client) ([]Resource, error) {
 				AccountID: client.AccountID,
 			})
 		}
+
+		progress.report("aws_storagegateway_gateway", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 )
 
-func ListSubnet(client *Client) ([]Resource, error) {
+func ListSubnet(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Ec2conn.DescribeSubnetsRequest(&ec2.DescribeSubnetsInput{})
 
 	var result []Resource
 
 	p := ec2.NewDescribeSubnetsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.Subnets {
@@ -35,6 +35,8 @@ func ListSubnet(client *Client) ([]Resource, error) {
 				Tags:      tags,
 			})
 		}
+
+		progress.report("aws_subnet", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/transfer"
 )
 
-func ListTransferServer(client *Client) ([]Resource, error) {
+func ListTransferServer(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Transferconn.ListServersRequest(&transfer.ListServersInput{})
 
 	var result []Resource
 
 	p := transfer.NewListServersPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.Servers {
@@ -27,6 +27,8 @@ func ListTransferServer(client *Client) ([]Resource, error) {
 				AccountID: client.AccountID,
 			})
 		}
+
+		progress.report("aws_transfer_server", len(result))
 	}
 
 	if err := p.Err(); err != nil {
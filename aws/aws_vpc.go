@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 )
 
-func ListVpc(client *Client) ([]Resource, error) {
+func ListVpc(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Ec2conn.DescribeVpcsRequest(&ec2.DescribeVpcsInput{})
 
 	var result []Resource
 
 	p := ec2.NewDescribeVpcsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.Vpcs {
@@ -35,6 +35,8 @@ func ListVpc(client *Client) ([]Resource, error) {
 				Tags:      tags,
 			})
 		}
+
+		progress.report("aws_vpc", len(result))
 	}
 
 	if err := p.Err(); err != nil {
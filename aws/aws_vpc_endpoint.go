@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 )
 
-func ListVpcEndpoint(client *Client) ([]Resource, error) {
+func ListVpcEndpoint(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Ec2conn.DescribeVpcEndpointsRequest(&ec2.DescribeVpcEndpointsInput{})
 
 	var result []Resource
 
 	p := ec2.NewDescribeVpcEndpointsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.VpcEndpoints {
@@ -36,6 +36,8 @@ func ListVpcEndpoint(client *Client) ([]Resource, error) {
 				CreatedAt: &t,
 			})
 		}
+
+		progress.report("aws_vpc_endpoint", len(result))
 	}
 
 	if err := p.Err(); err != nil {
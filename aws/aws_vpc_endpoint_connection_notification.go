@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 )
 
-func ListVpcEndpointConnectionNotification(client *Client) ([]Resource, error) {
+func ListVpcEndpointConnectionNotification(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Ec2conn.DescribeVpcEndpointConnectionNotificationsRequest(&ec2.DescribeVpcEndpointConnectionNotificationsInput{})
 
 	var result []Resource
 
 	p := ec2.NewDescribeVpcEndpointConnectionNotificationsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.ConnectionNotificationSet {
@@ -27,6 +27,8 @@ func ListVpcEndpointConnectionNotification(client *Client) ([]Resource, error) {
 				AccountID: client.AccountID,
 			})
 		}
+
+		progress.report("aws_vpc_endpoint_connection_notification", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 )
 
-func ListVpcPeeringConnection(client *Client) ([]Resource, error) {
+func ListVpcPeeringConnection(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Ec2conn.DescribeVpcPeeringConnectionsRequest(&ec2.DescribeVpcPeeringConnectionsInput{})
 
 	var result []Resource
 
 	p := ec2.NewDescribeVpcPeeringConnectionsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.VpcPeeringConnections {
@@ -33,6 +33,8 @@ func ListVpcPeeringConnection(client *Client) ([]Resource, error) {
 				Tags:      tags,
 			})
 		}
+
+		progress.report("aws_vpc_peering_connection", len(result))
 	}
 
 	if err := p.Err(); err != nil {
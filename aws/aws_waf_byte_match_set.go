@@ -8,12 +8,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/waf"
 )
 
-func ListWafByteMatchSet(client *Client) ([]Resource, error) {
+func ListWafByteMatchSet(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Wafconn.ListByteMatchSetsRequest(&waf.ListByteMatchSetsInput{})
 
 	var result []Resource
 
-	resp, err := req.Send(context.Background())
+	resp, err := req.Send(ctx)
 	if err != nil {
 		return nil, err
 	}
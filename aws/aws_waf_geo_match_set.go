@@ -8,12 +8,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/waf"
 )
 
-func ListWafGeoMatchSet(client *Client) ([]Resource, error) {
+func ListWafGeoMatchSet(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Wafconn.ListGeoMatchSetsRequest(&waf.ListGeoMatchSetsInput{})
 
 	var result []Resource
 
-	resp, err := req.Send(context.Background())
+	resp, err := req.Send(ctx)
 	if err != nil {
 		return nil, err
 	}
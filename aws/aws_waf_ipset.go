@@ -8,12 +8,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/waf"
 )
 
-func ListWafIpset(client *Client) ([]Resource, error) {
+func ListWafIpset(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Wafconn.ListIPSetsRequest(&waf.ListIPSetsInput{})
 
 	var result []Resource
 
-	resp, err := req.Send(context.Background())
+	resp, err := req.Send(ctx)
 	if err != nil {
 		return nil, err
 	}
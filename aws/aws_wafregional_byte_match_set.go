@@ -8,12 +8,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/wafregional"
 )
 
-func ListWafregionalByteMatchSet(client *Client) ([]Resource, error) {
+func ListWafregionalByteMatchSet(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Wafregionalconn.ListByteMatchSetsRequest(&wafregional.ListByteMatchSetsInput{})
 
 	var result []Resource
 
-	resp, err := req.Send(context.Background())
+	resp, err := req.Send(ctx)
 	if err != nil {
 		return nil, err
 	}
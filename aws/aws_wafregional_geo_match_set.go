@@ -8,12 +8,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/wafregional"
 )
 
-func ListWafregionalGeoMatchSet(client *Client) ([]Resource, error) {
+func ListWafregionalGeoMatchSet(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Wafregionalconn.ListGeoMatchSetsRequest(&wafregional.ListGeoMatchSetsInput{})
 
 	var result []Resource
 
-	resp, err := req.Send(context.Background())
+	resp, err := req.Send(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -8,12 +8,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/wafregional"
 )
 
-func ListWafregionalIpset(client *Client) ([]Resource, error) {
+func ListWafregionalIpset(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Wafregionalconn.ListIPSetsRequest(&wafregional.ListIPSetsInput{})
 
 	var result []Resource
 
-	resp, err := req.Send(context.Background())
+	resp, err := req.Send(ctx)
 	if err != nil {
 		return nil, err
 	}
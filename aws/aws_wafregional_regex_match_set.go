@@ -8,12 +8,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/wafregional"
 )
 
-func ListWafregionalRegexMatchSet(client *Client) ([]Resource, error) {
+func ListWafregionalRegexMatchSet(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Wafregionalconn.ListRegexMatchSetsRequest(&wafregional.ListRegexMatchSetsInput{})
 
 	var result []Resource
 
-	resp, err := req.Send(context.Background())
+	resp, err := req.Send(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -8,12 +8,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/wafv2"
 )
 
-func ListWafv2WebAclLoggingConfiguration(client *Client) ([]Resource, error) {
+func ListWafv2WebAclLoggingConfiguration(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Wafv2conn.ListLoggingConfigurationsRequest(&wafv2.ListLoggingConfigurationsInput{})
 
 	var result []Resource
 
-	resp, err := req.Send(context.Background())
+	resp, err := req.Send(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -8,13 +8,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/worklink"
 )
 
-func ListWorklinkFleet(client *Client) ([]Resource, error) {
+func ListWorklinkFleet(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Worklinkconn.ListFleetsRequest(&worklink.ListFleetsInput{})
 
 	var result []Resource
 
 	p := worklink.NewListFleetsPaginator(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.FleetSummaryList {
@@ -30,6 +30,8 @@ func ListWorklinkFleet(client *Client) ([]Resource, error) {
 				CreatedAt: &t,
 			})
 		}
+
+		progress.report("aws_worklink_fleet", len(result))
 	}
 
 	if err := p.Err(); err != nil {
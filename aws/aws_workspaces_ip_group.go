@@ -8,12 +8,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/workspaces"
 )
 
-func ListWorkspacesIpGroup(client *Client) ([]Resource, error) {
+func ListWorkspacesIpGroup(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
 	req := client.Workspacesconn.DescribeIpGroupsRequest(&workspaces.DescribeIpGroupsInput{})
 
 	var result []Resource
 
-	resp, err := req.Send(context.Background())
+	resp, err := req.Send(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,30 @@
+package aws
+
+import (
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws/awserr"
+)
+
+// accessDeniedCodes are the error codes AWS APIs return if the caller lacks the permission to make a request.
+//
+//nolint:gochecknoglobals
+var accessDeniedCodes = map[string]struct{}{
+	"AccessDenied":                {},
+	"AccessDeniedException":       {},
+	"AuthorizationError":          {}, // SNS
+	"AuthorizationErrorException": {},
+	"UnauthorizedOperation":       {}, // EC2
+}
+
+// IsAccessDenied returns true if the given error is an AWS API error caused by missing permissions.
+func IsAccessDenied(err error) bool {
+	var awsErr awserr.Error
+	if !errors.As(err, &awsErr) {
+		return false
+	}
+
+	_, ok := accessDeniedCodes[awsErr.Code()]
+
+	return ok
+}
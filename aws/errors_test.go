@@ -0,0 +1,65 @@
+package aws_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws/awserr"
+	"github.com/jckuester/awsls/aws"
+)
+
+func TestIsAccessDenied(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "access denied",
+			err:  awserr.New("AccessDenied", "not authorized", nil),
+			want: true,
+		},
+		{
+			name: "access denied exception",
+			err:  awserr.New("AccessDeniedException", "not authorized", nil),
+			want: true,
+		},
+		{
+			name: "unauthorized operation of EC2",
+			err:  awserr.New("UnauthorizedOperation", "not authorized", nil),
+			want: true,
+		},
+		{
+			name: "authorization error of SNS",
+			err:  awserr.New("AuthorizationError", "not authorized", nil),
+			want: true,
+		},
+		{
+			name: "wrapped access denied",
+			err:  fmt.Errorf("failed to list: %w", awserr.New("AccessDenied", "not authorized", nil)),
+			want: true,
+		},
+		{
+			name: "other AWS error",
+			err:  awserr.New("ThrottlingException", "rate exceeded", nil),
+			want: false,
+		},
+		{
+			name: "not an AWS error",
+			err:  errors.New("AccessDenied"),
+			want: false,
+		},
+		{
+			name: "no error",
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := aws.IsAccessDenied(tt.err); got != tt.want {
+				t.Errorf("IsAccessDenied() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package aws
+
+import (
+	awsSDK "github.com/aws/aws-sdk-go-v2/aws"
+)
+
+// WithHTTPClient is a config that makes NewClient send the requests of all services with the given HTTP client
+// instead of the SDK's default one.
+type WithHTTPClient struct {
+	awsSDK.HTTPClient
+}
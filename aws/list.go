@@ -3,6 +3,7 @@
 package aws
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -17,447 +18,462 @@ type Resource struct {
 	AccountID string
 	Tags      map[string]string
 	CreatedAt *time.Time
+	// StateStale is true if the state couldn't be refreshed via the Terraform AWS Provider,
+	// so attribute values may be outdated or missing.
+	StateStale bool
 	terradozer.UpdatableResource
 }
 
-func ListResourcesByType(client *Client, resourceType string) ([]Resource, error) {
+// ProgressFunc is called after each page of resources of the given type has been listed
+// with the number of resources listed so far.
+type ProgressFunc func(resourceType string, listed int)
+
+// report calls the progress function, if any.
+func (f ProgressFunc) report(resourceType string, listed int) {
+	if f != nil {
+		f(resourceType, listed)
+	}
+}
+
+func ListResourcesByType(ctx context.Context, client *Client, resourceType string,
+	progress ProgressFunc) ([]Resource, error) {
 	switch resourceType {
 	case "aws_accessanalyzer_analyzer":
-		return ListAccessanalyzerAnalyzer(client)
+		return ListAccessanalyzerAnalyzer(ctx, client, progress)
 	case "aws_acm_certificate":
-		return ListAcmCertificate(client)
+		return ListAcmCertificate(ctx, client, progress)
 	case "aws_alb_target_group":
-		return ListAlbTargetGroup(client)
+		return ListAlbTargetGroup(ctx, client, progress)
 	case "aws_ami":
-		return ListAmi(client)
+		return ListAmi(ctx, client, progress)
 	case "aws_api_gateway_api_key":
-		return ListApiGatewayApiKey(client)
+		return ListApiGatewayApiKey(ctx, client, progress)
 	case "aws_api_gateway_client_certificate":
-		return ListApiGatewayClientCertificate(client)
+		return ListApiGatewayClientCertificate(ctx, client, progress)
 	case "aws_api_gateway_domain_name":
-		return ListApiGatewayDomainName(client)
+		return ListApiGatewayDomainName(ctx, client, progress)
 	case "aws_api_gateway_rest_api":
-		return ListApiGatewayRestApi(client)
+		return ListApiGatewayRestApi(ctx, client, progress)
 	case "aws_api_gateway_usage_plan":
-		return ListApiGatewayUsagePlan(client)
+		return ListApiGatewayUsagePlan(ctx, client, progress)
 	case "aws_api_gateway_vpc_link":
-		return ListApiGatewayVpcLink(client)
+		return ListApiGatewayVpcLink(ctx, client, progress)
 	case "aws_apigatewayv2_api":
-		return ListApigatewayv2Api(client)
+		return ListApigatewayv2Api(ctx, client, progress)
 	case "aws_apigatewayv2_domain_name":
-		return ListApigatewayv2DomainName(client)
+		return ListApigatewayv2DomainName(ctx, client, progress)
 	case "aws_apigatewayv2_vpc_link":
-		return ListApigatewayv2VpcLink(client)
+		return ListApigatewayv2VpcLink(ctx, client, progress)
 	case "aws_appmesh_mesh":
-		return ListAppmeshMesh(client)
+		return ListAppmeshMesh(ctx, client, progress)
 	case "aws_appsync_graphql_api":
-		return ListAppsyncGraphqlApi(client)
+		return ListAppsyncGraphqlApi(ctx, client, progress)
 	case "aws_athena_workgroup":
-		return ListAthenaWorkgroup(client)
+		return ListAthenaWorkgroup(ctx, client, progress)
 	case "aws_autoscaling_group":
-		return ListAutoscalingGroup(client)
+		return ListAutoscalingGroup(ctx, client, progress)
 	case "aws_backup_plan":
-		return ListBackupPlan(client)
+		return ListBackupPlan(ctx, client, progress)
 	case "aws_backup_vault":
-		return ListBackupVault(client)
+		return ListBackupVault(ctx, client, progress)
 	case "aws_batch_compute_environment":
-		return ListBatchComputeEnvironment(client)
+		return ListBatchComputeEnvironment(ctx, client, progress)
 	case "aws_batch_job_definition":
-		return ListBatchJobDefinition(client)
+		return ListBatchJobDefinition(ctx, client, progress)
 	case "aws_batch_job_queue":
-		return ListBatchJobQueue(client)
+		return ListBatchJobQueue(ctx, client, progress)
 	case "aws_cloudformation_stack":
-		return ListCloudformationStack(client)
+		return ListCloudformationStack(ctx, client, progress)
 	case "aws_cloudformation_stack_set":
-		return ListCloudformationStackSet(client)
+		return ListCloudformationStackSet(ctx, client, progress)
 	case "aws_cloudhsm_v2_cluster":
-		return ListCloudhsmV2Cluster(client)
+		return ListCloudhsmV2Cluster(ctx, client, progress)
 	case "aws_cloudwatch_dashboard":
-		return ListCloudwatchDashboard(client)
+		return ListCloudwatchDashboard(ctx, client, progress)
 	case "aws_cloudwatch_event_rule":
-		return ListCloudwatchEventRule(client)
+		return ListCloudwatchEventRule(ctx, client, progress)
 	case "aws_cloudwatch_log_destination":
-		return ListCloudwatchLogDestination(client)
+		return ListCloudwatchLogDestination(ctx, client, progress)
 	case "aws_cloudwatch_log_group":
-		return ListCloudwatchLogGroup(client)
+		return ListCloudwatchLogGroup(ctx, client, progress)
 	case "aws_cloudwatch_log_resource_policy":
-		return ListCloudwatchLogResourcePolicy(client)
+		return ListCloudwatchLogResourcePolicy(ctx, client, progress)
 	case "aws_codebuild_source_credential":
-		return ListCodebuildSourceCredential(client)
+		return ListCodebuildSourceCredential(ctx, client, progress)
 	case "aws_codecommit_repository":
-		return ListCodecommitRepository(client)
+		return ListCodecommitRepository(ctx, client, progress)
 	case "aws_codepipeline_webhook":
-		return ListCodepipelineWebhook(client)
+		return ListCodepipelineWebhook(ctx, client, progress)
 	case "aws_codestarnotifications_notification_rule":
-		return ListCodestarnotificationsNotificationRule(client)
+		return ListCodestarnotificationsNotificationRule(ctx, client, progress)
 	case "aws_config_config_rule":
-		return ListConfigConfigRule(client)
+		return ListConfigConfigRule(ctx, client, progress)
 	case "aws_config_configuration_recorder":
-		return ListConfigConfigurationRecorder(client)
+		return ListConfigConfigurationRecorder(ctx, client, progress)
 	case "aws_config_delivery_channel":
-		return ListConfigDeliveryChannel(client)
+		return ListConfigDeliveryChannel(ctx, client, progress)
 	case "aws_cur_report_definition":
-		return ListCurReportDefinition(client)
+		return ListCurReportDefinition(ctx, client, progress)
 	case "aws_datasync_agent":
-		return ListDatasyncAgent(client)
+		return ListDatasyncAgent(ctx, client, progress)
 	case "aws_datasync_task":
-		return ListDatasyncTask(client)
+		return ListDatasyncTask(ctx, client, progress)
 	case "aws_dax_parameter_group":
-		return ListDaxParameterGroup(client)
+		return ListDaxParameterGroup(ctx, client, progress)
 	case "aws_dax_subnet_group":
-		return ListDaxSubnetGroup(client)
+		return ListDaxSubnetGroup(ctx, client, progress)
 	case "aws_db_event_subscription":
-		return ListDbEventSubscription(client)
+		return ListDbEventSubscription(ctx, client, progress)
 	case "aws_db_instance":
-		return ListDbInstance(client)
+		return ListDbInstance(ctx, client, progress)
 	case "aws_db_parameter_group":
-		return ListDbParameterGroup(client)
+		return ListDbParameterGroup(ctx, client, progress)
 	case "aws_db_security_group":
-		return ListDbSecurityGroup(client)
+		return ListDbSecurityGroup(ctx, client, progress)
 	case "aws_db_snapshot":
-		return ListDbSnapshot(client)
+		return ListDbSnapshot(ctx, client, progress)
 	case "aws_db_subnet_group":
-		return ListDbSubnetGroup(client)
+		return ListDbSubnetGroup(ctx, client, progress)
 	case "aws_devicefarm_project":
-		return ListDevicefarmProject(client)
+		return ListDevicefarmProject(ctx, client, progress)
 	case "aws_dlm_lifecycle_policy":
-		return ListDlmLifecyclePolicy(client)
+		return ListDlmLifecyclePolicy(ctx, client, progress)
 	case "aws_dms_certificate":
-		return ListDmsCertificate(client)
+		return ListDmsCertificate(ctx, client, progress)
 	case "aws_dms_endpoint":
-		return ListDmsEndpoint(client)
+		return ListDmsEndpoint(ctx, client, progress)
 	case "aws_dms_replication_subnet_group":
-		return ListDmsReplicationSubnetGroup(client)
+		return ListDmsReplicationSubnetGroup(ctx, client, progress)
 	case "aws_dms_replication_task":
-		return ListDmsReplicationTask(client)
+		return ListDmsReplicationTask(ctx, client, progress)
 	case "aws_dx_connection":
-		return ListDxConnection(client)
+		return ListDxConnection(ctx, client, progress)
 	case "aws_dx_hosted_private_virtual_interface":
-		return ListDxHostedPrivateVirtualInterface(client)
+		return ListDxHostedPrivateVirtualInterface(ctx, client, progress)
 	case "aws_dx_hosted_public_virtual_interface":
-		return ListDxHostedPublicVirtualInterface(client)
+		return ListDxHostedPublicVirtualInterface(ctx, client, progress)
 	case "aws_dx_hosted_transit_virtual_interface":
-		return ListDxHostedTransitVirtualInterface(client)
+		return ListDxHostedTransitVirtualInterface(ctx, client, progress)
 	case "aws_dx_lag":
-		return ListDxLag(client)
+		return ListDxLag(ctx, client, progress)
 	case "aws_dx_private_virtual_interface":
-		return ListDxPrivateVirtualInterface(client)
+		return ListDxPrivateVirtualInterface(ctx, client, progress)
 	case "aws_dx_public_virtual_interface":
-		return ListDxPublicVirtualInterface(client)
+		return ListDxPublicVirtualInterface(ctx, client, progress)
 	case "aws_dx_transit_virtual_interface":
-		return ListDxTransitVirtualInterface(client)
+		return ListDxTransitVirtualInterface(ctx, client, progress)
 	case "aws_dynamodb_global_table":
-		return ListDynamodbGlobalTable(client)
+		return ListDynamodbGlobalTable(ctx, client, progress)
 	case "aws_ebs_snapshot":
-		return ListEbsSnapshot(client)
+		return ListEbsSnapshot(ctx, client, progress)
 	case "aws_ebs_volume":
-		return ListEbsVolume(client)
+		return ListEbsVolume(ctx, client, progress)
 	case "aws_ec2_capacity_reservation":
-		return ListEc2CapacityReservation(client)
+		return ListEc2CapacityReservation(ctx, client, progress)
 	case "aws_ec2_client_vpn_endpoint":
-		return ListEc2ClientVpnEndpoint(client)
+		return ListEc2ClientVpnEndpoint(ctx, client, progress)
 	case "aws_ec2_fleet":
-		return ListEc2Fleet(client)
+		return ListEc2Fleet(ctx, client, progress)
 	case "aws_ec2_local_gateway_route_table_vpc_association":
-		return ListEc2LocalGatewayRouteTableVpcAssociation(client)
+		return ListEc2LocalGatewayRouteTableVpcAssociation(ctx, client, progress)
 	case "aws_ec2_traffic_mirror_filter":
-		return ListEc2TrafficMirrorFilter(client)
+		return ListEc2TrafficMirrorFilter(ctx, client, progress)
 	case "aws_ec2_traffic_mirror_session":
-		return ListEc2TrafficMirrorSession(client)
+		return ListEc2TrafficMirrorSession(ctx, client, progress)
 	case "aws_ec2_traffic_mirror_target":
-		return ListEc2TrafficMirrorTarget(client)
+		return ListEc2TrafficMirrorTarget(ctx, client, progress)
 	case "aws_ec2_transit_gateway":
-		return ListEc2TransitGateway(client)
+		return ListEc2TransitGateway(ctx, client, progress)
 	case "aws_ec2_transit_gateway_peering_attachment":
-		return ListEc2TransitGatewayPeeringAttachment(client)
+		return ListEc2TransitGatewayPeeringAttachment(ctx, client, progress)
 	case "aws_ec2_transit_gateway_route_table":
-		return ListEc2TransitGatewayRouteTable(client)
+		return ListEc2TransitGatewayRouteTable(ctx, client, progress)
 	case "aws_ec2_transit_gateway_vpc_attachment":
-		return ListEc2TransitGatewayVpcAttachment(client)
+		return ListEc2TransitGatewayVpcAttachment(ctx, client, progress)
 	case "aws_ecr_repository":
-		return ListEcrRepository(client)
+		return ListEcrRepository(ctx, client, progress)
 	case "aws_ecs_cluster":
-		return ListEcsCluster(client)
+		return ListEcsCluster(ctx, client, progress)
 	case "aws_efs_access_point":
-		return ListEfsAccessPoint(client)
+		return ListEfsAccessPoint(ctx, client, progress)
 	case "aws_efs_file_system":
-		return ListEfsFileSystem(client)
+		return ListEfsFileSystem(ctx, client, progress)
 	case "aws_egress_only_internet_gateway":
-		return ListEgressOnlyInternetGateway(client)
+		return ListEgressOnlyInternetGateway(ctx, client, progress)
 	case "aws_eip":
-		return ListEip(client)
+		return ListEip(ctx, client, progress)
 	case "aws_elastic_beanstalk_application":
-		return ListElasticBeanstalkApplication(client)
+		return ListElasticBeanstalkApplication(ctx, client, progress)
 	case "aws_elastic_beanstalk_application_version":
-		return ListElasticBeanstalkApplicationVersion(client)
+		return ListElasticBeanstalkApplicationVersion(ctx, client, progress)
 	case "aws_elastic_beanstalk_environment":
-		return ListElasticBeanstalkEnvironment(client)
+		return ListElasticBeanstalkEnvironment(ctx, client, progress)
 	case "aws_elasticache_replication_group":
-		return ListElasticacheReplicationGroup(client)
+		return ListElasticacheReplicationGroup(ctx, client, progress)
 	case "aws_elastictranscoder_pipeline":
-		return ListElastictranscoderPipeline(client)
+		return ListElastictranscoderPipeline(ctx, client, progress)
 	case "aws_elastictranscoder_preset":
-		return ListElastictranscoderPreset(client)
+		return ListElastictranscoderPreset(ctx, client, progress)
 	case "aws_elb":
-		return ListElb(client)
+		return ListElb(ctx, client, progress)
 	case "aws_emr_security_configuration":
-		return ListEmrSecurityConfiguration(client)
+		return ListEmrSecurityConfiguration(ctx, client, progress)
 	case "aws_fsx_lustre_file_system":
-		return ListFsxLustreFileSystem(client)
+		return ListFsxLustreFileSystem(ctx, client, progress)
 	case "aws_fsx_windows_file_system":
-		return ListFsxWindowsFileSystem(client)
+		return ListFsxWindowsFileSystem(ctx, client, progress)
 	case "aws_gamelift_alias":
-		return ListGameliftAlias(client)
+		return ListGameliftAlias(ctx, client, progress)
 	case "aws_gamelift_build":
-		return ListGameliftBuild(client)
+		return ListGameliftBuild(ctx, client, progress)
 	case "aws_gamelift_game_session_queue":
-		return ListGameliftGameSessionQueue(client)
+		return ListGameliftGameSessionQueue(ctx, client, progress)
 	case "aws_globalaccelerator_accelerator":
-		return ListGlobalacceleratorAccelerator(client)
+		return ListGlobalacceleratorAccelerator(ctx, client, progress)
 	case "aws_glue_crawler":
-		return ListGlueCrawler(client)
+		return ListGlueCrawler(ctx, client, progress)
 	case "aws_glue_job":
-		return ListGlueJob(client)
+		return ListGlueJob(ctx, client, progress)
 	case "aws_glue_security_configuration":
-		return ListGlueSecurityConfiguration(client)
+		return ListGlueSecurityConfiguration(ctx, client, progress)
 	case "aws_glue_trigger":
-		return ListGlueTrigger(client)
+		return ListGlueTrigger(ctx, client, progress)
 	case "aws_iam_access_key":
-		return ListIamAccessKey(client)
+		return ListIamAccessKey(ctx, client, progress)
 	case "aws_iam_group":
-		return ListIamGroup(client)
+		return ListIamGroup(ctx, client, progress)
 	case "aws_iam_instance_profile":
-		return ListIamInstanceProfile(client)
+		return ListIamInstanceProfile(ctx, client, progress)
 	case "aws_iam_policy":
-		return ListIamPolicy(client)
+		return ListIamPolicy(ctx, client, progress)
 	case "aws_iam_role":
-		return ListIamRole(client)
+		return ListIamRole(ctx, client, progress)
 	case "aws_iam_server_certificate":
-		return ListIamServerCertificate(client)
+		return ListIamServerCertificate(ctx, client, progress)
 	case "aws_iam_service_linked_role":
-		return ListIamServiceLinkedRole(client)
+		return ListIamServiceLinkedRole(ctx, client, progress)
 	case "aws_iam_user":
-		return ListIamUser(client)
+		return ListIamUser(ctx, client, progress)
 	case "aws_instance":
-		return ListInstance(client)
+		return ListInstance(ctx, client, progress)
 	case "aws_internet_gateway":
-		return ListInternetGateway(client)
+		return ListInternetGateway(ctx, client, progress)
 	case "aws_iot_certificate":
-		return ListIotCertificate(client)
+		return ListIotCertificate(ctx, client, progress)
 	case "aws_iot_policy":
-		return ListIotPolicy(client)
+		return ListIotPolicy(ctx, client, progress)
 	case "aws_iot_thing":
-		return ListIotThing(client)
+		return ListIotThing(ctx, client, progress)
 	case "aws_iot_thing_type":
-		return ListIotThingType(client)
+		return ListIotThingType(ctx, client, progress)
 	case "aws_iot_topic_rule":
-		return ListIotTopicRule(client)
+		return ListIotTopicRule(ctx, client, progress)
 	case "aws_key_pair":
-		return ListKeyPair(client)
+		return ListKeyPair(ctx, client, progress)
 	case "aws_kinesis_analytics_application":
-		return ListKinesisAnalyticsApplication(client)
+		return ListKinesisAnalyticsApplication(ctx, client, progress)
 	case "aws_kms_external_key":
-		return ListKmsExternalKey(client)
+		return ListKmsExternalKey(ctx, client, progress)
 	case "aws_kms_key":
-		return ListKmsKey(client)
+		return ListKmsKey(ctx, client, progress)
 	case "aws_lambda_event_source_mapping":
-		return ListLambdaEventSourceMapping(client)
+		return ListLambdaEventSourceMapping(ctx, client, progress)
 	case "aws_lambda_function":
-		return ListLambdaFunction(client)
+		return ListLambdaFunction(ctx, client, progress)
 	case "aws_launch_configuration":
-		return ListLaunchConfiguration(client)
+		return ListLaunchConfiguration(ctx, client, progress)
 	case "aws_launch_template":
-		return ListLaunchTemplate(client)
+		return ListLaunchTemplate(ctx, client, progress)
 	case "aws_lb_target_group":
-		return ListLbTargetGroup(client)
+		return ListLbTargetGroup(ctx, client, progress)
 	case "aws_licensemanager_license_configuration":
-		return ListLicensemanagerLicenseConfiguration(client)
+		return ListLicensemanagerLicenseConfiguration(ctx, client, progress)
 	case "aws_lightsail_domain":
-		return ListLightsailDomain(client)
+		return ListLightsailDomain(ctx, client, progress)
 	case "aws_lightsail_instance":
-		return ListLightsailInstance(client)
+		return ListLightsailInstance(ctx, client, progress)
 	case "aws_lightsail_key_pair":
-		return ListLightsailKeyPair(client)
+		return ListLightsailKeyPair(ctx, client, progress)
 	case "aws_lightsail_static_ip":
-		return ListLightsailStaticIp(client)
+		return ListLightsailStaticIp(ctx, client, progress)
 	case "aws_media_convert_queue":
-		return ListMediaConvertQueue(client)
+		return ListMediaConvertQueue(ctx, client, progress)
 	case "aws_media_package_channel":
-		return ListMediaPackageChannel(client)
+		return ListMediaPackageChannel(ctx, client, progress)
 	case "aws_media_store_container":
-		return ListMediaStoreContainer(client)
+		return ListMediaStoreContainer(ctx, client, progress)
 	case "aws_mq_broker":
-		return ListMqBroker(client)
+		return ListMqBroker(ctx, client, progress)
 	case "aws_mq_configuration":
-		return ListMqConfiguration(client)
+		return ListMqConfiguration(ctx, client, progress)
 	case "aws_msk_cluster":
-		return ListMskCluster(client)
+		return ListMskCluster(ctx, client, progress)
 	case "aws_msk_configuration":
-		return ListMskConfiguration(client)
+		return ListMskConfiguration(ctx, client, progress)
 	case "aws_nat_gateway":
-		return ListNatGateway(client)
+		return ListNatGateway(ctx, client, progress)
 	case "aws_neptune_event_subscription":
-		return ListNeptuneEventSubscription(client)
+		return ListNeptuneEventSubscription(ctx, client, progress)
 	case "aws_network_acl":
-		return ListNetworkAcl(client)
+		return ListNetworkAcl(ctx, client, progress)
 	case "aws_network_interface":
-		return ListNetworkInterface(client)
+		return ListNetworkInterface(ctx, client, progress)
 	case "aws_opsworks_stack":
-		return ListOpsworksStack(client)
+		return ListOpsworksStack(ctx, client, progress)
 	case "aws_opsworks_user_profile":
-		return ListOpsworksUserProfile(client)
+		return ListOpsworksUserProfile(ctx, client, progress)
 	case "aws_placement_group":
-		return ListPlacementGroup(client)
+		return ListPlacementGroup(ctx, client, progress)
 	case "aws_qldb_ledger":
-		return ListQldbLedger(client)
+		return ListQldbLedger(ctx, client, progress)
 	case "aws_rds_global_cluster":
-		return ListRdsGlobalCluster(client)
+		return ListRdsGlobalCluster(ctx, client, progress)
 	case "aws_redshift_cluster":
-		return ListRedshiftCluster(client)
+		return ListRedshiftCluster(ctx, client, progress)
 	case "aws_redshift_event_subscription":
-		return ListRedshiftEventSubscription(client)
+		return ListRedshiftEventSubscription(ctx, client, progress)
 	case "aws_redshift_snapshot_copy_grant":
-		return ListRedshiftSnapshotCopyGrant(client)
+		return ListRedshiftSnapshotCopyGrant(ctx, client, progress)
 	case "aws_redshift_snapshot_schedule":
-		return ListRedshiftSnapshotSchedule(client)
+		return ListRedshiftSnapshotSchedule(ctx, client, progress)
 	case "aws_route53_health_check":
-		return ListRoute53HealthCheck(client)
+		return ListRoute53HealthCheck(ctx, client, progress)
 	case "aws_route53_resolver_endpoint":
-		return ListRoute53ResolverEndpoint(client)
+		return ListRoute53ResolverEndpoint(ctx, client, progress)
 	case "aws_route53_resolver_rule":
-		return ListRoute53ResolverRule(client)
+		return ListRoute53ResolverRule(ctx, client, progress)
 	case "aws_route53_resolver_rule_association":
-		return ListRoute53ResolverRuleAssociation(client)
+		return ListRoute53ResolverRuleAssociation(ctx, client, progress)
 	case "aws_route53_zone":
-		return ListRoute53Zone(client)
+		return ListRoute53Zone(ctx, client, progress)
 	case "aws_route_table":
-		return ListRouteTable(client)
+		return ListRouteTable(ctx, client, progress)
 	case "aws_s3_bucket":
-		return ListS3Bucket(client)
+		return ListS3Bucket(ctx, client, progress)
 	case "aws_sagemaker_endpoint":
-		return ListSagemakerEndpoint(client)
+		return ListSagemakerEndpoint(ctx, client, progress)
 	case "aws_sagemaker_model":
-		return ListSagemakerModel(client)
+		return ListSagemakerModel(ctx, client, progress)
 	case "aws_secretsmanager_secret":
-		return ListSecretsmanagerSecret(client)
+		return ListSecretsmanagerSecret(ctx, client, progress)
 	case "aws_security_group":
-		return ListSecurityGroup(client)
+		return ListSecurityGroup(ctx, client, progress)
 	case "aws_service_discovery_service":
-		return ListServiceDiscoveryService(client)
+		return ListServiceDiscoveryService(ctx, client, progress)
 	case "aws_servicecatalog_portfolio":
-		return ListServicecatalogPortfolio(client)
+		return ListServicecatalogPortfolio(ctx, client, progress)
 	case "aws_ses_active_receipt_rule_set":
-		return ListSesActiveReceiptRuleSet(client)
+		return ListSesActiveReceiptRuleSet(ctx, client, progress)
 	case "aws_ses_configuration_set":
-		return ListSesConfigurationSet(client)
+		return ListSesConfigurationSet(ctx, client, progress)
 	case "aws_ses_receipt_filter":
-		return ListSesReceiptFilter(client)
+		return ListSesReceiptFilter(ctx, client, progress)
 	case "aws_ses_receipt_rule_set":
-		return ListSesReceiptRuleSet(client)
+		return ListSesReceiptRuleSet(ctx, client, progress)
 	case "aws_ses_template":
-		return ListSesTemplate(client)
+		return ListSesTemplate(ctx, client, progress)
 	case "aws_sfn_activity":
-		return ListSfnActivity(client)
+		return ListSfnActivity(ctx, client, progress)
 	case "aws_sfn_state_machine":
-		return ListSfnStateMachine(client)
+		return ListSfnStateMachine(ctx, client, progress)
 	case "aws_sns_platform_application":
-		return ListSnsPlatformApplication(client)
+		return ListSnsPlatformApplication(ctx, client, progress)
 	case "aws_sns_topic":
-		return ListSnsTopic(client)
+		return ListSnsTopic(ctx, client, progress)
 	case "aws_sns_topic_subscription":
-		return ListSnsTopicSubscription(client)
+		return ListSnsTopicSubscription(ctx, client, progress)
 	case "aws_spot_fleet_request":
-		return ListSpotFleetRequest(client)
+		return ListSpotFleetRequest(ctx, client, progress)
 	case "aws_spot_instance_request":
-		return ListSpotInstanceRequest(client)
+		return ListSpotInstanceRequest(ctx, client, progress)
 	case "aws_ssm_activation":
-		return ListSsmActivation(client)
+		return ListSsmActivation(ctx, client, progress)
 	case "aws_ssm_association":
-		return ListSsmAssociation(client)
+		return ListSsmAssociation(ctx, client, progress)
 	case "aws_ssm_document":
-		return ListSsmDocument(client)
+		return ListSsmDocument(ctx, client, progress)
 	case "aws_ssm_maintenance_window":
-		return ListSsmMaintenanceWindow(client)
+		return ListSsmMaintenanceWindow(ctx, client, progress)
 	case "aws_ssm_parameter":
-		return ListSsmParameter(client)
+		return ListSsmParameter(ctx, client, progress)
 	case "aws_ssm_patch_baseline":
-		return ListSsmPatchBaseline(client)
+		return ListSsmPatchBaseline(ctx, client, progress)
 	case "aws_ssm_patch_group":
-		return ListSsmPatchGroup(client)
+		return ListSsmPatchGroup(ctx, client, progress)
 	case "aws_ssm_resource_data_sync":
-		return ListSsmResourceDataSync(client)
+		return ListSsmResourceDataSync(ctx, client, progress)
 	case "aws_storagegateway_gateway":
-		return ListStoragegatewayGateway(client)
+		return ListStoragegatewayGateway(ctx, client, progress)
 	case "aws_subnet":
-		return ListSubnet(client)
+		return ListSubnet(ctx, client, progress)
 	case "aws_transfer_server":
-		return ListTransferServer(client)
+		return ListTransferServer(ctx, client, progress)
 	case "aws_vpc":
-		return ListVpc(client)
+		return ListVpc(ctx, client, progress)
 	case "aws_vpc_endpoint":
-		return ListVpcEndpoint(client)
+		return ListVpcEndpoint(ctx, client, progress)
 	case "aws_vpc_endpoint_connection_notification":
-		return ListVpcEndpointConnectionNotification(client)
+		return ListVpcEndpointConnectionNotification(ctx, client, progress)
 	case "aws_vpc_endpoint_service":
-		return ListVpcEndpointService(client)
+		return ListVpcEndpointService(ctx, client, progress)
 	case "aws_vpc_peering_connection":
-		return ListVpcPeeringConnection(client)
+		return ListVpcPeeringConnection(ctx, client, progress)
 	case "aws_vpn_gateway":
-		return ListVpnGateway(client)
+		return ListVpnGateway(ctx, client, progress)
 	case "aws_waf_byte_match_set":
-		return ListWafByteMatchSet(client)
+		return ListWafByteMatchSet(ctx, client, progress)
 	case "aws_waf_geo_match_set":
-		return ListWafGeoMatchSet(client)
+		return ListWafGeoMatchSet(ctx, client, progress)
 	case "aws_waf_ipset":
-		return ListWafIpset(client)
+		return ListWafIpset(ctx, client, progress)
 	case "aws_waf_rate_based_rule":
-		return ListWafRateBasedRule(client)
+		return ListWafRateBasedRule(ctx, client, progress)
 	case "aws_waf_regex_match_set":
-		return ListWafRegexMatchSet(client)
+		return ListWafRegexMatchSet(ctx, client, progress)
 	case "aws_waf_regex_pattern_set":
-		return ListWafRegexPatternSet(client)
+		return ListWafRegexPatternSet(ctx, client, progress)
 	case "aws_waf_rule":
-		return ListWafRule(client)
+		return ListWafRule(ctx, client, progress)
 	case "aws_waf_rule_group":
-		return ListWafRuleGroup(client)
+		return ListWafRuleGroup(ctx, client, progress)
 	case "aws_waf_size_constraint_set":
-		return ListWafSizeConstraintSet(client)
+		return ListWafSizeConstraintSet(ctx, client, progress)
 	case "aws_waf_sql_injection_match_set":
-		return ListWafSqlInjectionMatchSet(client)
+		return ListWafSqlInjectionMatchSet(ctx, client, progress)
 	case "aws_waf_web_acl":
-		return ListWafWebAcl(client)
+		return ListWafWebAcl(ctx, client, progress)
 	case "aws_waf_xss_match_set":
-		return ListWafXssMatchSet(client)
+		return ListWafXssMatchSet(ctx, client, progress)
 	case "aws_wafregional_byte_match_set":
-		return ListWafregionalByteMatchSet(client)
+		return ListWafregionalByteMatchSet(ctx, client, progress)
 	case "aws_wafregional_geo_match_set":
-		return ListWafregionalGeoMatchSet(client)
+		return ListWafregionalGeoMatchSet(ctx, client, progress)
 	case "aws_wafregional_ipset":
-		return ListWafregionalIpset(client)
+		return ListWafregionalIpset(ctx, client, progress)
 	case "aws_wafregional_rate_based_rule":
-		return ListWafregionalRateBasedRule(client)
+		return ListWafregionalRateBasedRule(ctx, client, progress)
 	case "aws_wafregional_regex_match_set":
-		return ListWafregionalRegexMatchSet(client)
+		return ListWafregionalRegexMatchSet(ctx, client, progress)
 	case "aws_wafregional_regex_pattern_set":
-		return ListWafregionalRegexPatternSet(client)
+		return ListWafregionalRegexPatternSet(ctx, client, progress)
 	case "aws_wafregional_rule":
-		return ListWafregionalRule(client)
+		return ListWafregionalRule(ctx, client, progress)
 	case "aws_wafregional_rule_group":
-		return ListWafregionalRuleGroup(client)
+		return ListWafregionalRuleGroup(ctx, client, progress)
 	case "aws_wafregional_size_constraint_set":
-		return ListWafregionalSizeConstraintSet(client)
+		return ListWafregionalSizeConstraintSet(ctx, client, progress)
 	case "aws_wafregional_sql_injection_match_set":
-		return ListWafregionalSqlInjectionMatchSet(client)
+		return ListWafregionalSqlInjectionMatchSet(ctx, client, progress)
 	case "aws_wafregional_web_acl":
-		return ListWafregionalWebAcl(client)
+		return ListWafregionalWebAcl(ctx, client, progress)
 	case "aws_wafregional_xss_match_set":
-		return ListWafregionalXssMatchSet(client)
+		return ListWafregionalXssMatchSet(ctx, client, progress)
 	case "aws_wafv2_web_acl_logging_configuration":
-		return ListWafv2WebAclLoggingConfiguration(client)
+		return ListWafv2WebAclLoggingConfiguration(ctx, client, progress)
 	case "aws_worklink_fleet":
-		return ListWorklinkFleet(client)
+		return ListWorklinkFleet(ctx, client, progress)
 	case "aws_workspaces_ip_group":
-		return ListWorkspacesIpGroup(client)
+		return ListWorkspacesIpGroup(ctx, client, progress)
 	default:
 		return nil, fmt.Errorf("resource type is not (yet) supported: %s", resourceType)
 	}
@@ -0,0 +1,226 @@
+// Code is generated. DO NOT EDIT.
+
+package aws
+
+// ListOperations maps each supported resource type to the AWS API operation used for listing it,
+// in the notation of IAM actions (e.g., ec2:DescribeInstances).
+var ListOperations = map[string]string{
+	"aws_accessanalyzer_analyzer":                       "access-analyzer:ListAnalyzers",
+	"aws_acm_certificate":                               "acm:ListCertificates",
+	"aws_alb_target_group":                              "elasticloadbalancing:DescribeTargetGroups",
+	"aws_ami":                                           "ec2:DescribeImages",
+	"aws_api_gateway_api_key":                           "apigateway:GetApiKeys",
+	"aws_api_gateway_client_certificate":                "apigateway:GetClientCertificates",
+	"aws_api_gateway_domain_name":                       "apigateway:GetDomainNames",
+	"aws_api_gateway_rest_api":                          "apigateway:GetRestApis",
+	"aws_api_gateway_usage_plan":                        "apigateway:GetUsagePlans",
+	"aws_api_gateway_vpc_link":                          "apigateway:GetVpcLinks",
+	"aws_apigatewayv2_api":                              "apigateway:GetApis",
+	"aws_apigatewayv2_domain_name":                      "apigateway:GetDomainNames",
+	"aws_apigatewayv2_vpc_link":                         "apigateway:GetVpcLinks",
+	"aws_appmesh_mesh":                                  "appmesh:ListMeshes",
+	"aws_appsync_graphql_api":                           "appsync:ListGraphqlApis",
+	"aws_athena_workgroup":                              "athena:ListWorkGroups",
+	"aws_autoscaling_group":                             "autoscaling:DescribeAutoScalingGroups",
+	"aws_backup_plan":                                   "backup:ListBackupPlans",
+	"aws_backup_vault":                                  "backup:ListBackupVaults",
+	"aws_batch_compute_environment":                     "batch:DescribeComputeEnvironments",
+	"aws_batch_job_definition":                          "batch:DescribeJobDefinitions",
+	"aws_batch_job_queue":                               "batch:DescribeJobQueues",
+	"aws_cloudformation_stack":                          "cloudformation:DescribeStacks",
+	"aws_cloudformation_stack_set":                      "cloudformation:ListStackSets",
+	"aws_cloudhsm_v2_cluster":                           "cloudhsm:DescribeClusters",
+	"aws_cloudwatch_dashboard":                          "cloudwatch:ListDashboards",
+	"aws_cloudwatch_event_rule":                         "events:ListRules",
+	"aws_cloudwatch_log_destination":                    "logs:DescribeDestinations",
+	"aws_cloudwatch_log_group":                          "logs:DescribeLogGroups",
+	"aws_cloudwatch_log_resource_policy":                "logs:DescribeResourcePolicies",
+	"aws_codebuild_source_credential":                   "codebuild:ListSourceCredentials",
+	"aws_codecommit_repository":                         "codecommit:ListRepositories",
+	"aws_codepipeline_webhook":                          "codepipeline:ListWebhooks",
+	"aws_codestarnotifications_notification_rule":       "codestar-notifications:ListNotificationRules",
+	"aws_config_config_rule":                            "config:DescribeConfigRules",
+	"aws_config_configuration_recorder":                 "config:DescribeConfigurationRecorders",
+	"aws_config_delivery_channel":                       "config:DescribeDeliveryChannels",
+	"aws_cur_report_definition":                         "cur:DescribeReportDefinitions",
+	"aws_datasync_agent":                                "datasync:ListAgents",
+	"aws_datasync_task":                                 "datasync:ListTasks",
+	"aws_dax_parameter_group":                           "dax:DescribeParameterGroups",
+	"aws_dax_subnet_group":                              "dax:DescribeSubnetGroups",
+	"aws_db_event_subscription":                         "rds:DescribeEventSubscriptions",
+	"aws_db_instance":                                   "rds:DescribeDBInstances",
+	"aws_db_parameter_group":                            "rds:DescribeDBParameterGroups",
+	"aws_db_security_group":                             "rds:DescribeDBSecurityGroups",
+	"aws_db_snapshot":                                   "rds:DescribeDBSnapshots",
+	"aws_db_subnet_group":                               "rds:DescribeDBSubnetGroups",
+	"aws_devicefarm_project":                            "devicefarm:ListProjects",
+	"aws_dlm_lifecycle_policy":                          "dlm:GetLifecyclePolicies",
+	"aws_dms_certificate":                               "dms:DescribeCertificates",
+	"aws_dms_endpoint":                                  "dms:DescribeEndpoints",
+	"aws_dms_replication_subnet_group":                  "dms:DescribeReplicationSubnetGroups",
+	"aws_dms_replication_task":                          "dms:DescribeReplicationTasks",
+	"aws_dx_connection":                                 "directconnect:DescribeConnections",
+	"aws_dx_hosted_private_virtual_interface":           "directconnect:DescribeVirtualInterfaces",
+	"aws_dx_hosted_public_virtual_interface":            "directconnect:DescribeVirtualInterfaces",
+	"aws_dx_hosted_transit_virtual_interface":           "directconnect:DescribeVirtualInterfaces",
+	"aws_dx_lag":                                        "directconnect:DescribeLags",
+	"aws_dx_private_virtual_interface":                  "directconnect:DescribeVirtualInterfaces",
+	"aws_dx_public_virtual_interface":                   "directconnect:DescribeVirtualInterfaces",
+	"aws_dx_transit_virtual_interface":                  "directconnect:DescribeVirtualInterfaces",
+	"aws_dynamodb_global_table":                         "dynamodb:ListGlobalTables",
+	"aws_ebs_snapshot":                                  "ec2:DescribeSnapshots",
+	"aws_ebs_volume":                                    "ec2:DescribeVolumes",
+	"aws_ec2_capacity_reservation":                      "ec2:DescribeCapacityReservations",
+	"aws_ec2_client_vpn_endpoint":                       "ec2:DescribeClientVpnEndpoints",
+	"aws_ec2_fleet":                                     "ec2:DescribeFleets",
+	"aws_ec2_local_gateway_route_table_vpc_association": "ec2:DescribeLocalGatewayRouteTableVpcAssociations",
+	"aws_ec2_traffic_mirror_filter":                     "ec2:DescribeTrafficMirrorFilters",
+	"aws_ec2_traffic_mirror_session":                    "ec2:DescribeTrafficMirrorSessions",
+	"aws_ec2_traffic_mirror_target":                     "ec2:DescribeTrafficMirrorTargets",
+	"aws_ec2_transit_gateway":                           "ec2:DescribeTransitGateways",
+	"aws_ec2_transit_gateway_peering_attachment":        "ec2:DescribeTransitGatewayPeeringAttachments",
+	"aws_ec2_transit_gateway_route_table":               "ec2:DescribeTransitGatewayRouteTables",
+	"aws_ec2_transit_gateway_vpc_attachment":            "ec2:DescribeTransitGatewayVpcAttachments",
+	"aws_ecr_repository":                                "ecr:DescribeRepositories",
+	"aws_ecs_cluster":                                   "ecs:DescribeClusters",
+	"aws_efs_access_point":                              "elasticfilesystem:DescribeAccessPoints",
+	"aws_efs_file_system":                               "elasticfilesystem:DescribeFileSystems",
+	"aws_egress_only_internet_gateway":                  "ec2:DescribeEgressOnlyInternetGateways",
+	"aws_eip":                                           "ec2:DescribeAddresses",
+	"aws_elastic_beanstalk_application":                 "elasticbeanstalk:DescribeApplications",
+	"aws_elastic_beanstalk_application_version":         "elasticbeanstalk:DescribeApplicationVersions",
+	"aws_elastic_beanstalk_environment":                 "elasticbeanstalk:DescribeEnvironments",
+	"aws_elasticache_replication_group":                 "elasticache:DescribeReplicationGroups",
+	"aws_elastictranscoder_pipeline":                    "elastictranscoder:ListPipelines",
+	"aws_elastictranscoder_preset":                      "elastictranscoder:ListPresets",
+	"aws_elb":                                           "elasticloadbalancing:DescribeLoadBalancers",
+	"aws_emr_security_configuration":                    "elasticmapreduce:ListSecurityConfigurations",
+	"aws_fsx_lustre_file_system":                        "fsx:DescribeFileSystems",
+	"aws_fsx_windows_file_system":                       "fsx:DescribeFileSystems",
+	"aws_gamelift_alias":                                "gamelift:ListAliases",
+	"aws_gamelift_build":                                "gamelift:ListBuilds",
+	"aws_gamelift_game_session_queue":                   "gamelift:DescribeGameSessionQueues",
+	"aws_globalaccelerator_accelerator":                 "globalaccelerator:ListAccelerators",
+	"aws_glue_crawler":                                  "glue:GetCrawlers",
+	"aws_glue_job":                                      "glue:GetJobs",
+	"aws_glue_security_configuration":                   "glue:GetSecurityConfigurations",
+	"aws_glue_trigger":                                  "glue:GetTriggers",
+	"aws_iam_access_key":                                "iam:ListAccessKeys",
+	"aws_iam_group":                                     "iam:ListGroups",
+	"aws_iam_instance_profile":                          "iam:ListInstanceProfiles",
+	"aws_iam_policy":                                    "iam:ListPolicies",
+	"aws_iam_role":                                      "iam:ListRoles",
+	"aws_iam_server_certificate":                        "iam:ListServerCertificates",
+	"aws_iam_service_linked_role":                       "iam:ListRoles",
+	"aws_iam_user":                                      "iam:ListUsers",
+	"aws_instance":                                      "ec2:DescribeInstances",
+	"aws_internet_gateway":                              "ec2:DescribeInternetGateways",
+	"aws_iot_certificate":                               "iot:ListCertificates",
+	"aws_iot_policy":                                    "iot:ListPolicies",
+	"aws_iot_thing":                                     "iot:ListThings",
+	"aws_iot_thing_type":                                "iot:ListThingTypes",
+	"aws_iot_topic_rule":                                "iot:ListTopicRules",
+	"aws_key_pair":                                      "ec2:DescribeKeyPairs",
+	"aws_kinesis_analytics_application":                 "kinesisanalytics:ListApplications",
+	"aws_kms_external_key":                              "kms:ListKeys",
+	"aws_kms_key":                                       "kms:ListKeys",
+	"aws_lambda_event_source_mapping":                   "lambda:ListEventSourceMappings",
+	"aws_lambda_function":                               "lambda:ListFunctions",
+	"aws_launch_configuration":                          "autoscaling:DescribeLaunchConfigurations",
+	"aws_launch_template":                               "ec2:DescribeLaunchTemplates",
+	"aws_lb_target_group":                               "elasticloadbalancing:DescribeTargetGroups",
+	"aws_licensemanager_license_configuration":          "license-manager:ListLicenseConfigurations",
+	"aws_lightsail_domain":                              "lightsail:GetDomains",
+	"aws_lightsail_instance":                            "lightsail:GetInstances",
+	"aws_lightsail_key_pair":                            "lightsail:GetKeyPairs",
+	"aws_lightsail_static_ip":                           "lightsail:GetStaticIps",
+	"aws_media_convert_queue":                           "mediaconvert:ListQueues",
+	"aws_media_package_channel":                         "mediapackage:ListChannels",
+	"aws_media_store_container":                         "mediastore:ListContainers",
+	"aws_mq_broker":                                     "mq:ListBrokers",
+	"aws_mq_configuration":                              "mq:ListConfigurations",
+	"aws_msk_cluster":                                   "kafka:ListClusters",
+	"aws_msk_configuration":                             "kafka:ListConfigurations",
+	"aws_nat_gateway":                                   "ec2:DescribeNatGateways",
+	"aws_neptune_event_subscription":                    "rds:DescribeEventSubscriptions",
+	"aws_network_acl":                                   "ec2:DescribeNetworkAcls",
+	"aws_network_interface":                             "ec2:DescribeNetworkInterfaces",
+	"aws_opsworks_stack":                                "opsworks:DescribeStacks",
+	"aws_opsworks_user_profile":                         "opsworks:DescribeUserProfiles",
+	"aws_placement_group":                               "ec2:DescribePlacementGroups",
+	"aws_qldb_ledger":                                   "qldb:ListLedgers",
+	"aws_rds_global_cluster":                            "rds:DescribeGlobalClusters",
+	"aws_redshift_cluster":                              "redshift:DescribeClusters",
+	"aws_redshift_event_subscription":                   "redshift:DescribeEventSubscriptions",
+	"aws_redshift_snapshot_copy_grant":                  "redshift:DescribeSnapshotCopyGrants",
+	"aws_redshift_snapshot_schedule":                    "redshift:DescribeSnapshotSchedules",
+	"aws_route53_health_check":                          "route53:ListHealthChecks",
+	"aws_route53_resolver_endpoint":                     "route53resolver:ListResolverEndpoints",
+	"aws_route53_resolver_rule":                         "route53resolver:ListResolverRules",
+	"aws_route53_resolver_rule_association":             "route53resolver:ListResolverRuleAssociations",
+	"aws_route53_zone":                                  "route53:ListHostedZones",
+	"aws_route_table":                                   "ec2:DescribeRouteTables",
+	"aws_s3_bucket":                                     "s3:ListBuckets",
+	"aws_sagemaker_endpoint":                            "sagemaker:ListEndpoints",
+	"aws_sagemaker_model":                               "sagemaker:ListModels",
+	"aws_secretsmanager_secret":                         "secretsmanager:ListSecrets",
+	"aws_security_group":                                "ec2:DescribeSecurityGroups",
+	"aws_service_discovery_service":                     "servicediscovery:ListServices",
+	"aws_servicecatalog_portfolio":                      "servicecatalog:ListPortfolios",
+	"aws_ses_active_receipt_rule_set":                   "ses:ListReceiptRuleSets",
+	"aws_ses_configuration_set":                         "ses:ListConfigurationSets",
+	"aws_ses_receipt_filter":                            "ses:ListReceiptFilters",
+	"aws_ses_receipt_rule_set":                          "ses:ListReceiptRuleSets",
+	"aws_ses_template":                                  "ses:ListTemplates",
+	"aws_sfn_activity":                                  "states:ListActivities",
+	"aws_sfn_state_machine":                             "states:ListStateMachines",
+	"aws_sns_platform_application":                      "sns:ListPlatformApplications",
+	"aws_sns_topic":                                     "sns:ListTopics",
+	"aws_sns_topic_subscription":                        "sns:ListSubscriptions",
+	"aws_spot_fleet_request":                            "ec2:DescribeSpotFleetRequests",
+	"aws_spot_instance_request":                         "ec2:DescribeSpotInstanceRequests",
+	"aws_ssm_activation":                                "ssm:DescribeActivations",
+	"aws_ssm_association":                               "ssm:ListAssociations",
+	"aws_ssm_document":                                  "ssm:ListDocuments",
+	"aws_ssm_maintenance_window":                        "ssm:DescribeMaintenanceWindows",
+	"aws_ssm_parameter":                                 "ssm:DescribeParameters",
+	"aws_ssm_patch_baseline":                            "ssm:DescribePatchBaselines",
+	"aws_ssm_patch_group":                               "ssm:DescribePatchGroups",
+	"aws_ssm_resource_data_sync":                        "ssm:ListResourceDataSync",
+	"aws_storagegateway_gateway":                        "storagegateway:ListGateways",
+	"aws_subnet":                                        "ec2:DescribeSubnets",
+	"aws_transfer_server":                               "transfer:ListServers",
+	"aws_vpc":                                           "ec2:DescribeVpcs",
+	"aws_vpc_endpoint":                                  "ec2:DescribeVpcEndpoints",
+	"aws_vpc_endpoint_connection_notification":          "ec2:DescribeVpcEndpointConnectionNotifications",
+	"aws_vpc_endpoint_service":                          "ec2:DescribeVpcEndpointServices",
+	"aws_vpc_peering_connection":                        "ec2:DescribeVpcPeeringConnections",
+	"aws_vpn_gateway":                                   "ec2:DescribeVpnGateways",
+	"aws_waf_byte_match_set":                            "waf:ListByteMatchSets",
+	"aws_waf_geo_match_set":                             "waf:ListGeoMatchSets",
+	"aws_waf_ipset":                                     "waf:ListIPSets",
+	"aws_waf_rate_based_rule":                           "waf:ListRateBasedRules",
+	"aws_waf_regex_match_set":                           "waf:ListRegexMatchSets",
+	"aws_waf_regex_pattern_set":                         "waf:ListRegexPatternSets",
+	"aws_waf_rule":                                      "waf:ListRules",
+	"aws_waf_rule_group":                                "waf:ListRuleGroups",
+	"aws_waf_size_constraint_set":                       "waf:ListSizeConstraintSets",
+	"aws_waf_sql_injection_match_set":                   "waf:ListSqlInjectionMatchSets",
+	"aws_waf_web_acl":                                   "waf:ListWebACLs",
+	"aws_waf_xss_match_set":                             "waf:ListXssMatchSets",
+	"aws_wafregional_byte_match_set":                    "waf-regional:ListByteMatchSets",
+	"aws_wafregional_geo_match_set":                     "waf-regional:ListGeoMatchSets",
+	"aws_wafregional_ipset":                             "waf-regional:ListIPSets",
+	"aws_wafregional_rate_based_rule":                   "waf-regional:ListRateBasedRules",
+	"aws_wafregional_regex_match_set":                   "waf-regional:ListRegexMatchSets",
+	"aws_wafregional_regex_pattern_set":                 "waf-regional:ListRegexPatternSets",
+	"aws_wafregional_rule":                              "waf-regional:ListRules",
+	"aws_wafregional_rule_group":                        "waf-regional:ListRuleGroups",
+	"aws_wafregional_size_constraint_set":               "waf-regional:ListSizeConstraintSets",
+	"aws_wafregional_sql_injection_match_set":           "waf-regional:ListSqlInjectionMatchSets",
+	"aws_wafregional_web_acl":                           "waf-regional:ListWebACLs",
+	"aws_wafregional_xss_match_set":                     "waf-regional:ListXssMatchSets",
+	"aws_wafv2_web_acl_logging_configuration":           "wafv2:ListLoggingConfigurations",
+	"aws_worklink_fleet":                                "worklink:ListFleets",
+	"aws_workspaces_ip_group":                           "workspaces:DescribeIpGroups",
+}
@@ -0,0 +1,32 @@
+package aws_test
+
+import (
+	"testing"
+
+	"github.com/jckuester/awsls/aws"
+	"github.com/jckuester/awsls/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListOperations(t *testing.T) {
+	for _, rType := range resource.SupportedTypes {
+		assert.Contains(t, aws.ListOperations, rType, "list operation missing for supported resource type")
+	}
+}
+
+func TestListOperations_iamPrefix(t *testing.T) {
+	tests := []struct {
+		rType string
+		want  string
+	}{
+		{rType: "aws_instance", want: "ec2:DescribeInstances"},
+		{rType: "aws_iot_thing", want: "iot:ListThings"},
+		{rType: "aws_cloudwatch_dashboard", want: "cloudwatch:ListDashboards"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.rType, func(t *testing.T) {
+			assert.Equal(t, tc.want, aws.ListOperations[tc.rType])
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	awsSDK "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+)
+
+// ListAvailableVolumeIDs returns the IDs of the EBS volumes in the account and region of the client
+// that are not attached to any instance (i.e., in state available).
+func (client *Client) ListAvailableVolumeIDs(ctx context.Context) (map[string]bool, error) {
+	req := client.Ec2conn.DescribeVolumesRequest(&ec2.DescribeVolumesInput{
+		Filters: []ec2.Filter{{Name: awsSDK.String("status"), Values: []string{"available"}}},
+	})
+
+	result := map[string]bool{}
+
+	p := ec2.NewDescribeVolumesPaginator(req)
+	for p.Next(ctx) {
+		for _, v := range p.CurrentPage().Volumes {
+			result[*v.VolumeId] = true
+		}
+	}
+
+	if err := p.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list available volumes: %s", err)
+	}
+
+	return result, nil
+}
+
+// ListUnusedSecurityGroupIDs returns the IDs of the security groups in the account and region of the client
+// that are not associated with any network interface. Default security groups are never returned,
+// as they can't be deleted.
+func (client *Client) ListUnusedSecurityGroupIDs(ctx context.Context) (map[string]bool, error) {
+	result := map[string]bool{}
+
+	sgs := ec2.NewDescribeSecurityGroupsPaginator(
+		client.Ec2conn.DescribeSecurityGroupsRequest(&ec2.DescribeSecurityGroupsInput{}))
+	for sgs.Next(ctx) {
+		for _, sg := range sgs.CurrentPage().SecurityGroups {
+			if sg.GroupName != nil && *sg.GroupName == "default" {
+				continue
+			}
+
+			result[*sg.GroupId] = true
+		}
+	}
+
+	if err := sgs.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list security groups: %s", err)
+	}
+
+	enis := ec2.NewDescribeNetworkInterfacesPaginator(
+		client.Ec2conn.DescribeNetworkInterfacesRequest(&ec2.DescribeNetworkInterfacesInput{}))
+	for enis.Next(ctx) {
+		for _, eni := range enis.CurrentPage().NetworkInterfaces {
+			for _, g := range eni.Groups {
+				delete(result, *g.GroupId)
+			}
+		}
+	}
+
+	if err := enis.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list network interfaces: %s", err)
+	}
+
+	return result, nil
+}
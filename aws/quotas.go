@@ -0,0 +1,42 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws/awserr"
+	"github.com/aws/aws-sdk-go-v2/service/servicequotas"
+)
+
+// GetServiceQuota returns the value of a quota that applies to the account and region of the client.
+// If the quota hasn't been changed for the account, the AWS default value is returned.
+func (client *Client) GetServiceQuota(serviceCode, quotaCode string) (float64, error) {
+	req := client.Servicequotasconn.GetServiceQuotaRequest(&servicequotas.GetServiceQuotaInput{
+		ServiceCode: &serviceCode,
+		QuotaCode:   &quotaCode,
+	})
+
+	resp, err := req.Send(context.Background())
+	if err == nil {
+		return *resp.Quota.Value, nil
+	}
+
+	var awsErr awserr.Error
+	if !errors.As(err, &awsErr) || awsErr.Code() != servicequotas.ErrCodeNoSuchResourceException {
+		return 0, fmt.Errorf("failed to get service quota: %s", err)
+	}
+
+	defaultReq := client.Servicequotasconn.GetAWSDefaultServiceQuotaRequest(
+		&servicequotas.GetAWSDefaultServiceQuotaInput{
+			ServiceCode: &serviceCode,
+			QuotaCode:   &quotaCode,
+		})
+
+	defaultResp, err := defaultReq.Send(context.Background())
+	if err != nil {
+		return 0, fmt.Errorf("failed to get default service quota: %s", err)
+	}
+
+	return *defaultResp.Quota.Value, nil
+}
@@ -0,0 +1,98 @@
+package aws_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	awsSDK "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/external"
+	"github.com/jckuester/awsls/util"
+)
+
+func TestClient_GetServiceQuota(t *testing.T) {
+	tests := []struct {
+		name string
+		// responses of the Service Quotas API, which fails for a missing response
+		appliedQuota string
+		defaultQuota string
+		// the quota hasn't been changed for the account, so only its AWS default value exists
+		notChanged bool
+		want       float64
+		wantErr    string
+	}{
+		{
+			name:         "quota changed for account",
+			appliedQuota: `{"Quota": {"Value": 200}}`,
+			defaultQuota: `{"Quota": {"Value": 100}}`,
+			want:         200,
+		},
+		{
+			name:         "quota not changed for account",
+			notChanged:   true,
+			defaultQuota: `{"Quota": {"Value": 100}}`,
+			want:         100,
+		},
+		{
+			name:    "error",
+			wantErr: "failed to get service quota",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch strings.TrimPrefix(r.Header.Get("X-Amz-Target"), "ServiceQuotasV20190624.") {
+				case "GetServiceQuota":
+					if tt.notChanged {
+						w.WriteHeader(http.StatusBadRequest)
+						_, _ = w.Write([]byte(`{"__type": "NoSuchResourceException", "message": "not found"}`))
+						return
+					}
+
+					if tt.appliedQuota != "" {
+						_, _ = w.Write([]byte(tt.appliedQuota))
+						return
+					}
+				case "GetAWSDefaultServiceQuota":
+					if tt.defaultQuota != "" {
+						_, _ = w.Write([]byte(tt.defaultQuota))
+						return
+					}
+				}
+
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"__type": "AccessDeniedException", "message": "denied"}`))
+			}))
+			defer server.Close()
+
+			clients, err := util.NewAWSClientPool(nil, []string{"us-test-1"},
+				external.WithCredentialsProvider{
+					CredentialsProvider: awsSDK.NewStaticCredentialsProvider("test", "test", "")},
+				util.WithEndpointURL(server.URL),
+				util.WithMaxRetries(0))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			client := clients[util.AWSClientKey{Region: "us-test-1"}]
+
+			got, err := client.GetServiceQuota("vpc", "L-F678F1CE")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("GetServiceQuota() error = %v, want error containing %q", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tt.want {
+				t.Errorf("GetServiceQuota() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
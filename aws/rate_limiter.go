@@ -0,0 +1,23 @@
+package aws
+
+import (
+	awsSDK "github.com/aws/aws-sdk-go-v2/aws"
+	"golang.org/x/time/rate"
+)
+
+// WithRateLimiter is a config that makes NewClient wait for the given limiter before sending any request
+// (including retries), so that a limiter shared by multiple clients limits the rate of all their requests in total.
+type WithRateLimiter struct {
+	*rate.Limiter
+}
+
+// waitForLimiter returns a request handler that waits until the limiter allows another request,
+// or fails the request if its context is done before.
+func waitForLimiter(limiter *rate.Limiter) func(*awsSDK.Request) {
+	return func(r *awsSDK.Request) {
+		err := limiter.Wait(r.Context())
+		if err != nil {
+			r.Error = err
+		}
+	}
+}
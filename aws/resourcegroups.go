@@ -0,0 +1,111 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroups"
+)
+
+// ListResourceGroupARNs returns the ARNs of all resources that are members of the given resource group
+// in the account and region of the client.
+func (client *Client) ListResourceGroupARNs(groupName string) ([]string, error) {
+	req := client.Resourcegroupsconn.ListGroupResourcesRequest(&resourcegroups.ListGroupResourcesInput{
+		GroupName: &groupName,
+	})
+
+	var result []string
+
+	p := resourcegroups.NewListGroupResourcesPaginator(req)
+	for p.Next(context.Background()) {
+		page := p.CurrentPage()
+
+		for _, r := range page.ResourceIdentifiers {
+			result = append(result, *r.ResourceArn)
+		}
+	}
+
+	if err := p.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list resources of resource group %s: %s", groupName, err)
+	}
+
+	return result, nil
+}
+
+// arnResourceTypes are the resource types in the ARNs of Terraform resource types whose name doesn't contain
+// the resource type of their ARN (e.g., the ARN of an aws_ami is arn:aws:ec2:us-east-1:123456789012:image/ami-1234).
+var arnResourceTypes = map[string]string{
+	"aws_alb":          "loadbalancer",
+	"aws_ami":          "image",
+	"aws_db_instance":  "db",
+	"aws_eip":          "elastic-ip",
+	"aws_elb":          "loadbalancer",
+	"aws_lb":           "loadbalancer",
+	"aws_route53_zone": "hostedzone",
+}
+
+// MatchesARN returns true if the given ARN identifies the resource of the given type and ID
+// (e.g., arn:aws:ec2:us-east-1:123456789012:instance/i-1234 identifies the aws_instance i-1234).
+// Besides the ID, the service and the resource type in the ARN (if any) must belong to the resource type,
+// so that resources of different types with the same name (e.g., an IAM user and role) don't match.
+func MatchesARN(rType, id, arn string) bool {
+	if id == arn {
+		return true
+	}
+
+	// the resource part of an ARN is everything after the 5th colon
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) != 6 {
+		return false
+	}
+
+	if service := arnService(rType); service != "" && parts[2] != service {
+		return false
+	}
+
+	resourcePart := parts[5]
+	if resourcePart == id {
+		return true
+	}
+
+	// the resource part is either the ID or prefixed by the resource type (e.g., instance/i-1234 or
+	// function:my-function), and the ID can be prefixed by a path (e.g., role/path/my-role)
+	i := strings.IndexAny(resourcePart, "/:")
+	if i < 0 {
+		return false
+	}
+
+	resourceType, name := resourcePart[:i], resourcePart[i+1:]
+
+	if name != id && !strings.HasSuffix(name, "/"+id) {
+		return false
+	}
+
+	return matchesARNResourceType(rType, resourceType)
+}
+
+// arnService returns the service namespace in the ARNs of the given resource type, which is the prefix
+// of the IAM action used for listing it (e.g., iam for iam:ListUsers), or empty if unknown.
+func arnService(rType string) string {
+	op, ok := ListOperations[rType]
+	if !ok {
+		return ""
+	}
+
+	return strings.SplitN(op, ":", 2)[0]
+}
+
+// matchesARNResourceType returns true if the resource type of an ARN belongs to the given Terraform resource type,
+// i.e., it's contained in the name of the type (e.g., user in aws_iam_user, log-group in aws_cloudwatch_log_group).
+func matchesARNResourceType(rType, resourceType string) bool {
+	if t, ok := arnResourceTypes[rType]; ok {
+		return t == resourceType
+	}
+
+	normalize := func(s string) string {
+		return strings.NewReplacer("-", "", "_", "").Replace(strings.ToLower(s))
+	}
+
+	return strings.Contains(normalize(strings.TrimPrefix(rType, "aws_")), normalize(resourceType))
+}
@@ -0,0 +1,102 @@
+package aws_test
+
+import (
+	"testing"
+
+	"github.com/jckuester/awsls/aws"
+)
+
+func TestMatchesARN(t *testing.T) {
+	tests := []struct {
+		name  string
+		rType string
+		id    string
+		arn   string
+		want  bool
+	}{
+		{
+			name:  "ID is the ARN",
+			rType: "aws_iam_policy",
+			id:    "arn:aws:iam::123456789012:policy/foo",
+			arn:   "arn:aws:iam::123456789012:policy/foo",
+			want:  true,
+		},
+		{
+			name:  "resource part with resource type prefix",
+			rType: "aws_instance",
+			id:    "i-1234",
+			arn:   "arn:aws:ec2:us-east-1:123456789012:instance/i-1234",
+			want:  true,
+		},
+		{
+			name:  "resource part without resource type prefix",
+			rType: "aws_s3_bucket",
+			id:    "my-bucket",
+			arn:   "arn:aws:s3:::my-bucket",
+			want:  true,
+		},
+		{
+			name:  "resource part separated by colon",
+			rType: "aws_lambda_function",
+			id:    "my-function",
+			arn:   "arn:aws:lambda:us-east-1:123456789012:function:my-function",
+			want:  true,
+		},
+		{
+			name:  "resource type with hyphen",
+			rType: "aws_cloudwatch_log_group",
+			id:    "my-logs",
+			arn:   "arn:aws:logs:us-east-1:123456789012:log-group:my-logs",
+			want:  true,
+		},
+		{
+			name:  "ID with path",
+			rType: "aws_iam_role",
+			id:    "my-role",
+			arn:   "arn:aws:iam::123456789012:role/service-role/my-role",
+			want:  true,
+		},
+		{
+			name:  "resource type of ARN not contained in the Terraform type",
+			rType: "aws_ami",
+			id:    "ami-1234",
+			arn:   "arn:aws:ec2:us-east-1:123456789012:image/ami-1234",
+			want:  true,
+		},
+		{
+			name:  "same name, but different resource type",
+			rType: "aws_iam_user",
+			id:    "deploy",
+			arn:   "arn:aws:iam::123456789012:role/deploy",
+			want:  false,
+		},
+		{
+			name:  "same name, but different service",
+			rType: "aws_lambda_function",
+			id:    "my-function",
+			arn:   "arn:aws:states:us-east-1:123456789012:function:my-function",
+			want:  false,
+		},
+		{
+			name:  "ID is only a suffix of the resource name",
+			rType: "aws_instance",
+			id:    "1234",
+			arn:   "arn:aws:ec2:us-east-1:123456789012:instance/i-1234",
+			want:  false,
+		},
+		{
+			name:  "invalid ARN",
+			rType: "aws_instance",
+			id:    "i-1234",
+			arn:   "i-1234/foo",
+			want:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := aws.MatchesARN(tt.rType, tt.id, tt.arn); got != tt.want {
+				t.Errorf("MatchesARN() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
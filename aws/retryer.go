@@ -0,0 +1,11 @@
+package aws
+
+import (
+	awsSDK "github.com/aws/aws-sdk-go-v2/aws"
+)
+
+// WithRetryer is a config that makes NewClient retry failed requests of all services with the given retryer
+// instead of the SDK's default one.
+type WithRetryer struct {
+	awsSDK.Retryer
+}
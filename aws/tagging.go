@@ -0,0 +1,66 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
+)
+
+// ListTaggedResources returns the tags of all tagged resources in the account and region of the client by ARN,
+// as returned by the Resource Groups Tagging API.
+func (client *Client) ListTaggedResources(ctx context.Context) (map[string]map[string]string, error) {
+	req := client.Resourcegroupstaggingapiconn.GetResourcesRequest(&resourcegroupstaggingapi.GetResourcesInput{})
+
+	result := map[string]map[string]string{}
+
+	p := resourcegroupstaggingapi.NewGetResourcesPaginator(req)
+	for p.Next(ctx) {
+		page := p.CurrentPage()
+
+		for _, m := range page.ResourceTagMappingList {
+			if m.ResourceARN == nil {
+				continue
+			}
+
+			tags := map[string]string{}
+			for _, t := range m.Tags {
+				if t.Key != nil && t.Value != nil {
+					tags[*t.Key] = *t.Value
+				}
+			}
+
+			result[*m.ResourceARN] = tags
+		}
+	}
+
+	if err := p.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list tagged resources: %s", err)
+	}
+
+	return result, nil
+}
+
+// SetTaggedResourceTags sets the tags of the given resources of a type that have no tags from the list API
+// to the tags of the ARN identifying them (see ListTaggedResources). Resources whose ARN isn't unique are skipped.
+func SetTaggedResourceTags(rType string, resources []Resource, tagsByARN map[string]map[string]string) {
+	for i := range resources {
+		if resources[i].Tags != nil {
+			continue
+		}
+
+		var tags map[string]string
+		matches := 0
+
+		for arn, t := range tagsByARN {
+			if MatchesARN(rType, resources[i].ID, arn) {
+				tags = t
+				matches++
+			}
+		}
+
+		if matches == 1 {
+			resources[i].Tags = tags
+		}
+	}
+}
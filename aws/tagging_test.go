@@ -0,0 +1,103 @@
+package aws_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	awsSDK "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/external"
+	"github.com/jckuester/awsls/aws"
+	"github.com/jckuester/awsls/util"
+)
+
+func TestClient_ListTaggedResources(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Amz-Target") != "ResourceGroupsTaggingAPI_20170126.GetResources" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"ResourceTagMappingList": [
+			{"ResourceARN": "arn:aws:sqs:us-test-1:123456789012:my-queue",
+			 "Tags": [{"Key": "Name", "Value": "foo"}, {"Key": "env", "Value": "prod"}]}
+		]}`))
+	}))
+	defer server.Close()
+
+	clients, err := util.NewAWSClientPool(nil, []string{"us-test-1"},
+		external.WithCredentialsProvider{CredentialsProvider: awsSDK.NewStaticCredentialsProvider("test", "test", "")},
+		util.WithEndpointURL(server.URL),
+		util.WithMaxRetries(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := clients[util.AWSClientKey{Region: "us-test-1"}]
+
+	got, err := client.ListTaggedResources(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]map[string]string{
+		"arn:aws:sqs:us-test-1:123456789012:my-queue": {"Name": "foo", "env": "prod"},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ListTaggedResources() = %v, want %v", got, want)
+	}
+}
+
+func TestSetTaggedResourceTags(t *testing.T) {
+	tagsByARN := map[string]map[string]string{
+		"arn:aws:iam::123456789012:user/deploy":          {"team": "a"},
+		"arn:aws:iam::123456789012:role/deploy":          {"team": "b"},
+		"arn:aws:iam::123456789012:role/path/ci":         {"team": "c"},
+		"arn:aws:iam::123456789012:role/other-path/ci":   {"team": "d"},
+		"arn:aws:ec2:us-east-1:123456789012:vpc/vpc-123": {"team": "e"},
+	}
+
+	tests := []struct {
+		name      string
+		rType     string
+		resources []aws.Resource
+		want      []aws.Resource
+	}{
+		{
+			name:      "tags of the ARN with the same resource type",
+			rType:     "aws_iam_role",
+			resources: []aws.Resource{{Type: "aws_iam_role", ID: "deploy"}},
+			want:      []aws.Resource{{Type: "aws_iam_role", ID: "deploy", Tags: map[string]string{"team": "b"}}},
+		},
+		{
+			name:      "tags from the list API are kept",
+			rType:     "aws_iam_user",
+			resources: []aws.Resource{{Type: "aws_iam_user", ID: "deploy", Tags: map[string]string{}}},
+			want:      []aws.Resource{{Type: "aws_iam_user", ID: "deploy", Tags: map[string]string{}}},
+		},
+		{
+			name:      "ambiguous ARNs",
+			rType:     "aws_iam_role",
+			resources: []aws.Resource{{Type: "aws_iam_role", ID: "ci"}},
+			want:      []aws.Resource{{Type: "aws_iam_role", ID: "ci"}},
+		},
+		{
+			name:      "no matching ARN",
+			rType:     "aws_iam_role",
+			resources: []aws.Resource{{Type: "aws_iam_role", ID: "admin"}},
+			want:      []aws.Resource{{Type: "aws_iam_role", ID: "admin"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			aws.SetTaggedResourceTags(tt.rType, tt.resources, tagsByARN)
+
+			if !reflect.DeepEqual(tt.resources, tt.want) {
+				t.Errorf("SetTaggedResourceTags() = %v, want %v", tt.resources, tt.want)
+			}
+		})
+	}
+}
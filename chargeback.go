@@ -0,0 +1,93 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/jckuester/awsls/aws"
+	"github.com/jckuester/awsls/resource"
+)
+
+// unassignedOwner is the owner of resources that don't carry the owner tag.
+const unassignedOwner = "unassigned"
+
+// chargebackRow is the number of resources of a type that belong to an owner.
+type chargebackRow struct {
+	rType string
+	owner string
+	count int
+}
+
+// countByOwner counts the resources of the given type per value of the owner tag, sorted by owner.
+func countByOwner(rType string, resources []aws.Resource, ownerTag string) []chargebackRow {
+	counts := map[string]int{}
+
+	for i := range resources {
+		owner, ok := resource.GetTags(&resources[i])[ownerTag]
+		if !ok || owner == "" {
+			owner = unassignedOwner
+		}
+
+		counts[owner]++
+	}
+
+	var result []chargebackRow
+	for owner, count := range counts {
+		result = append(result, chargebackRow{rType: rType, owner: owner, count: count})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].owner < result[j].owner
+	})
+
+	return result
+}
+
+// printChargebackCsv writes the resource counts per type and owner in csv format into the output directory.
+func printChargebackCsv(outDir, resourceType string, rows []chargebackRow) error {
+	filePath := filepath.Join(outDir, resourceType+".chargeback.csv")
+	err := createOutDir(outDir)
+	if err != nil {
+		return err
+	}
+	csvFile, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer csvFile.Close()
+
+	err = writeChargebackCsv(csvFile, rows)
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Printf("printed csv file into %s \n", csvFile.Name())
+
+	return nil
+}
+
+// writeChargebackCsv writes the header and a row for each resource type and owner in csv format.
+func writeChargebackCsv(out io.Writer, rows []chargebackRow) error {
+	w := csv.NewWriter(out)
+
+	err := w.Write([]string{"TYPE", "OWNER", "COUNT"})
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		err := w.Write([]string{row.rType, row.owner, strconv.Itoa(row.count)})
+		if err != nil {
+			return err
+		}
+	}
+
+	w.Flush()
+
+	return w.Error()
+}
@@ -0,0 +1,272 @@
+package main
+
+import (
+	"container/heap"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/jckuester/awsls/aws"
+	"github.com/jckuester/awsls/internal"
+	"github.com/jckuester/awsls/util"
+)
+
+// combinedFileName is the name of the file (without extension) into which --combined writes the resources.
+const combinedFileName = "resources"
+
+// combinedOutput collects the resources of all types for --combined, so that they are written into a single file.
+// The resources are buffered per client (i.e., profile and region) and merged into a single output that is sorted
+// by account, region, type and ID, so that the file doesn't depend on the order in which the clients were listed.
+type combinedOutput struct {
+	attrs   []string
+	buffers map[util.AWSClientKey][]combinedResource
+}
+
+// combinedResource is a resource together with the attributes supported by its type.
+type combinedResource struct {
+	r        aws.Resource
+	hasAttrs map[string]bool
+}
+
+// add collects the resources of a type together with the attributes printed for, and supported by, the type.
+func (c *combinedOutput) add(resources []aws.Resource, hasAttrs map[string]bool, attributes []string) {
+	for _, attr := range attributes {
+		if !containsString(c.attrs, attr) {
+			c.attrs = append(c.attrs, attr)
+		}
+	}
+
+	if c.buffers == nil {
+		c.buffers = map[util.AWSClientKey][]combinedResource{}
+	}
+
+	for _, r := range resources {
+		key := util.AWSClientKey{Profile: r.Profile, Region: r.Region}
+		c.buffers[key] = append(c.buffers[key], combinedResource{r: r, hasAttrs: hasAttrs})
+	}
+}
+
+// attributes returns the union of the attributes printed for each type, in the order they first appear.
+// Resources of types for which an attribute isn't printed get the null value (see --null-value).
+func (c *combinedOutput) attributes() []string {
+	return c.attrs
+}
+
+// resources returns the resources of all types (sorted).
+func (c *combinedOutput) resources() []aws.Resource {
+	sorted := c.sorted()
+
+	result := make([]aws.Resource, len(sorted))
+	for i := range sorted {
+		result[i] = sorted[i].r
+	}
+
+	return result
+}
+
+// sorted sorts the buffered resources of each client and returns them k-way merged into a single slice
+// sorted by account, region, type and ID.
+func (c *combinedOutput) sorted() []combinedResource {
+	h := &combinedHeap{}
+
+	n := 0
+	for _, buffer := range c.buffers {
+		sort.SliceStable(buffer, func(i, j int) bool {
+			return lessCombined(&buffer[i].r, &buffer[j].r)
+		})
+
+		*h = append(*h, buffer)
+		n += len(buffer)
+	}
+
+	heap.Init(h)
+
+	result := make([]combinedResource, 0, n)
+	for h.Len() > 0 {
+		next := (*h)[0]
+		result = append(result, next[0])
+
+		if len(next) == 1 {
+			heap.Pop(h)
+		} else {
+			(*h)[0] = next[1:]
+			heap.Fix(h, 0)
+		}
+	}
+
+	return result
+}
+
+// lessCombined reports whether resource a is written before b into the combined output (ties are
+// sorted by profile, e.g., if the same account is listed via multiple profiles).
+func lessCombined(a, b *aws.Resource) bool {
+	if a.AccountID != b.AccountID {
+		return a.AccountID < b.AccountID
+	}
+
+	if a.Region != b.Region {
+		return a.Region < b.Region
+	}
+
+	if a.Type != b.Type {
+		return a.Type < b.Type
+	}
+
+	if a.ID != b.ID {
+		return a.ID < b.ID
+	}
+
+	return a.Profile < b.Profile
+}
+
+// combinedHeap is a min-heap of sorted per-client buffers (none empty), ordered by their first resource.
+type combinedHeap [][]combinedResource
+
+func (h combinedHeap) Len() int { return len(h) }
+
+func (h combinedHeap) Less(i, j int) bool { return lessCombined(&h[i][0].r, &h[j][0].r) }
+
+func (h combinedHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *combinedHeap) Push(x interface{}) { *h = append(*h, x.([]combinedResource)) }
+
+func (h *combinedHeap) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+
+	return x
+}
+
+// printCombinedCsv writes the resources of all types in csv format into the output directory.
+// Returns the paths of the written files and the number of rows written.
+func printCombinedCsv(ctx context.Context, c *combinedOutput, opts options) ([]string, int, error) {
+	err := createOutDir(opts.outDir)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if opts.expandTags {
+		opts.tagKeys = distinctTagKeys(c.resources())
+	}
+
+	filePath := filepath.Join(opts.outDir, combinedFileName+".csv")
+
+	w := internal.NewRollingCsvWriter(filePath, csvHeader(c.attributes(), opts), int64(opts.maxFileSize))
+	if opts.delimiter != 0 {
+		w.Comma = opts.delimiter
+	}
+
+	rows, err := writeCombinedRecordsCsv(w, c, opts)
+	if err != nil {
+		_ = w.Close()
+		return nil, 0, err
+	}
+
+	err = w.Close()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = printedCsvFiles(ctx, w.Files(), opts)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return w.Files(), rows, nil
+}
+
+// writeCombinedCsv writes the header and a row for each resource of all types in csv format.
+func writeCombinedCsv(out io.Writer, c *combinedOutput, opts options) error {
+	w := csv.NewWriter(out)
+	if opts.delimiter != 0 {
+		w.Comma = opts.delimiter
+	}
+
+	if opts.expandTags {
+		opts.tagKeys = distinctTagKeys(c.resources())
+	}
+
+	err := printHeaderCsv(w, c.attributes(), opts)
+	if err != nil {
+		return err
+	}
+
+	_, err = writeCombinedRecordsCsv(w, c, opts)
+
+	return err
+}
+
+// writeCombinedRecordsCsv writes a row for each resource of all types (sorted) with a column for each attribute
+// of any type. Returns the number of rows written.
+func writeCombinedRecordsCsv(w csvWriter, c *combinedOutput, opts options) (int, error) {
+	attributes := c.attributes()
+	sorted := c.sorted()
+
+	rows := 0
+
+	// consecutive resources of the same type are written at once, as they share the supported attributes
+	for start := 0; start < len(sorted); {
+		end := start + 1
+		for end < len(sorted) && sorted[end].r.Type == sorted[start].r.Type {
+			end++
+		}
+
+		resources := make([]aws.Resource, 0, end-start)
+		for _, e := range sorted[start:end] {
+			resources = append(resources, e.r)
+		}
+
+		n, err := writeRecordsCsv(w, resources, sorted[start].hasAttrs, attributes, opts)
+		rows += n
+		if err != nil {
+			return rows, err
+		}
+
+		start = end
+	}
+
+	return rows, nil
+}
+
+// printCombinedJSON writes the resources of all types as a single JSON array (or grouped, see --group)
+// into the output directory.
+// Returns the path of the written file.
+func printCombinedJSON(c *combinedOutput, opts options) (string, error) {
+	filePath := filepath.Join(opts.outDir, combinedFileName+".json")
+	err := createOutDir(opts.outDir)
+	if err != nil {
+		return "", err
+	}
+	jsonFile, err := os.Create(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer jsonFile.Close()
+
+	err = writeCombinedJSON(jsonFile, c, opts)
+	if err != nil {
+		return "", err
+	}
+
+	_, _ = fmt.Printf("printed json file into %s \n", jsonFile.Name())
+
+	return filePath, nil
+}
+
+// writeCombinedJSON writes the resources of all types as a single JSON array (or grouped, see --group).
+func writeCombinedJSON(out io.Writer, c *combinedOutput, opts options) error {
+	attributes := c.attributes()
+
+	result := []jsonResource{}
+
+	for _, e := range c.sorted() {
+		result = append(result, newJSONResource(&e.r, e.hasAttrs, attributes, opts.headerCase))
+	}
+
+	return encodeJSONResources(out, result, opts.group)
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"gopkg.in/yaml.v2"
+)
+
+// config are settings loaded via --config from a YAML (or JSON) file, so that inventories can be defined
+// once and version-controlled, for example:
+//
+//	profiles: [dev, prod]
+//	regions: [us-east-1, eu-west-1]
+//	types: [aws_instance, "aws_iam_*"]
+//	attributes: [instance_type, tags]
+//	provider_version: 2.68.0
+//
+// Flags (and arguments) given on the command line take precedence over the values of the file.
+type config struct {
+	// Profiles are the named AWS profiles to list resources with.
+	Profiles []string `yaml:"profiles"`
+	// Regions are the regions to list resources in.
+	Regions []string `yaml:"regions"`
+	// Types are glob patterns of the resource types to list.
+	Types []string `yaml:"types"`
+	// Attributes are the attributes to print for each resource.
+	Attributes []string `yaml:"attributes"`
+	// ProviderVersion is the version of the Terraform AWS Provider used to fetch attributes.
+	ProviderVersion string `yaml:"provider_version"`
+}
+
+// loadConfig reads the config from the given YAML or JSON file (as JSON is a subset of YAML).
+// Unknown keys are an error.
+func loadConfig(path string) (*config, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %s", err)
+	}
+
+	var c config
+
+	err = yaml.UnmarshalStrict(data, &c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %s", path, err)
+	}
+
+	return &c, nil
+}
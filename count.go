@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/jckuester/awsls/aws"
+)
+
+// countRow is the number of resources of a type in a profile and region.
+type countRow struct {
+	rType   string
+	profile string
+	region  string
+	count   int
+}
+
+// countTable collects the resource counts of all types for --count, so that they are printed as one table.
+type countTable struct {
+	rows []countRow
+}
+
+// add counts the resources of the given type per profile and region, sorted by profile and region.
+func (t *countTable) add(rType string, resources []aws.Resource) {
+	type profileRegion struct {
+		profile string
+		region  string
+	}
+
+	counts := map[profileRegion]int{}
+
+	for _, r := range resources {
+		counts[profileRegion{r.Profile, r.Region}]++
+	}
+
+	var rows []countRow
+	for k, count := range counts {
+		rows = append(rows, countRow{rType: rType, profile: k.profile, region: k.region, count: count})
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].profile != rows[j].profile {
+			return rows[i].profile < rows[j].profile
+		}
+
+		return rows[i].region < rows[j].region
+	})
+
+	t.rows = append(t.rows, rows...)
+}
+
+// write prints the resource counts as a table with a row per type, profile and region.
+func (t *countTable) write(out io.Writer) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	_, err := fmt.Fprintln(w, "TYPE\tPROFILE\tREGION\tCOUNT")
+	if err != nil {
+		return err
+	}
+
+	for _, row := range t.rows {
+		_, err := fmt.Fprintf(w, "%s\t%s\t%s\t%d\n", row.rType, row.profile, row.region, row.count)
+		if err != nil {
+			return err
+		}
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/jckuester/awsls/resource"
+	"github.com/jckuester/awsls/util"
+)
+
+// plannedTypes returns the resource types that would be listed (see --dry-run), i.e., the given ones without
+// the excluded types and, for --orphans, without the types for which no orphan condition is known.
+func plannedTypes(rTypes []string, opts options) ([]string, error) {
+	rTypes, err := resource.ExcludeTypes(rTypes, opts.excludeTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	if !opts.orphans {
+		return rTypes, nil
+	}
+
+	var result []string
+
+	for _, rType := range rTypes {
+		if resource.HasOrphanCondition(rType) {
+			result = append(result, rType)
+		}
+	}
+
+	return result, nil
+}
+
+// writeDryRun prints the queries that would run for --dry-run as a table with a row per type, profile and region,
+// followed by the number of queries in total.
+func writeDryRun(out io.Writer, rTypes []string, clientKeys []util.AWSClientKey) error {
+	keys := append([]util.AWSClientKey{}, clientKeys...)
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Profile != keys[j].Profile {
+			return keys[i].Profile < keys[j].Profile
+		}
+
+		return keys[i].Region < keys[j].Region
+	})
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	_, err := fmt.Fprintln(w, "TYPE\tPROFILE\tREGION")
+	if err != nil {
+		return err
+	}
+
+	for _, rType := range rTypes {
+		for _, k := range keys {
+			_, err := fmt.Fprintf(w, "%s\t%s\t%s\n", rType, k.Profile, k.Region)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	err = w.Flush()
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(out, "\nqueries in total: %d (%d types x %d profile/region combinations)\n",
+		len(rTypes)*len(keys), len(rTypes), len(keys))
+
+	return err
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/jckuester/awsls/aws"
+	"github.com/jckuester/awsls/resource"
+)
+
+// duplicateGroup are resources of the same type (across all accounts and regions) that share the same key,
+// i.e., the value of the attribute or tag the resources are grouped by.
+type duplicateGroup struct {
+	rType     string
+	key       string
+	resources []aws.Resource
+}
+
+// defaultNameTag is the tag whose value is printed in the NAME column and by which --find-duplicates groups
+// resources, unless another one is given via --name-tag.
+const defaultNameTag = "Name"
+
+// findDuplicates groups the resources of the given type by the value of the given attribute
+// (or by the value of the nameTag if the attribute is empty) and returns the groups with more than one member,
+// sorted by key. Resources without a value are never duplicates.
+func findDuplicates(rType string, resources []aws.Resource, hasAttrs map[string]bool,
+	attribute, nameTag string) []duplicateGroup {
+	groups := map[string][]aws.Resource{}
+
+	for i := range resources {
+		r := &resources[i]
+
+		var key string
+		if attribute == "" {
+			key = resource.GetTags(r)[nameTag]
+		} else {
+			key, _ = attributeValue(attribute, r, hasAttrs)
+		}
+
+		if key == "" {
+			continue
+		}
+
+		groups[key] = append(groups[key], *r)
+	}
+
+	var result []duplicateGroup
+	for key, members := range groups {
+		if len(members) > 1 {
+			result = append(result, duplicateGroup{rType: rType, key: key, resources: members})
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].key < result[j].key
+	})
+
+	return result
+}
+
+// printDuplicatesCsv writes the duplicate groups in csv format into the output directory.
+func printDuplicatesCsv(outDir, resourceType string, groups []duplicateGroup) error {
+	filePath := filepath.Join(outDir, resourceType+".duplicates.csv")
+	err := createOutDir(outDir)
+	if err != nil {
+		return err
+	}
+	csvFile, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer csvFile.Close()
+
+	err = writeDuplicatesCsv(csvFile, groups)
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Printf("printed csv file into %s \n", csvFile.Name())
+
+	return nil
+}
+
+// writeDuplicatesCsv writes the header and a row for each member of each duplicate group in csv format.
+func writeDuplicatesCsv(out io.Writer, groups []duplicateGroup) error {
+	w := csv.NewWriter(out)
+
+	err := w.Write([]string{"TYPE", "KEY", "ACCOUNT_ID", "PROFILE", "REGION", "ID"})
+	if err != nil {
+		return err
+	}
+
+	for _, g := range groups {
+		for _, r := range g.resources {
+			err := w.Write([]string{g.rType, g.key, r.AccountID, r.Profile, r.Region, r.ID})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	w.Flush()
+
+	return w.Error()
+}
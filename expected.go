@@ -0,0 +1,123 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/jckuester/awsls/aws"
+)
+
+// expectedResources are the keys of the resources that are expected to exist per resource type (see --expected).
+type expectedResources map[string]map[string]bool
+
+// loadExpected reads the expected resources from a csv file with a header row, which has a TYPE column and
+// a column named like the key (e.g., ID), so that the csv output of awsls can be used as expected resources.
+// Column names are matched case-insensitively.
+func loadExpected(path, key string) (expectedResources, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open expected resources: %s", err)
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = -1
+
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header of expected resources: %s", err)
+	}
+
+	typeColumn, keyColumn := -1, -1
+	for i, column := range header {
+		if strings.EqualFold(column, "type") {
+			typeColumn = i
+		}
+
+		if strings.EqualFold(column, key) {
+			keyColumn = i
+		}
+	}
+
+	if typeColumn < 0 {
+		return nil, fmt.Errorf("expected resources have no TYPE column")
+	}
+
+	if keyColumn < 0 {
+		return nil, fmt.Errorf("expected resources have no %s column", key)
+	}
+
+	result := expectedResources{}
+
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read expected resources: %s", err)
+		}
+
+		if typeColumn >= len(record) || keyColumn >= len(record) {
+			return nil, fmt.Errorf("expected resource has too few columns: %v", record)
+		}
+
+		rType := record[typeColumn]
+		if result[rType] == nil {
+			result[rType] = map[string]bool{}
+		}
+
+		result[rType][record[keyColumn]] = true
+	}
+
+	return result, nil
+}
+
+// reconcile compares the listed resources of the given type against the expected ones and returns
+// the keys of the expected resources that are missing and the keys of the listed resources that are
+// not expected (both sorted).
+func reconcile(rType string, expected expectedResources, resources []aws.Resource, hasAttrs map[string]bool,
+	key string) ([]string, []string) {
+	var missing, extra []string
+
+	listed := map[string]bool{}
+
+	for i := range resources {
+		k, ok := jsonMapKey(&resources[i], key, hasAttrs)
+		if !ok {
+			k = resources[i].ID
+		}
+
+		listed[k] = true
+
+		if !expected[rType][k] {
+			extra = append(extra, k)
+		}
+	}
+
+	for k := range expected[rType] {
+		if !listed[k] {
+			missing = append(missing, k)
+		}
+	}
+
+	sort.Strings(missing)
+	sort.Strings(extra)
+
+	return missing, extra
+}
+
+// writeReconciliation writes a line for each missing and extra resource of the given type.
+func writeReconciliation(out io.Writer, rType string, missing, extra []string) {
+	for _, k := range missing {
+		_, _ = fmt.Fprintf(out, "MISSING %s %s\n", rType, k)
+	}
+
+	for _, k := range extra {
+		_, _ = fmt.Fprintf(out, "EXTRA   %s %s\n", rType, k)
+	}
+}
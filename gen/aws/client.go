@@ -70,6 +70,17 @@ func NewClient(configs ...external.Config) (*Client, error) {
 		return nil, fmt.Errorf("failed to load config: %s", err)
 	}
 
+	for _, c := range configs {
+		switch c := c.(type) {
+		case WithRetryer:
+			cfg.Retryer = c.Retryer
+		case WithHTTPClient:
+			cfg.HTTPClient = c.HTTPClient
+		case WithRateLimiter:
+			cfg.Handlers.Send.PushFront(waitForLimiter(c.Limiter))
+		}
+	}
+
 	client := &Client{
 	{{ range . }}{{ . | Title }}conn: {{.}}.New(cfg),
 	{{end}}}
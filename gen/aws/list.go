@@ -18,6 +18,8 @@ type GeneratedResourceInfo struct {
 	Tags         bool
 	CreationTime bool
 	Owner        bool
+	// ListOperation is the AWS API operation used for listing in the notation of IAM actions
+	ListOperation string
 }
 
 func GenerateListFunctions(outputPath string, resourceServices map[string]string, resourceIDs map[string]string,
@@ -135,7 +137,8 @@ func GenerateListFunctions(outputPath string, resourceServices map[string]string
 			listFunctionNames[rType] = TypeToOpName(rType)
 
 			genInfo := GeneratedResourceInfo{
-				Type: rType,
+				Type:          rType,
+				ListOperation: iamAction(op),
 			}
 
 			op.Inputs = Inputs[rType]
@@ -195,6 +198,27 @@ func GenerateListFunctions(outputPath string, resourceServices map[string]string
 	return listFunctionNames, genResourceInfo
 }
 
+// iamPrefixes are the IAM service prefixes of services (by package name) whose prefix differs from the name
+// that their requests are signed with (e.g., requests to IoT are signed for execute-api, but IAM actions are iot:*).
+var iamPrefixes = map[string]string{
+	"cloudwatch": "cloudwatch",
+	"iot":        "iot",
+}
+
+// iamAction returns the name of the IAM action that permits the given operation (e.g., ec2:DescribeInstances).
+func iamAction(op Operation) string {
+	prefix, ok := iamPrefixes[op.API.PackageName()]
+	if !ok {
+		prefix = op.API.Metadata.SigningName
+	}
+
+	if prefix == "" {
+		prefix = op.API.Metadata.EndpointsID
+	}
+
+	return fmt.Sprintf("%s:%s", prefix, op.ExportedName)
+}
+
 func GetResourceIDNameCandidates(v *api.ShapeRef) []string {
 	var result []string
 
@@ -5,6 +5,8 @@ package aws
 import (
 	"reflect"
 	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/private/model/api"
 )
 
 func Test_pluralizeListFunctionCandidateNames(t *testing.T) {
@@ -36,3 +38,46 @@ func Test_pluralizeListFunctionCandidateNames(t *testing.T) {
 		})
 	}
 }
+
+func Test_iamAction(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata api.Metadata
+		opName   string
+		want     string
+	}{
+		{
+			name:     "prefix is the signing name",
+			metadata: api.Metadata{ServiceID: "EC2", SigningName: "ec2", EndpointsID: "ec2"},
+			opName:   "DescribeInstances",
+			want:     "ec2:DescribeInstances",
+		},
+		{
+			name:     "prefix is the endpoints ID without signing name",
+			metadata: api.Metadata{ServiceID: "ECS", EndpointsID: "ecs"},
+			opName:   "ListClusters",
+			want:     "ecs:ListClusters",
+		},
+		{
+			name:     "signing name differs from IAM prefix",
+			metadata: api.Metadata{ServiceID: "IoT", SigningName: "execute-api", EndpointsID: "iot"},
+			opName:   "ListThings",
+			want:     "iot:ListThings",
+		},
+		{
+			name:     "CloudWatch",
+			metadata: api.Metadata{ServiceID: "CloudWatch", SigningName: "monitoring", EndpointsID: "monitoring"},
+			opName:   "ListDashboards",
+			want:     "cloudwatch:ListDashboards",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op := Operation{Operation: api.Operation{ExportedName: tt.opName, API: &api.API{Metadata: tt.metadata}}}
+
+			if got := iamAction(op); got != tt.want {
+				t.Errorf("iamAction() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
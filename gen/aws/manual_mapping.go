@@ -86,3 +86,9 @@ var Inputs = map[string]string{
 Scope: "Local",
 `,
 }
+
+// ManualListOperations are list operations of resource types whose list functions are manually added
+// (and therefore not generated).
+var ManualListOperations = map[string]string{
+	"aws_instance": "ec2:DescribeInstances",
+}
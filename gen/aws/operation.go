@@ -49,14 +49,14 @@ import(
 {{ $respType := printf "%sResponse" .ExportedName -}}
 {{ $pagerType := printf "%sPaginator" .ExportedName -}}
 
-func  List{{.OpName}}(client *Client) ([]Resource, error) {
+func  List{{.OpName}}(ctx context.Context, client *Client, progress ProgressFunc) ([]Resource, error) {
     req := client.{{ .API.PackageName | Title }}conn.{{ $reqType }}(&{{ .API.PackageName }}.{{ .InputRef.GoTypeElem }}{ {{ if ne .Inputs "" }}{{ .Inputs }}{{ end }} })
 
 	var result []Resource
 
 	{{ if .Paginator }}
     p := {{ .API.PackageName }}.New{{ $pagerType }}(req)
-	for p.Next(context.Background()) {
+	for p.Next(ctx) {
 		page := p.CurrentPage()
 
 		for _, r := range page.{{ .OutputListName }}{
@@ -73,6 +73,8 @@ func  List{{.OpName}}(client *Client) ([]Resource, error) {
 				{{ if ne .GetCreationTimeGoCode "" }}CreatedAt: &t,{{ end }}
 			})
 		}
+
+		progress.report("{{ .TerraformType }}", len(result))
 	}
 
 	if err := p.Err(); err != nil {
@@ -81,7 +83,7 @@ func  List{{.OpName}}(client *Client) ([]Resource, error) {
 
 	{{ else }}
 
-    resp, err := req.Send(context.Background())
+    resp, err := req.Send(ctx)
 	if err != nil {
 		return nil, err
 	}
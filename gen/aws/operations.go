@@ -0,0 +1,60 @@
+// +build codegen
+
+package aws
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/jckuester/awsls/gen/util"
+)
+
+// GenerateListOperations generates code of a map of Terraform resource types to the AWS API operation used
+// for listing resources of each type and writes the code to directory outputPath.
+func GenerateListOperations(outputPath string, genResourceInfos map[string][]GeneratedResourceInfo) error {
+	listOperations := map[string]string{}
+	for rType, op := range ManualListOperations {
+		listOperations[rType] = op
+	}
+
+	for _, infos := range genResourceInfos {
+		for _, info := range infos {
+			listOperations[info.Type] = info.ListOperation
+		}
+	}
+
+	err := util.WriteGoFile(
+		filepath.Join(outputPath, "operations.go"),
+		util.CodeLayout,
+		"",
+		"aws",
+		listOperationsGoCode(listOperations),
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to write Go code to file: %s", err)
+	}
+
+	return nil
+}
+
+func listOperationsGoCode(listOperations map[string]string) string {
+	var buf bytes.Buffer
+	err := listOperationsTmpl.Execute(&buf, listOperations)
+	if err != nil {
+		panic(err)
+	}
+
+	return strings.TrimSpace(buf.String())
+}
+
+var listOperationsTmpl = template.Must(template.New("listOperations").Parse(`
+// ListOperations maps each supported resource type to the AWS API operation used for listing it,
+// in the notation of IAM actions (e.g., ec2:DescribeInstances).
+var ListOperations = map[string]string{
+{{ range $key, $value := . }}"{{ $key }}": "{{ $value }}",
+{{ end }}}
+`))
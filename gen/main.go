@@ -89,6 +89,11 @@ func main() {
 		log.WithError(err).Fatal("failed to generate list supported resource types")
 	}
 
+	err = aws.GenerateListOperations("../aws", genResourceInfos)
+	if err != nil {
+		log.WithError(err).Fatal("failed to generate map of resource type -> list operation")
+	}
+
 	err = aws.WriteReadme("..", genResourceInfos)
 	if err != nil {
 		log.WithError(err).Fatal("failed to generate README")
@@ -40,6 +40,7 @@ func listByTypeGoCode(listFunctionNames map[string]string) string {
 }
 
 var listByTypeTmpl = template.Must(template.New("listByType").Parse(`import(
+"context"
 "fmt"
 "time"
 
@@ -54,13 +55,28 @@ type Resource struct {
 	AccountID string
 	Tags map[string]string
 	CreatedAt *time.Time
+	// StateStale is true if the state couldn't be refreshed via the Terraform AWS Provider,
+	// so attribute values may be outdated or missing.
+	StateStale bool
 	terradozer.UpdatableResource
 }
 
-func ListResourcesByType(client *Client, resourceType string) ([]Resource, error) {
+// ProgressFunc is called after each page of resources of the given type has been listed
+// with the number of resources listed so far.
+type ProgressFunc func(resourceType string, listed int)
+
+// report calls the progress function, if any.
+func (f ProgressFunc) report(resourceType string, listed int) {
+	if f != nil {
+		f(resourceType, listed)
+	}
+}
+
+func ListResourcesByType(ctx context.Context, client *Client, resourceType string,
+	progress ProgressFunc) ([]Resource, error) {
 	switch resourceType {
 	{{ range $key, $value := . }}case "{{ $key }}":
-	return List{{ $value }}(client)
+	return List{{ $value }}(ctx, client, progress)
 	{{ end }}default:
 		return nil, fmt.Errorf("resource type is not (yet) supported: %s", resourceType)
 	}
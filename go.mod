@@ -9,11 +9,20 @@ require (
 	github.com/disneystreaming/go-ssmhelpers v0.2.1
 	github.com/fatih/color v1.9.0
 	github.com/gobwas/glob v0.2.3
+	github.com/golang/protobuf v1.3.4
 	github.com/gruntwork-io/terratest v0.23.0
+	github.com/hashicorp/terraform v0.12.28
 	github.com/jckuester/terradozer v0.1.3
+	github.com/mattn/go-isatty v0.0.11
+	github.com/mitchellh/go-homedir v1.1.0
 	github.com/onsi/gomega v1.9.0
 	github.com/pkg/errors v0.9.1
 	github.com/spf13/pflag v1.0.3
 	github.com/stretchr/testify v1.5.1
+	github.com/xitongsys/parquet-go v1.5.4
+	github.com/xitongsys/parquet-go-source v0.0.0-20200817004010-026bad9b25d0
 	github.com/zclconf/go-cty v1.4.0
+	golang.org/x/crypto v0.0.0-20200510223506-06a226fb4e37
+	golang.org/x/time v0.0.0-20191024005414-555d28b269f0
+	gopkg.in/yaml.v2 v2.2.4
 )
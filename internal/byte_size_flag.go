@@ -0,0 +1,55 @@
+package internal
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// byteSizeUnits are the supported units of a byte size, ordered so that longer suffixes are matched first.
+//
+//nolint:gochecknoglobals
+var byteSizeUnits = []struct {
+	suffix string
+	factor int64
+}{
+	{"GB", 1 << 30},
+	{"MB", 1 << 20},
+	{"KB", 1 << 10},
+	{"B", 1},
+}
+
+// ByteSizeFlag is a number of bytes given as a flag with an optional unit (e.g., 100MB).
+// Units are powers of 1024.
+type ByteSizeFlag int64
+
+func (b *ByteSizeFlag) String() string {
+	return fmt.Sprint(int64(*b))
+}
+
+// Set is the method to set the flag value, part of the flag.Value interface.
+func (b *ByteSizeFlag) Set(value string) error {
+	s := strings.ToUpper(strings.TrimSpace(value))
+
+	factor := int64(1)
+	for _, unit := range byteSizeUnits {
+		if strings.HasSuffix(s, unit.suffix) {
+			s = strings.TrimSpace(strings.TrimSuffix(s, unit.suffix))
+			factor = unit.factor
+			break
+		}
+	}
+
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || n < 0 {
+		return fmt.Errorf("invalid size: %s", value)
+	}
+
+	*b = ByteSizeFlag(n * factor)
+
+	return nil
+}
+
+func (b *ByteSizeFlag) Type() string {
+	return "byteSize"
+}
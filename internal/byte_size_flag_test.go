@@ -0,0 +1,39 @@
+package internal_test
+
+import (
+	"testing"
+
+	"github.com/jckuester/awsls/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestByteSizeFlag_Set(t *testing.T) {
+	tests := []struct {
+		name    string
+		arg     string
+		want    internal.ByteSizeFlag
+		wantErr bool
+	}{
+		{name: "bytes without unit", arg: "512", want: 512},
+		{name: "kilobytes", arg: "2KB", want: 2048},
+		{name: "megabytes lower case", arg: "100mb", want: 100 << 20},
+		{name: "gigabytes", arg: "1GB", want: 1 << 30},
+		{name: "unknown unit", arg: "1TB", wantErr: true},
+		{name: "negative", arg: "-1MB", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b internal.ByteSizeFlag
+
+			err := b.Set(tt.arg)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, b)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package internal
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DurationFlag is a duration given as a flag, either in Go syntax (e.g., 36h) or in days (e.g., 365d).
+type DurationFlag time.Duration
+
+func (d *DurationFlag) String() string {
+	return time.Duration(*d).String()
+}
+
+// Set is the method to set the flag value, part of the flag.Value interface.
+func (d *DurationFlag) Set(value string) error {
+	if strings.HasSuffix(value, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(value, "d"))
+		if err != nil || days < 0 {
+			return fmt.Errorf("invalid duration: %s", value)
+		}
+
+		*d = DurationFlag(time.Duration(days) * 24 * time.Hour)
+
+		return nil
+	}
+
+	v, err := time.ParseDuration(value)
+	if err != nil || v < 0 {
+		return fmt.Errorf("invalid duration: %s", value)
+	}
+
+	*d = DurationFlag(v)
+
+	return nil
+}
+
+func (d *DurationFlag) Type() string {
+	return "duration"
+}
@@ -0,0 +1,39 @@
+package internal_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jckuester/awsls/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDurationFlag_Set(t *testing.T) {
+	tests := []struct {
+		name    string
+		arg     string
+		want    time.Duration
+		wantErr bool
+	}{
+		{name: "days", arg: "365d", want: 365 * 24 * time.Hour},
+		{name: "go duration", arg: "36h", want: 36 * time.Hour},
+		{name: "invalid days", arg: "xd", wantErr: true},
+		{name: "negative", arg: "-1h", wantErr: true},
+		{name: "no unit", arg: "7", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d internal.DurationFlag
+
+			err := d.Set(tt.arg)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, time.Duration(d))
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package internal
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// RollingCsvWriter writes csv records into a file, and rolls over into a new numbered file
+// (e.g., aws_instance.part002.csv) whenever writing the next record would exceed the maximum file size.
+// The header is repeated at the beginning of each file.
+type RollingCsvWriter struct {
+	// Comma is the field delimiter (set to ',' by NewRollingCsvWriter), which must be set before the first Write.
+	Comma rune
+
+	path    string
+	header  []string
+	maxSize int64
+
+	part int
+	// written is the number of bytes written into the current file, of which headerSize are taken by the header
+	written    int64
+	headerSize int64
+	file       *os.File
+	out        *bufio.Writer
+	files      []string
+	err        error
+
+	buf bytes.Buffer
+	enc *csv.Writer
+}
+
+// NewRollingCsvWriter creates a writer for the given file path. If maxSize is 0,
+// all records are written into a single file at path.
+func NewRollingCsvWriter(path string, header []string, maxSize int64) *RollingCsvWriter {
+	w := &RollingCsvWriter{
+		Comma:   ',',
+		path:    path,
+		header:  header,
+		maxSize: maxSize,
+	}
+	w.enc = csv.NewWriter(&w.buf)
+
+	return w
+}
+
+// Write writes a single csv record, preceded by the header if a new file is started.
+func (w *RollingCsvWriter) Write(record []string) error {
+	if w.err != nil {
+		return w.err
+	}
+
+	b, err := w.encode(record)
+	if err != nil {
+		return err
+	}
+
+	if w.file == nil || (w.maxSize > 0 && w.written+int64(len(b)) > w.maxSize && w.written > w.headerSize) {
+		w.err = w.roll()
+		if w.err != nil {
+			return w.err
+		}
+	}
+
+	return w.write(b)
+}
+
+// Flush writes any buffered data to the current file.
+func (w *RollingCsvWriter) Flush() {
+	if w.out == nil || w.err != nil {
+		return
+	}
+
+	w.err = w.out.Flush()
+}
+
+// Error reports any error that has occurred during a previous Write or Flush.
+func (w *RollingCsvWriter) Error() error {
+	return w.err
+}
+
+// Close flushes and closes the current file. If no record has been written, a file with only the header is created.
+func (w *RollingCsvWriter) Close() error {
+	if w.file == nil && w.err == nil {
+		w.err = w.roll()
+	}
+
+	if w.file == nil {
+		return w.err
+	}
+
+	w.Flush()
+
+	err := w.file.Close()
+	if w.err == nil {
+		w.err = err
+	}
+
+	return w.err
+}
+
+// Files returns the paths of all files written so far.
+func (w *RollingCsvWriter) Files() []string {
+	return w.files
+}
+
+// roll closes the current file (if any) and continues writing into the next one.
+func (w *RollingCsvWriter) roll() error {
+	if w.file != nil {
+		err := w.out.Flush()
+		if err != nil {
+			return err
+		}
+
+		err = w.file.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	w.part++
+
+	path := w.path
+	if w.maxSize > 0 {
+		ext := filepath.Ext(w.path)
+		path = fmt.Sprintf("%s.part%03d%s", strings.TrimSuffix(w.path, ext), w.part, ext)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	w.file = f
+	w.out = bufio.NewWriter(f)
+	w.written = 0
+	w.files = append(w.files, path)
+
+	b, err := w.encode(w.header)
+	if err != nil {
+		return err
+	}
+
+	w.headerSize = int64(len(b))
+
+	return w.write(b)
+}
+
+// write writes the given bytes into the current file and keeps track of the file size.
+func (w *RollingCsvWriter) write(b []byte) error {
+	n, err := w.out.Write(b)
+	w.written += int64(n)
+	if err != nil {
+		w.err = err
+	}
+
+	return err
+}
+
+// encode returns the given record in csv format.
+func (w *RollingCsvWriter) encode(record []string) ([]byte, error) {
+	w.buf.Reset()
+	w.enc.Comma = w.Comma
+
+	err := w.enc.Write(record)
+	if err != nil {
+		return nil, err
+	}
+
+	w.enc.Flush()
+
+	return append([]byte{}, w.buf.Bytes()...), w.enc.Error()
+}
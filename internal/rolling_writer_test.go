@@ -0,0 +1,76 @@
+package internal_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jckuester/awsls/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRollingCsvWriter(t *testing.T) {
+	tests := []struct {
+		name      string
+		maxSize   int64
+		records   [][]string
+		wantFiles map[string]string
+	}{
+		{
+			name:    "no max size",
+			records: [][]string{{"i-1"}, {"i-2"}},
+			wantFiles: map[string]string{
+				"aws_instance.csv": "ID\ni-1\ni-2\n",
+			},
+		},
+		{
+			name:    "no records",
+			maxSize: 10,
+			wantFiles: map[string]string{
+				"aws_instance.part001.csv": "ID\n",
+			},
+		},
+		{
+			name:    "roll over into multiple files",
+			maxSize: 11,
+			records: [][]string{{"i-1"}, {"i-2"}, {"i-3"}},
+			wantFiles: map[string]string{
+				"aws_instance.part001.csv": "ID\ni-1\ni-2\n",
+				"aws_instance.part002.csv": "ID\ni-3\n",
+			},
+		},
+		{
+			name:    "record larger than max size",
+			maxSize: 1,
+			records: [][]string{{"i-1"}, {"i-2"}},
+			wantFiles: map[string]string{
+				"aws_instance.part001.csv": "ID\ni-1\n",
+				"aws_instance.part002.csv": "ID\ni-2\n",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "awsls")
+			require.NoError(t, err)
+			defer os.RemoveAll(dir)
+
+			w := internal.NewRollingCsvWriter(filepath.Join(dir, "aws_instance.csv"), []string{"ID"}, tt.maxSize)
+
+			for _, record := range tt.records {
+				require.NoError(t, w.Write(record))
+			}
+			require.NoError(t, w.Close())
+
+			assert.Len(t, w.Files(), len(tt.wantFiles))
+
+			for name, want := range tt.wantFiles {
+				actual, err := ioutil.ReadFile(filepath.Join(dir, name))
+				require.NoError(t, err)
+				assert.Equal(t, want, string(actual))
+			}
+		})
+	}
+}
@@ -1,5 +1,7 @@
 package internal
 
+import "sync"
+
 // Semaphore is a thin wrapper around a channel for using it as a semaphore.
 type Semaphore chan struct{}
 
@@ -27,3 +29,57 @@ func (s Semaphore) Release() {
 		panic("release without an acquire")
 	}
 }
+
+// KeyedSemaphore is a set of semaphores, one for each key (e.g., an AWS account ID),
+// each of which allows up to the same limit of simultaneous acquisitions.
+// A nil KeyedSemaphore doesn't limit anything.
+type KeyedSemaphore struct {
+	sync.Mutex
+	limit      int
+	semaphores map[string]Semaphore
+}
+
+// NewKeyedSemaphore creates a keyed semaphore that allows up to a given limit of
+// simultaneous acquisitions per key.
+func NewKeyedSemaphore(n int) *KeyedSemaphore {
+	if n == 0 {
+		panic("semaphore with limit 0")
+	}
+
+	return &KeyedSemaphore{
+		limit:      n,
+		semaphores: map[string]Semaphore{},
+	}
+}
+
+// Acquire is used to acquire an available slot for the given key. Blocks until available.
+func (s *KeyedSemaphore) Acquire(key string) {
+	if s == nil {
+		return
+	}
+
+	s.get(key).Acquire()
+}
+
+// Release is used to return a slot for the given key. Acquire must be called as a pre-condition.
+func (s *KeyedSemaphore) Release(key string) {
+	if s == nil {
+		return
+	}
+
+	s.get(key).Release()
+}
+
+// get returns the semaphore of the given key, creating it on first use.
+func (s *KeyedSemaphore) get(key string) Semaphore {
+	s.Lock()
+	defer s.Unlock()
+
+	sem, ok := s.semaphores[key]
+	if !ok {
+		sem = NewSemaphore(s.limit)
+		s.semaphores[key] = sem
+	}
+
+	return sem
+}
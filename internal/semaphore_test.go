@@ -0,0 +1,116 @@
+package internal_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jckuester/awsls/internal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyedSemaphore_limitPerKey(t *testing.T) {
+	tests := []struct {
+		name  string
+		limit int
+		// number of concurrent acquisitions per key
+		acquisitions map[string]int
+	}{
+		{name: "limit of one", limit: 1, acquisitions: map[string]int{"a": 5}},
+		{name: "single key", limit: 2, acquisitions: map[string]int{"a": 10}},
+		{name: "multiple keys", limit: 3, acquisitions: map[string]int{"a": 10, "b": 5, "c": 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sem := internal.NewKeyedSemaphore(tt.limit)
+
+			var mu sync.Mutex
+			inFlight := map[string]int{}
+			maxInFlight := map[string]int{}
+
+			var wg sync.WaitGroup
+
+			for key, n := range tt.acquisitions {
+				for i := 0; i < n; i++ {
+					wg.Add(1)
+
+					go func(key string) {
+						defer wg.Done()
+
+						sem.Acquire(key)
+						defer sem.Release(key)
+
+						mu.Lock()
+						inFlight[key]++
+						if inFlight[key] > maxInFlight[key] {
+							maxInFlight[key] = inFlight[key]
+						}
+						mu.Unlock()
+
+						time.Sleep(5 * time.Millisecond)
+
+						mu.Lock()
+						inFlight[key]--
+						mu.Unlock()
+					}(key)
+				}
+			}
+
+			wg.Wait()
+
+			for key := range tt.acquisitions {
+				assert.LessOrEqual(t, maxInFlight[key], tt.limit, "key %s", key)
+				assert.Equal(t, 0, inFlight[key], "key %s", key)
+			}
+		})
+	}
+}
+
+func TestKeyedSemaphore_keysAreIndependent(t *testing.T) {
+	sem := internal.NewKeyedSemaphore(1)
+
+	sem.Acquire("a")
+
+	acquiredB := make(chan struct{})
+	go func() {
+		sem.Acquire("b")
+		close(acquiredB)
+	}()
+
+	select {
+	case <-acquiredB:
+	case <-time.After(time.Second):
+		t.Fatal("acquiring key b is blocked by key a")
+	}
+
+	acquiredA := make(chan struct{})
+	go func() {
+		sem.Acquire("a")
+		close(acquiredA)
+	}()
+
+	select {
+	case <-acquiredA:
+		t.Fatal("acquired key a beyond its limit")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	sem.Release("a")
+
+	select {
+	case <-acquiredA:
+	case <-time.After(time.Second):
+		t.Fatal("acquiring key a is blocked after it has been released")
+	}
+}
+
+func TestKeyedSemaphore_nil(t *testing.T) {
+	var sem *internal.KeyedSemaphore
+
+	assert.NotPanics(t, func() {
+		sem.Acquire("a")
+		sem.Acquire("a")
+		sem.Release("a")
+		sem.Release("a")
+	})
+}
@@ -27,3 +27,8 @@ func BuildVersionString() string {
 
 	return result
 }
+
+// UserAgent returns the product token of awsls (e.g., awsls/0.8.0) for the User-Agent header of API requests.
+func UserAgent() string {
+	return fmt.Sprintf("awsls/%s", version)
+}
@@ -13,3 +13,7 @@ func TestBuildVersionString(t *testing.T) {
 
 	assert.Equal(t, actualVersionString, "version: dev\ncommit: ?\nbuilt at: ?\nusing: "+runtime.Version())
 }
+
+func TestUserAgent(t *testing.T) {
+	assert.Equal(t, "awsls/dev", internal.UserAgent())
+}
@@ -0,0 +1,313 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jckuester/awsls/aws"
+)
+
+// jsonResource is the JSON representation of a resource: an object with the fixed fields of the resource
+// and a key per requested attribute. Attributes that are not supported by the resource type are null,
+// and the ones that can't be read are an object with the error (e.g., {"error": "state is nil"}).
+type jsonResource struct {
+	Type      string
+	ID        string
+	AccountID string
+	Profile   string
+	Region    string
+	Created   *time.Time
+	// Attributes are the values of the requested attributes by name: a string, nil, or a jsonAttributeError
+	Attributes map[string]interface{}
+	// attributes are the names of the requested attributes in the order in which they are encoded
+	attributes []string
+	// headerCase is the casing of the keys (see --header-case)
+	headerCase string
+}
+
+// jsonAttributeError is the JSON representation of an attribute that can't be read.
+type jsonAttributeError struct {
+	Error string `json:"error"`
+}
+
+// jsonField is a key and value of the JSON object of a resource.
+type jsonField struct {
+	key   string
+	value interface{}
+}
+
+// MarshalJSON encodes the resource with its keys in the casing set via --header-case. An attribute named
+// like a fixed field (e.g., id) isn't encoded again, as the key would be ambiguous.
+func (r jsonResource) MarshalJSON() ([]byte, error) {
+	fields := []jsonField{
+		{"type", r.Type},
+		{"id", r.ID},
+		{"account_id", r.AccountID},
+		{"profile", r.Profile},
+		{"region", r.Region},
+		{"created", r.Created},
+	}
+
+	seen := map[string]bool{}
+	for _, f := range fields {
+		seen[f.key] = true
+	}
+
+	for _, attr := range r.attributes {
+		if seen[attr] {
+			continue
+		}
+		seen[attr] = true
+
+		fields = append(fields, jsonField{attr, r.Attributes[attr]})
+	}
+
+	var buf bytes.Buffer
+
+	buf.WriteByte('{')
+
+	for i, f := range fields {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		key, err := json.Marshal(headerName(f.key, r.headerCase))
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := json.Marshal(f.value)
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// newJSONResource returns the JSON representation of the given resource.
+func newJSONResource(r *aws.Resource, hasAttrs map[string]bool, attributes []string,
+	headerCase string) jsonResource {
+	result := jsonResource{
+		headerCase: headerCase,
+		Type:       r.Type,
+		ID:         r.ID,
+		AccountID:  r.AccountID,
+		Profile:    r.Profile,
+		Region:     r.Region,
+		Created:    r.CreatedAt,
+		Attributes: map[string]interface{}{},
+		attributes: attributes,
+	}
+
+	for _, attr := range attributes {
+		v, ok, err := lookupAttribute(attr, r, hasAttrs)
+		switch {
+		case err != nil:
+			result.Attributes[attr] = jsonAttributeError{Error: err.Error()}
+		case !ok:
+			result.Attributes[attr] = nil
+		default:
+			result.Attributes[attr] = v
+		}
+	}
+
+	return result
+}
+
+// printResourcesJSON writes the resources as a JSON array (or grouped, see --group) into the output directory.
+// Returns the path of the written file.
+func printResourcesJSON(resourceType string, resources []aws.Resource, hasAttrs map[string]bool,
+	attributes []string, opts options) (string, error) {
+	filePath := filepath.Join(opts.outDir, resourceType+".json")
+	err := createOutDir(opts.outDir)
+	if err != nil {
+		return "", err
+	}
+	jsonFile, err := os.Create(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer jsonFile.Close()
+
+	err = writeResourcesJSON(jsonFile, resources, hasAttrs, attributes, opts)
+	if err != nil {
+		return "", err
+	}
+
+	_, _ = fmt.Printf("printed json file into %s \n", jsonFile.Name())
+
+	return filePath, nil
+}
+
+// writeResourcesJSON writes the resources as a JSON array (or grouped, see --group).
+func writeResourcesJSON(out io.Writer, resources []aws.Resource, hasAttrs map[string]bool,
+	attributes []string, opts options) error {
+	result := make([]jsonResource, 0, len(resources))
+
+	for i := range resources {
+		result = append(result, newJSONResource(&resources[i], hasAttrs, attributes, opts.headerCase))
+	}
+
+	return encodeJSONResources(out, result, opts.group)
+}
+
+// jsonGroupByAccount groups the JSON output by account and region (see --group).
+const jsonGroupByAccount = "json-by-account"
+
+// encodeJSONResources writes the resources as an indented JSON array, or, if grouped by account,
+// as an object with the resources of each account ID in an object with the resources of each region
+// (e.g., {"123456789012": {"us-east-1": [...]}}).
+func encodeJSONResources(out io.Writer, resources []jsonResource, group string) error {
+	var result interface{} = resources
+
+	if group == jsonGroupByAccount {
+		byAccount := map[string]map[string][]jsonResource{}
+
+		for _, r := range resources {
+			if byAccount[r.AccountID] == nil {
+				byAccount[r.AccountID] = map[string][]jsonResource{}
+			}
+
+			byAccount[r.AccountID][r.Region] = append(byAccount[r.AccountID][r.Region], r)
+		}
+
+		result = byAccount
+	}
+
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(result)
+}
+
+// writeResourceNDJSON writes the resource as a single line of JSON (see --output ndjson).
+func writeResourceNDJSON(out io.Writer, r *aws.Resource, hasAttrs map[string]bool, attributes []string,
+	headerCase string) error {
+	return json.NewEncoder(out).Encode(newJSONResource(r, hasAttrs, attributes, headerCase))
+}
+
+// printResourcesJSONMap writes the resources as a JSON object keyed by the --key attribute
+// into the output directory.
+func printResourcesJSONMap(resourceType string, resources []aws.Resource, hasAttrs map[string]bool,
+	attributes []string, opts options) (string, error) {
+	filePath := filepath.Join(opts.outDir, resourceType+".json")
+	err := createOutDir(opts.outDir)
+	if err != nil {
+		return "", err
+	}
+	jsonFile, err := os.Create(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer jsonFile.Close()
+
+	err = writeResourcesJSONMap(jsonFile, resources, hasAttrs, attributes, opts)
+	if err != nil {
+		return "", err
+	}
+
+	_, _ = fmt.Printf("printed json file into %s \n", jsonFile.Name())
+
+	return filePath, nil
+}
+
+// writeResourcesJSONMap writes the resources as a JSON object keyed by the --key attribute.
+// Duplicate keys (e.g., the same IAM role name in multiple accounts) are either an error,
+// or the key of each further resource gets a numbered suffix (e.g., my-role#2), see --on-duplicate-key.
+func writeResourcesJSONMap(out io.Writer, resources []aws.Resource, hasAttrs map[string]bool,
+	attributes []string, opts options) error {
+	result := map[string]jsonResource{}
+	occurrences := map[string]int{}
+
+	for i := range resources {
+		r := &resources[i]
+
+		key, ok := jsonMapKey(r, opts.jsonKey, hasAttrs)
+		if !ok {
+			return fmt.Errorf("resource has no value for key %s: %s", opts.jsonKey, r.ID)
+		}
+
+		occurrences[key]++
+		if occurrences[key] > 1 {
+			if opts.onDuplicateKey != "suffix" {
+				return fmt.Errorf("duplicate key: %s", key)
+			}
+
+			key = fmt.Sprintf("%s#%d", key, occurrences[key])
+		}
+
+		result[key] = newJSONResource(r, hasAttrs, attributes, opts.headerCase)
+	}
+
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(result)
+}
+
+// jsonMapKey returns the value of the given key attribute of a resource,
+// or false if the resource type doesn't support the attribute.
+func jsonMapKey(r *aws.Resource, key string, hasAttrs map[string]bool) (string, bool) {
+	switch key {
+	case "id":
+		return r.ID, true
+	case "type":
+		return r.Type, true
+	default:
+		return attributeValue(key, r, hasAttrs)
+	}
+}
+
+// validateJSONFile re-reads the given JSON file, which is either an array of resources, an object with a resource
+// per key (see --output json-map) or the resources grouped by account and region (see --group), and checks that
+// it is well-formed and contains the expected number of resources.
+func validateJSONFile(path string, wantResources int, group string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var resources []json.RawMessage
+
+	if group == jsonGroupByAccount {
+		var byAccount map[string]map[string][]json.RawMessage
+		if err := json.Unmarshal(data, &byAccount); err != nil {
+			return fmt.Errorf("failed to parse %s: %s", path, err)
+		}
+
+		for _, byRegion := range byAccount {
+			for _, r := range byRegion {
+				resources = append(resources, r...)
+			}
+		}
+	} else if arrErr := json.Unmarshal(data, &resources); arrErr != nil {
+		var resourcesByKey map[string]json.RawMessage
+		if err := json.Unmarshal(data, &resourcesByKey); err != nil {
+			return fmt.Errorf("failed to parse %s: %s", path, arrErr)
+		}
+
+		resources = make([]json.RawMessage, 0, len(resourcesByKey))
+		for _, r := range resourcesByKey {
+			resources = append(resources, r)
+		}
+	}
+
+	if len(resources) != wantResources {
+		return fmt.Errorf("expected %d resources, but found %d", wantResources, len(resources))
+	}
+
+	return nil
+}
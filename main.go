@@ -1,277 +1,2013 @@
 package main
 
 import (
+	"context"
 	"encoding/csv"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/apex/log"
 	"github.com/apex/log/handlers/cli"
+	"github.com/apex/log/handlers/logfmt"
+	awsSDK "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/external"
 	aws_ssmhelpers "github.com/disneystreaming/go-ssmhelpers/aws"
 	"github.com/fatih/color"
 	"github.com/jckuester/awsls/aws"
 	"github.com/jckuester/awsls/internal"
+	"github.com/jckuester/awsls/pkg/awsls"
 	"github.com/jckuester/awsls/resource"
 	"github.com/jckuester/awsls/util"
 	"github.com/jckuester/terradozer/pkg/provider"
+	"github.com/mattn/go-isatty"
+	"github.com/mitchellh/go-homedir"
 	flag "github.com/spf13/pflag"
+	"golang.org/x/time/rate"
+	"io"
+	"io/ioutil"
+	"math/rand"
 	"os"
+	"os/exec"
+	"os/signal"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"syscall"
+	"text/tabwriter"
+	"text/template"
 	"time"
+	"unicode/utf8"
 )
 
+// options are the settings given via flags that control how resources are listed and printed.
+type options struct {
+	ignoreAccessDenied bool
+	flushEvery         int
+	perTypeTimeout     time.Duration
+	withQuota          bool
+	nameTag            string
+	nullValue          string
+	attributeFilters   []awsls.AttributeFilter
+	resourceGroup      string
+	orphans            bool
+	compareSources     bool
+	output             string
+	idsWithType        bool
+	maxFileSize        internal.ByteSizeFlag
+	// onlyTypesWithAttribute restricts the matched resource types to those that support this attribute
+	onlyTypesWithAttribute string
+	idPrefix               string
+	// onResource is called for each resource once it has been listed and enriched with its state.
+	// Calls for the resources of a type and AWS client happen one after another (never concurrently),
+	// before or after the calls for the resources of another AWS client.
+	onResource     func(r aws.Resource) error
+	validateOutput bool
+	excludeTags    []awsls.TagFilter
+	// excludeTypes are glob patterns of resource types that are never listed
+	excludeTypes []string
+	// parallel is the number of AWS clients that list resources at the same time
+	parallel int
+	// tags keeps only resources that carry all of these tags
+	tags []awsls.TagFilter
+	// createdAfter and createdBefore keep only resources created within this time range (if non-zero)
+	createdAfter  time.Time
+	createdBefore time.Time
+	// keepUnknownCreated keeps resources with unknown creation time when filtering by creation time
+	keepUnknownCreated bool
+	warnOver           int
+	jsonKey            string
+	onDuplicateKey     string
+	preferListAPI      bool
+	ownerTag           string
+	explode            string
+	flagOlderThan      internal.DurationFlag
+	// warnOnStaleState adds a STATE_STALE column that marks resources whose state couldn't be refreshed
+	warnOnStaleState bool
+	// stdout writes the csv or JSON output to stdout instead of the output directory
+	stdout bool
+	// findDuplicates reports resources of the same type that share the value of duplicatesBy
+	// (or of the name tag if duplicatesBy is empty)
+	findDuplicates bool
+	duplicatesBy   string
+	// expected are the resources that are expected to exist, matched by the value of expectedKey
+	expected    expectedResources
+	expectedKey string
+	// headerCase is the casing of column headers: upper, lower, or as-is
+	headerCase string
+	// accountSem limits the number of in-flight requests per AWS account
+	accountSem *internal.KeyedSemaphore
+	// resourceGroupARNs are the ARNs of the resource group members per AWS client
+	resourceGroupARNs map[util.AWSClientKey][]string
+	// taggedResources are the tags of resources looked up for types whose state doesn't contain tags
+	taggedResources *awsls.TaggedResources
+	// counts collects the number of resources per type, profile and region instead of printing them (see --count)
+	counts *countTable
+	// stateCache caches fetched states on disk (see --cache-dir)
+	stateCache *resource.StateCache
+	// idFilter keeps only resources whose ID matches (if set)
+	idFilter *regexp.Regexp
+	// delimiter is the field delimiter of the csv output
+	delimiter rune
+	// untagged keeps only resources without any tags
+	untagged bool
+	// outDir is the directory into which output files are written
+	outDir string
+	// expandTags prints a tag:<key> column per distinct tag key instead of a single tags column
+	expandTags bool
+	// tagKeys are the distinct tag keys of the resources of a type, which become columns if expandTags is set
+	tagKeys []string
+	// sortBy is the column (or attribute) by which the resources of a type are sorted
+	sortBy string
+	// combined collects the resources of all types to write them into a single file instead of one per type
+	// (see --combined)
+	combined *combinedOutput
+	// rateLimiter limits the rate of the AWS API requests of awsls and of the resource reads of the
+	// Terraform AWS Provider (nil for no limit)
+	rateLimiter *rate.Limiter
+	// s3Output uploads the csv files written into outDir (a local staging directory then) to S3
+	// if --out-dir is an S3 URL
+	s3Output *s3Output
+	// summary counts the listed resources, which are summarized on stderr at the end of the run
+	summary *runSummary
+	// group nests the JSON output by account and region if set to json-by-account (see --group)
+	group string
+	// setup are the settings only needed to set up the run in mainExitCode (see parseOptions)
+	setup setupOptions
+}
+
 func main() {
 	os.Exit(mainExitCode())
 }
 
 // this will fetch and print all the resources specified
 func mainExitCode() int {
+	opts, err := parseOptions(os.Args[1:])
+	if err == flag.ErrHelp {
+		printHelp()
+		return 0
+	}
+
+	if err != nil {
+		fmt.Fprint(os.Stderr, color.RedString("Error: %s\n", err))
+		printHelp()
+
+		return 1
+	}
+
+	s := opts.setup
+
+	if opts.s3Output != nil {
+		// the csv files are written into a local staging directory first, from which they are uploaded
+		stagingDir, err := ioutil.TempDir("", "awsls")
+		if err != nil {
+			fmt.Fprint(os.Stderr, color.RedString("Error: %s\n", err))
+			return 1
+		}
+		defer os.RemoveAll(stagingDir)
 
-	var logDebug bool
-	var allProfilesFlag bool
-	var profiles internal.CommaSeparatedListFlag
-	var regions internal.CommaSeparatedListFlag
-	//var attributes internal.CommaSeparatedListFlag
-	var version bool
+		opts.outDir = stagingDir
+	}
 
-	flags := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	if s.cacheDir != "" && !s.noCache {
+		stateCache, err := resource.NewStateCache(s.cacheDir, s.cacheTTL, s.refreshCache)
+		if err != nil {
+			fmt.Fprint(os.Stderr, color.RedString("Error: invalid --cache-dir: %s\n", err))
+			return 1
+		}
 
-	flags.Usage = func() {
-		printHelp(flags)
+		opts.stateCache = stateCache
 	}
 
-	flags.BoolVar(&logDebug, "debug", false, "Enable debug logging")
-	flags.VarP(&profiles, "profiles", "p", "Comma-separated list of named AWS profiles for accounts to list resources in")
-	flags.BoolVar(&allProfilesFlag, "all-profiles", false, "List resources for all profiles in ~/.aws/config")
-	flags.VarP(&regions, "regions", "r", "Comma-separated list of regions to list resources in")
-	flags.BoolVar(&version, "version", false, "Show application version")
+	opts.taggedResources = awsls.NewTaggedResources()
 
-	_ = flags.Parse(os.Args[1:])
+	if !s.dryRun && !writesToStdout(opts) {
+		// fail early rather than for each resource type
+		err := createOutDir(opts.outDir)
+		if err != nil {
+			fmt.Fprint(os.Stderr, color.RedString("Error: invalid --out-dir: %s\n", err))
+			return 1
+		}
+	}
 
-	fmt.Println()
-	defer fmt.Println()
+	if !writesToStdout(opts) {
+		// a blank line would corrupt the output written to stdout
+		fmt.Println()
+		defer fmt.Println()
+	}
 
 	log.SetHandler(cli.Default)
 
-	if logDebug {
-		log.SetLevel(log.DebugLevel)
+	if s.logFile != "" {
+		f, err := os.OpenFile(s.logFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			fmt.Fprint(os.Stderr, color.RedString("Error: failed to open log file: %s\n", err))
+			return 1
+		}
+		defer f.Close()
+
+		log.SetHandler(logfmt.New(f))
 	}
 
-	if version {
+	log.SetLevel(logLevel(s.verbose, s.debug, s.quiet))
+
+	if s.version {
 		fmt.Println(internal.BuildVersionString())
 		return 0
 	}
 
-	if profiles != nil && allProfilesFlag == true {
-		fmt.Fprint(os.Stderr, color.RedString("Error:️ --profiles and --all-profiles flag cannot be used together\n"))
-		printHelp(flags)
+	if s.listSupported {
+		printSupportedTypes(os.Stdout, resource.SupportedTypes, s.verbose > 0)
+		return 0
+	}
 
-		return 1
+	if s.listTypes {
+		rTypes := append([]string{}, resource.SupportedTypes...)
+
+		if len(s.resourceTypePatterns) > 0 {
+			matched, err := matchTypes(s.resourceTypePatterns, s.exact)
+			if err != nil {
+				fmt.Fprint(os.Stderr, color.RedString("Error: %s\n", err))
+				return 1
+			}
+
+			if len(matched) == 0 {
+				return 1
+			}
+
+			rTypes = matched
+		}
+
+		sort.Strings(rTypes)
+		printSupportedTypes(os.Stdout, rTypes, s.verbose > 0)
+
+		return 0
+	}
+
+	if s.staticCredentials() {
+		fmt.Fprint(os.Stderr, color.YellowString("Warning: passing secrets on the command line is insecure, "+
+			"consider setting AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN env instead\n"))
 	}
 
-	if profiles == nil && allProfilesFlag == false {
+	if s.profiles == nil && !s.allProfiles && !s.staticCredentials() && s.accountID == "" {
 		env, ok := os.LookupEnv("AWS_PROFILE")
 		if ok {
-			profiles = []string{env}
+			s.profiles = []string{env}
 		}
 	}
 
-	if allProfilesFlag {
-		var awsConfigPath []string
-		awsConfigFileEnv, ok := os.LookupEnv("AWS_CONFIG_FILE")
-		if ok {
-			awsConfigPath = []string{awsConfigFileEnv}
+	for _, path := range []*string{&s.awsConfigFile, &s.awsCredentialsFile} {
+		if *path == "" {
+			continue
+		}
+
+		expanded, err := homedir.Expand(*path)
+		if err != nil {
+			fmt.Fprint(os.Stderr, color.RedString("Error: failed to expand path %s: %s\n", *path, err))
+			return 1
+		}
+
+		if _, err := os.Stat(expanded); err != nil {
+			fmt.Fprint(os.Stderr, color.RedString("Error: %s\n", err))
+			return 1
+		}
+
+		*path = expanded
+	}
+
+	if s.awsConfigFile != "" {
+		// the Terraform AWS Provider has no option for a custom config file, but reads it from the environment
+		os.Setenv("AWS_CONFIG_FILE", s.awsConfigFile)
+	}
+
+	_, hasEnvCredentials := os.LookupEnv("AWS_ACCESS_KEY_ID")
+
+	if s.profiles == nil && !s.allProfiles && !s.staticCredentials() && !hasEnvCredentials && s.accountID == "" &&
+		isatty.IsTerminal(os.Stdin.Fd()) {
+		profilesFromConfig, err := aws_ssmhelpers.GetAWSProfiles(awsConfigPath(s.awsConfigFile)...)
+		if err == nil && len(profilesFromConfig) > 0 {
+			selected, err := util.SelectProfiles(os.Stdin, os.Stderr, profilesFromConfig)
+			if err != nil {
+				fmt.Fprint(os.Stderr, color.RedString("Error: %s\n", err))
+				return 1
+			}
+
+			s.profiles = selected
 		}
+	}
 
-		profilesFromConfig, err := aws_ssmhelpers.GetAWSProfiles(awsConfigPath...)
+	if s.allProfiles {
+		profilesFromConfig, err := aws_ssmhelpers.GetAWSProfiles(awsConfigPath(s.awsConfigFile)...)
 		if err != nil {
 			fmt.Fprint(os.Stderr, color.RedString("Error: failed to load all profiles: %s\n", err))
 			return 1
 		}
 
 		if profilesFromConfig == nil {
-			fmt.Fprint(os.Stderr, color.RedString("Error: no profiles found in ~/.aws/config\n"))
+			configPath := "~/.aws/config"
+			if paths := awsConfigPath(s.awsConfigFile); paths != nil {
+				configPath = paths[0]
+			}
+
+			fmt.Fprint(os.Stderr, color.RedString("Error: no profiles found in %s\n", configPath))
+			return 1
+		}
+
+		s.profiles = profilesFromConfig
+	}
+
+	if s.defaultRegionOnly {
+		if s.regions != nil {
+			fmt.Fprint(os.Stderr, color.YellowString("Warning: --regions is ignored together with "+
+				"--default-region-only\n"))
+		}
+
+		// without regions, each client uses the default region of its profile
+		s.regions = nil
+	}
+
+	var clientConfigs []external.Config
+	var providerConfig util.ProviderConfig
+
+	if s.awsConfigFile != "" || s.awsCredentialsFile != "" {
+		clientConfigs = append(clientConfigs, util.WithSharedConfigFiles(s.awsConfigFile, s.awsCredentialsFile))
+
+		providerConfig.SharedCredentialsFile = s.awsCredentialsFile
+	}
+
+	if s.userAgent != "" {
+		clientConfigs = append(clientConfigs, util.WithUserAgent(s.userAgent))
+	}
+
+	clientConfigs = append(clientConfigs, util.WithMaxRetries(s.maxRetries))
+
+	if opts.rateLimiter != nil {
+		clientConfigs = append(clientConfigs, util.WithRateLimit(opts.rateLimiter))
+	}
+
+	if s.endpointURL != "" {
+		clientConfigs = append(clientConfigs, util.WithEndpointURL(s.endpointURL))
+
+		providerConfig.EndpointURL = s.endpointURL
+	}
+
+	if s.noVerifySSL {
+		clientConfigs = append(clientConfigs, util.WithInsecureSkipVerify())
+
+		providerConfig.Insecure = true
+	}
+
+	if s.staticCredentials() {
+		clientConfigs = append(clientConfigs, external.WithCredentialsProvider{
+			CredentialsProvider: awsSDK.NewStaticCredentialsProvider(s.accessKeyID, s.secretAccessKey, s.sessionToken),
+		})
+
+		providerConfig.AccessKey = s.accessKeyID
+		providerConfig.SecretKey = s.secretAccessKey
+		providerConfig.Token = s.sessionToken
+	}
+
+	var profilesFromConfig []string
+	if s.accountID != "" {
+		// the profiles of the account are looked up among all profiles after their credentials are resolved
+		var err error
+		profilesFromConfig, err = aws_ssmhelpers.GetAWSProfiles(awsConfigPath(s.awsConfigFile)...)
+		if err != nil {
+			fmt.Fprint(os.Stderr, color.RedString("Error: failed to load profiles: %s\n", err))
+			return 1
+		}
+	}
+
+	if !s.staticCredentials() && !s.dryRun {
+		ssoProfiles := s.profiles
+		if len(ssoProfiles) == 0 {
+			// the profile set via AWS_PROFILE (or the default one)
+			ssoProfiles = []string{""}
+		}
+
+		// the SDK doesn't support profiles configured for AWS SSO, so their credentials are resolved beforehand
+		var ssoCredentials util.ProfileCredentials
+		if s.accountID != "" {
+			// any profile might belong to the account, so one without a valid SSO token is only skipped
+			ssoCredentials = ssoCredentialsOfProfiles(s.awsConfigFile, profilesFromConfig)
+		} else {
+			var err error
+			ssoCredentials, err = util.SSOCredentials(s.awsConfigFile, ssoProfiles)
+			if err != nil {
+				fmt.Fprint(os.Stderr, color.RedString("Error: %s\n", err))
+				return 1
+			}
+		}
+
+		if len(ssoCredentials) > 0 {
+			clientConfigs = append(clientConfigs, ssoCredentials)
+			providerConfig.ProfileCredentials = ssoCredentials
+		}
+	}
+
+	if s.assumeRoleARN != "" {
+		clientConfigs = append(clientConfigs, util.AssumeRole{RoleARN: s.assumeRoleARN, ExternalID: s.externalID})
+
+		providerConfig.AssumeRoleARN = s.assumeRoleARN
+		providerConfig.ExternalID = s.externalID
+	}
+
+	if s.accountID != "" {
+		// the account of a profile is the same in every region, so a single one is enough to look it up
+		lookupRegion := "us-east-1"
+		if len(s.regions) > 0 {
+			lookupRegion = s.regions[0]
+		}
+
+		s.profiles = util.ProfilesOfAccount(profilesFromConfig, s.accountID, lookupRegion, clientConfigs...)
+		if len(s.profiles) == 0 {
+			fmt.Fprint(os.Stderr, color.RedString("Error: no profile found with credentials for account %s\n",
+				s.accountID))
 			return 1
 		}
 
-		profiles = profilesFromConfig
+		if len(s.profiles) > 1 {
+			// all profiles are of the same account, so resources would be listed multiple times
+			log.WithField("profiles", s.profiles).Debugf("multiple profiles found for account %s, using the first one",
+				s.accountID)
+			s.profiles = s.profiles[:1]
+		}
+	}
+
+	if s.allRegions {
+		profile := ""
+		if len(s.profiles) > 0 {
+			profile = s.profiles[0]
+		}
+
+		enabledRegions, err := util.EnabledRegions(profile, clientConfigs...)
+		if err != nil {
+			fmt.Fprint(os.Stderr, color.RedString("Error: %s\n", err))
+			return 1
+		}
+
+		log.WithField("regions", enabledRegions).Debug("enabled regions")
+		s.regions = enabledRegions
+	}
+
+	if s.sampleRegions > 0 {
+		s.regions = sampleRegions(s.regions, s.sampleRegions, s.seed)
+		log.WithField("regions", s.regions).Debug("sampled regions")
 	}
-	clients, err := util.NewAWSClientPool(profiles, regions)
+
+	clients, err := util.NewAWSClientPool(s.profiles, s.regions, clientConfigs...)
 	if err != nil {
 		fmt.Fprint(os.Stderr, color.RedString("\nError: %s\n", err))
 
 		return 1
 	}
+
+	if s.dryRun {
+		// the clients are created without calling AWS, so their profiles and (default) regions are known
+		clientKeys := make([]util.AWSClientKey, 0, len(clients))
+		for k := range clients {
+			clientKeys = append(clientKeys, k)
+		}
+
+		types, err := plannedTypes(s.resourceTypes, opts)
+		if err != nil {
+			fmt.Fprint(os.Stderr, color.RedString("Error: %s\n", err))
+			return 1
+		}
+
+		err = writeDryRun(os.Stdout, types, clientKeys)
+		if err != nil {
+			fmt.Fprint(os.Stderr, color.RedString("Error: %s\n", err))
+			return 1
+		}
+
+		return 0
+	}
+
+	if opts.resourceGroup != "" {
+		opts.resourceGroupARNs = map[util.AWSClientKey][]string{}
+
+		for key, client := range clients {
+			arns, err := client.ListResourceGroupARNs(opts.resourceGroup)
+			if err != nil {
+				// e.g., the group doesn't exist in this region, so none of its resources are listed
+				fmt.Fprint(os.Stderr, color.YellowString("Warning: skipping %s/%s: %s\n", key.Profile, key.Region, err))
+
+				delete(clients, key)
+
+				continue
+			}
+
+			opts.resourceGroupARNs[key] = arns
+		}
+
+		if len(clients) == 0 {
+			fmt.Fprint(os.Stderr, color.RedString("\nError: resource group %s couldn't be listed "+
+				"in any profile and region\n", opts.resourceGroup))
+
+			return 1
+		}
+	}
+
 	clientKeys := make([]util.AWSClientKey, 0, len(clients))
 	for k := range clients {
 		clientKeys = append(clientKeys, k)
 	}
-	// suppress provider debug and info logs
-	log.SetLevel(log.ErrorLevel)
-	if logDebug {
-		log.SetLevel(log.DebugLevel)
+
+	if opts.s3Output != nil {
+		// upload with the credentials of the first profile and region, but with a copy of its client,
+		// so that listing S3 resources isn't affected by the addressing style of the upload
+		s3Client := *clients[firstClientKey(clientKeys)].S3conn
+		// the bucket can't be addressed as a subdomain of a custom endpoint (e.g., of LocalStack)
+		s3Client.ForcePathStyle = s.endpointURL != ""
+		opts.s3Output.client = &s3Client
 	}
-	// initialize a Terraform AWS provider for each AWS client with a matching config
-	providers, err := util.NewProviderPool(clientKeys, "2.68.0", "~/.awsls", 10*time.Second)
+	expandedProviderDir, err := util.PrepareProviderDir(s.providerDir)
 	if err != nil {
-		fmt.Fprint(os.Stderr, color.RedString("\nError: %s\n", err))
-
+		fmt.Fprint(os.Stderr, color.RedString("Error: invalid --provider-dir %s: %s\n", s.providerDir, err))
 		return 1
 	}
+
+	if len(s.providerVersions) > 1 {
+		err := compareProviderVersions(os.Stdout, s.providerVersions, s.resourceTypes, attributesOrDefault(s.attributes, ""),
+			clientKeys[0], expandedProviderDir, providerConfig)
+		if err != nil {
+			fmt.Fprint(os.Stderr, color.RedString("Error: %s\n", err))
+			return 1
+		}
+
+		return 0
+	}
+
+	// from now on, Ctrl-C stops listing and prints the resources gathered so far,
+	// so that the launched providers are closed before exiting
+	ctx, stopInterrupt := interruptContext()
+	defer stopInterrupt()
+
+	// initialize a Terraform AWS provider for each AWS client with a matching config
+	providers, providerErrs := util.NewProviderPool(clientKeys, s.providerVersions[0], expandedProviderDir,
+		10*time.Second, providerConfig)
+	if len(providers) == 0 {
+		// no provider could be launched at all, e.g. because the version doesn't exist (all errors are the same)
+		for _, err := range providerErrs {
+			fmt.Fprint(os.Stderr, color.RedString("\nError: failed to initialize the Terraform AWS Provider "+
+				"version %s (check that this version exists, see --provider-version): %s\n", s.providerVersions[0], err))
+
+			return 1
+		}
+	}
+	for key, err := range providerErrs {
+		// resources of this client are still listed, but attributes can't be displayed
+		fmt.Fprint(os.Stderr, color.RedString("\nError (profile=%s, region=%s): %s\n", key.Profile, key.Region, err))
+	}
 	defer func() {
 		for _, p := range providers {
 			_ = p.Close()
 		}
 	}()
-	// print ec2 instance
-	attributes := []string{"instance_type", "instance_state", "private_ip", "public_ip", "tags"}
-	printResource("aws_instance", attributes, clients, providers)
 
-	// print ebs volumes
-	attributes = []string{"size", "tags"}
-	printResource("aws_ebs_volume", attributes, clients, providers)
+	if s.reportUnmatched {
+		err := printUnmatchedPatterns(os.Stderr, s.resourceTypePatterns)
+		if err != nil {
+			fmt.Fprint(os.Stderr, color.RedString("Error: %s\n", err))
+			return 1
+		}
+	}
+
+	if !s.quiet {
+		opts.summary = newRunSummary()
+	}
 
-	// print eip
-	attributes = []string{"public_ip", "tags"}
-	printResource("aws_eip", attributes, clients, providers)
+	errs := 0
 
-	// print s3
-	attributes = []string{"tags"}
-	printResource("aws_s3_bucket", attributes, clients, providers)
+	// each type is printed once (into a file named after the type), even if matched by multiple patterns
+	for _, rType := range s.resourceTypes {
+		if ctx.Err() != nil {
+			break
+		}
 
-	// print nat gateway
-	attributes = []string{"tags"}
-	printResource("aws_nat_gateway", attributes, clients, providers)
+		errs += printResource(ctx, rType, attributesOrDefault(s.attributes, rType), clients, providers, opts)
+	}
 
-	// print rds instance
-	attributes = []string{"instance_class", "tags"}
-	printResource("aws_db_instance", attributes, clients, providers)
+	if opts.counts != nil {
+		err := opts.counts.write(os.Stdout)
+		if err != nil {
+			fmt.Fprint(os.Stderr, color.RedString("Error: %s\n", err))
+			errs++
+		}
+	}
 
-	return 0
-}
+	if opts.combined != nil {
+		errs += printCombined(ctx, opts)
+	}
 
-func printResource(resourceTypePattern string, attributes []string, clients map[util.AWSClientKey]aws.Client, providers map[util.AWSClientKey]provider.TerraformProvider) {
-	matchedTypes, err := resource.MatchSupportedTypes(resourceTypePattern)
+	// on stderr, so that it doesn't end up in output printed to stdout
+	err = opts.summary.write(os.Stderr, clientKeys)
 	if err != nil {
-		fmt.Fprint(os.Stderr, color.RedString("Error: invalid glob pattern: %s\n", resourceTypePattern))
-		panic(err)
+		fmt.Fprint(os.Stderr, color.RedString("Error: %s\n", err))
+		errs++
+	}
+
+	if ctx.Err() != nil {
+		fmt.Fprint(os.Stderr, color.YellowString("\nInterrupted, printed only the resources listed so far\n"))
+		return exitCodeInterrupted
 	}
 
-	if len(matchedTypes) == 0 {
-		fmt.Fprint(os.Stderr, color.RedString("Error: no resource type found: %s\n", resourceTypePattern))
+	if errs > 0 {
+		fmt.Fprint(os.Stderr, color.RedString("\n%d error(s) occurred, see above\n", errs))
+		return 1
 	}
 
-	for _, rType := range matchedTypes {
-		var resources []aws.Resource
-		var hasAttrs map[string]bool
+	return 0
+}
 
-		for key, client := range clients {
-			err := client.SetAccountID()
+// printCombined writes the resources of all types collected for --combined into a single file (or to stdout).
+// Errors are logged; the number of errors is returned.
+func printCombined(ctx context.Context, opts options) int {
+	var err error
+
+	switch {
+	case opts.output == "json" && opts.stdout:
+		err = writeCombinedJSON(os.Stdout, opts.combined, opts)
+	case opts.output == "json":
+		var filePath string
+
+		filePath, err = printCombinedJSON(opts.combined, opts)
+		if err == nil && opts.validateOutput {
+			n := len(opts.combined.resources())
+
+			err = validateJSONFile(filePath, n, opts.group)
 			if err != nil {
-				fmt.Fprint(os.Stderr, color.RedString("Error %s: %s\n", rType, err))
-				panic(err)
+				err = fmt.Errorf("output is invalid: %s", err)
+				break
 			}
 
-			res, err := aws.ListResourcesByType(&client, rType)
+			_, _ = fmt.Printf("validated output (%d resources)\n", n)
+		}
+	case opts.stdout:
+		err = writeCombinedCsv(os.Stdout, opts.combined, opts)
+	default:
+		var files []string
+		var rows int
+
+		files, rows, err = printCombinedCsv(ctx, opts.combined, opts)
+		if err == nil && opts.validateOutput {
+			err = validateCsvFiles(files, rows, opts.delimiter)
 			if err != nil {
-				fmt.Fprint(os.Stderr, color.RedString("Error %s: %s\n", rType, err))
-				continue
+				err = fmt.Errorf("output is invalid: %s", err)
+				break
 			}
 
-			terraformProvider := providers[key]
+			_, _ = fmt.Printf("validated output (%d rows)\n", rows)
+		}
+	}
 
-			hasAttrs, err = resource.HasAttributes(attributes, rType, &terraformProvider)
-			if err != nil {
-				fmt.Fprint(os.Stderr, color.RedString("Error: failed to check if resource type has attribute: "+
-					"%s\n", err))
+	if err != nil {
+		fmt.Fprint(os.Stderr, color.RedString("Error: %s\n", err))
+		return 1
+	}
 
-				continue
-			}
+	return 0
+}
 
-			if len(hasAttrs) > 0 {
-				// for performance reasons:
-				// only fetch state if some attributes need to be displayed for this resource type
-				res = resource.GetStates(res, providers)
-			}
+// printsNameColumn returns true if the output contains the NAME column with the value of the name tag
+// (see --name-tag), which is only printed in csv and parquet format.
+func printsNameColumn(opts options) bool {
+	return opts.nameTag != "" && opts.counts == nil && !opts.findDuplicates &&
+		(opts.output == "csv" || opts.output == "parquet")
+}
 
-			resources = append(resources, res...)
-		}
+// writesToStdout returns true if the resources are written to stdout (e.g., for piping them into another command)
+// rather than into files.
+func writesToStdout(opts options) bool {
+	return opts.stdout || opts.counts != nil || containsString([]string{"ids", "protobuf", "ndjson"}, opts.output)
+}
 
-		if len(resources) == 0 {
-			continue
-		}
-		printResourcesCsv(resourceTypePattern, resources, hasAttrs, attributes)
+// colorDisabled returns true if colors are disabled via --no-color or a non-empty NO_COLOR environment variable
+// (see https://no-color.org), or if the output isn't a terminal, where escape codes would garble logs.
+func colorDisabled(noColor, isTerminal bool) bool {
+	return noColor || os.Getenv("NO_COLOR") != "" || !isTerminal
+}
+
+// logLevel returns the log level for the given number of --verbose flags: by default, only warnings and errors
+// are logged, -v also logs info (e.g., of the Terraform AWS Provider) and -vv (or --debug) debug messages.
+// With --quiet, only errors are logged.
+func logLevel(verbose int, debug, quiet bool) log.Level {
+	switch {
+	case quiet:
+		return log.ErrorLevel
+	case debug || verbose >= 2:
+		return log.DebugLevel
+	case verbose == 1:
+		return log.InfoLevel
+	default:
+		return log.WarnLevel
 	}
 }
 
-// print resources in csv format, and save it into the aws-resource folder
-func printResourcesCsv(resourceTypePattern string, resources []aws.Resource, hasAttrs map[string]bool, attributes []string) {
-	filePath := filepath.Join("aws-resources/", resourceTypePattern+".csv")
-	err := os.MkdirAll("aws-resources/", os.ModePerm)
+// exitCodeInterrupted is the exit code after an interrupt (128 + SIGINT), as is common for shells.
+const exitCodeInterrupted = 130
+
+// interruptContext returns a context that is canceled on SIGINT or SIGTERM. After the first signal,
+// signals are handled as usual again, so that another Ctrl-C terminates awsls immediately.
+func interruptContext() (context.Context, func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		select {
+		case <-signals:
+			cancel()
+		case <-ctx.Done():
+		}
+
+		signal.Stop(signals)
+	}()
+
+	return ctx, cancel
+}
+
+// matchTypes returns the supported resource types matched by any of the given glob patterns (without duplicates).
+// If exact is set, each pattern must be the name of a supported type instead (see --exact).
+func matchTypes(patterns []string, exact bool) ([]string, error) {
+	matched, unmatched, err := awsls.MatchTypes(patterns, exact)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
-	csvFile, err := os.Create(filePath)
-	if err != nil {
-		panic(err)
+
+	for _, pattern := range unmatched {
+		fmt.Fprint(os.Stderr, color.RedString("Error: no resource type found: %s\n", pattern))
 	}
-	defer csvFile.Close()
-	w := csv.NewWriter(csvFile)
 
-	printHeaderCsv(w, attributes)
+	return matched, nil
+}
 
-	for _, r := range resources {
-		resourceItem := []string{r.Type, r.ID}
-		if r.CreatedAt != nil {
-			resourceItem = append(resourceItem, r.CreatedAt.Format("2006-01-02 15:04:05"))
-		} else {
-			resourceItem = append(resourceItem, "")
-		}
-		for _, attr := range attributes {
-			v := "N/A"
-			_, ok := hasAttrs[attr]
-			if ok {
-				var err error
-				v, err = resource.GetAttribute(attr, &r)
-				if err != nil {
-					log.WithFields(log.Fields{
-						"type": r.Type,
-						"id":   r.ID}).WithError(err).Debug("failed to get attribute")
-					v = "error"
-				}
-			}
-			resourceItem = append(resourceItem, v)
-		}
-		err := w.Write(resourceItem)
-		if err != nil {
-			panic(err)
-		}
+// attributesOrDefault returns the given attributes, or if there are none, the default attributes
+// for the resource type (tags for any other type).
+func attributesOrDefault(attributes []string, resourceType string) []string {
+	if attributes != nil {
+		return attributes
 	}
-	w.Flush()
-	_, _ = fmt.Printf("printed csv file into %s \n", csvFile.Name())
+
+	if defaultAttrs, ok := defaultAttributes[resourceType]; ok {
+		return defaultAttrs
+	}
+
+	return []string{"tags"}
 }
 
-// print csv header with fixed type and attributes
-func printHeaderCsv(w *csv.Writer, attributes []string) {
-	header := []string{"TYPE", "ID", "CREATED"}
-	for _, attribute := range attributes {
-		header = append(header, attribute)
+// semverRegexp matches a semantic version, e.g., 2.68.0 or 3.0.0-beta1.
+//
+//nolint:gochecknoglobals
+var semverRegexp = regexp.MustCompile(`^\d+\.\d+\.\d+(-[0-9A-Za-z.-]+)?$`)
+
+// isSemver returns true if the given version is a semantic version (without a leading v).
+func isSemver(version string) bool {
+	return semverRegexp.MatchString(version)
+}
+
+// defaultAttributes are the attributes printed for a resource type if no attributes are given.
+//
+//nolint:gochecknoglobals
+var defaultAttributes = map[string][]string{
+	"aws_instance":    {"instance_type", "instance_state", "private_ip", "public_ip", "tags"},
+	"aws_ebs_volume":  {"size", "tags"},
+	"aws_eip":         {"public_ip", "tags"},
+	"aws_s3_bucket":   {"tags"},
+	"aws_nat_gateway": {"tags"},
+	"aws_db_instance": {"instance_class", "tags"},
+}
+
+// sampleRegions returns n randomly picked regions (in their original order) out of the given ones.
+// The same seed always picks the same regions.
+func sampleRegions(regions []string, n int, seed int64) []string {
+	if n >= len(regions) {
+		return regions
 	}
-	err := w.Write(header)
-	if err != nil {
-		panic(err)
+
+	picked := rand.New(rand.NewSource(seed)).Perm(len(regions))[:n]
+	sort.Ints(picked)
+
+	result := make([]string, 0, n)
+	for _, i := range picked {
+		result = append(result, regions[i])
+	}
+
+	return result
+}
+
+// awsConfigPath returns the path of the AWS config file if set via --aws-config or AWS_CONFIG_FILE env,
+// otherwise the default path ~/.aws/config is used.
+func awsConfigPath(awsConfigFile string) []string {
+	if awsConfigFile != "" {
+		return []string{awsConfigFile}
 	}
+
+	awsConfigFileEnv, ok := os.LookupEnv("AWS_CONFIG_FILE")
+	if ok {
+		return []string{awsConfigFileEnv}
+	}
+
+	return nil
+}
+
+// ssoCredentialsOfProfiles resolves the credentials of all given profiles that are configured for AWS SSO,
+// skipping the ones whose credentials can't be resolved (e.g., because no valid token is cached).
+func ssoCredentialsOfProfiles(configFile string, profiles []string) util.ProfileCredentials {
+	result := util.ProfileCredentials{}
+
+	for _, profile := range profiles {
+		creds, err := util.SSOCredentials(configFile, []string{profile})
+		if err != nil {
+			log.WithField("profile", profile).WithError(err).Debug("failed to resolve SSO credentials of profile")
+			continue
+		}
+
+		for p, c := range creds {
+			result[p] = c
+		}
+	}
+
+	return result
+}
+
+// printUnmatchedPatterns prints the glob patterns that match no supported resource type as JSON array.
+func printUnmatchedPatterns(out io.Writer, patterns []string) error {
+	unmatched := []string{}
+
+	for _, pattern := range patterns {
+		matchedTypes, err := resource.MatchSupportedTypes(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid glob pattern: %s", pattern)
+		}
+
+		if len(matchedTypes) == 0 {
+			unmatched = append(unmatched, pattern)
+		}
+	}
+
+	return json.NewEncoder(out).Encode(unmatched)
+}
+
+// printResource lists and prints all resources of the given type, unless the type is excluded or filtered out
+// (e.g., because it doesn't support an attribute that is required).
+// Errors (e.g., of listing a type in a region or writing a file) are logged and don't stop printing the other
+// resources; the number of errors is returned, which also includes output that fails validation
+// (see --validate-output) and resources that don't match the expected ones (see --expected).
+// Once the context is done, the resources listed so far are printed, but no further types are listed.
+func printResource(ctx context.Context, resourceType string, attributes []string,
+	clients map[util.AWSClientKey]aws.Client, providers map[util.AWSClientKey]provider.TerraformProvider,
+	opts options) int {
+	// exclude always wins over the resource type arguments
+	rTypes, err := resource.ExcludeTypes([]string{resourceType}, opts.excludeTypes)
+	if err != nil {
+		fmt.Fprint(os.Stderr, color.RedString("Error: invalid --exclude pattern: %s\n", err))
+		return 1
+	}
+
+	if opts.onlyTypesWithAttribute != "" {
+		rTypes, err = filterTypesByAttribute(rTypes, opts.onlyTypesWithAttribute, providers)
+		if err != nil {
+			fmt.Fprint(os.Stderr, color.RedString("Error: %s\n", err))
+			return 1
+		}
+
+		if !containsString(attributes, opts.onlyTypesWithAttribute) {
+			attributes = append(append([]string{}, attributes...), opts.onlyTypesWithAttribute)
+		}
+	}
+
+	if opts.untagged {
+		// resources of types that don't support tags are never tagged, but that's not what is looked for
+		typesWithTags, err := filterTypesByAttribute(rTypes, "tags", providers)
+		if err != nil {
+			fmt.Fprint(os.Stderr, color.RedString("Error: %s\n", err))
+			return 1
+		}
+
+		for _, rType := range rTypes {
+			if !containsString(typesWithTags, rType) {
+				log.WithField("type", rType).Debug("resource type doesn't support tags")
+			}
+		}
+
+		rTypes = typesWithTags
+	}
+
+	if opts.output == "ids" || opts.counts != nil {
+		// IDs (and thus counts) are known from the list API, so no attributes need to be fetched
+		attributes = nil
+	}
+
+	if opts.explode != "" && !containsString(attributes, opts.explode) {
+		attributes = append(append([]string{}, attributes...), opts.explode)
+	}
+
+	if opts.expandTags && opts.counts == nil && !containsString(attributes, "tags") {
+		attributes = append(append([]string{}, attributes...), "tags")
+	}
+
+	if opts.output == "chargeback" {
+		// only the owner tag is needed, which is taken from the state for types whose list API doesn't return tags
+		attributes = []string{"tags"}
+	}
+
+	if opts.findDuplicates {
+		groupBy := opts.duplicatesBy
+		if groupBy == "" {
+			// the name tag is taken from the state for types whose list API doesn't return tags
+			groupBy = "tags"
+		}
+
+		if !containsString(attributes, groupBy) {
+			attributes = append(append([]string{}, attributes...), groupBy)
+		}
+	}
+
+	if opts.expected != nil && opts.expectedKey != "id" && opts.expectedKey != "type" &&
+		!containsString(attributes, opts.expectedKey) {
+		attributes = append(append([]string{}, attributes...), opts.expectedKey)
+	}
+
+	if opts.output == "json-map" && opts.jsonKey != "id" && opts.jsonKey != "type" &&
+		!containsString(attributes, opts.jsonKey) {
+		attributes = append(append([]string{}, attributes...), opts.jsonKey)
+	}
+
+	if opts.counts == nil && opts.output != "ids" && !containsString(sortColumns, opts.sortBy) &&
+		!containsString(attributes, opts.sortBy) {
+		attributes = append(append([]string{}, attributes...), opts.sortBy)
+	}
+
+	// number of errors, each of which has been logged
+	errs := 0
+	logError := func(format string, a ...interface{}) {
+		fmt.Fprint(os.Stderr, color.RedString(format+"\n", a...))
+		errs++
+	}
+
+	// resource counts per type and owner for the chargeback output
+	var chargeback []chargebackRow
+
+	// resources sharing the same name tag or attribute value for --find-duplicates
+	var duplicates []duplicateGroup
+
+	// number of resource types printed to stdout so far
+	printedTypes := 0
+
+	for _, rType := range rTypes {
+		if opts.orphans {
+			if !resource.HasOrphanCondition(rType) {
+				log.WithField("type", rType).Debug("no orphan condition known for resource type")
+				continue
+			}
+		}
+
+		if ctx.Err() != nil {
+			break
+		}
+
+		resources, hasAttrs, listErrs := listResources(ctx, rType, attributes, clients, providers, opts)
+		errs += listErrs
+
+		// resources streamed via --output ndjson have already been counted when listed
+		opts.summary.add(rType, resources)
+
+		if opts.withQuota {
+			printQuotaUsage(os.Stderr, rType, resources, clients)
+		}
+
+		if opts.warnOnStaleState {
+			warnStaleStates(rType, resources)
+		}
+
+		if opts.expected != nil {
+			missing, extra := reconcile(rType, opts.expected, resources, hasAttrs, opts.expectedKey)
+			if len(missing) > 0 || len(extra) > 0 {
+				writeReconciliation(os.Stderr, rType, missing, extra)
+				logError("Error: %d missing and %d extra resources of type %s", len(missing), len(extra), rType)
+			}
+		}
+
+		if len(resources) == 0 {
+			continue
+		}
+
+		if opts.counts != nil {
+			opts.counts.add(rType, resources)
+			continue
+		}
+
+		if opts.combined != nil {
+			opts.combined.add(resources, hasAttrs, attributes)
+			continue
+		}
+
+		if opts.findDuplicates {
+			nameTag := opts.nameTag
+			if nameTag == "" {
+				nameTag = defaultNameTag
+			}
+
+			duplicates = append(duplicates, findDuplicates(rType, resources, hasAttrs, opts.duplicatesBy,
+				nameTag)...)
+			continue
+		}
+
+		if opts.output == "ids" {
+			writeIDs(os.Stdout, resources, opts)
+			continue
+		}
+
+		if opts.output == "chargeback" {
+			chargeback = append(chargeback, countByOwner(rType, resources, opts.ownerTag)...)
+			continue
+		}
+
+		if opts.output == "protobuf" {
+			err := writeResourcesProtobuf(os.Stdout, resources, hasAttrs, attributes)
+			if err != nil {
+				logError("Error %s: %s", rType, err)
+			}
+
+			continue
+		}
+
+		if opts.stdout {
+			if printedTypes > 0 {
+				// separate the output of each resource type (with its own header) by a blank line
+				fmt.Println()
+			}
+			printedTypes++
+		}
+
+		if opts.output == "json" && opts.stdout {
+			err := writeResourcesJSON(os.Stdout, resources, hasAttrs, attributes, opts)
+			if err != nil {
+				logError("Error %s: %s", rType, err)
+			}
+
+			continue
+		}
+
+		if opts.output == "json-map" && opts.stdout {
+			err := writeResourcesJSONMap(os.Stdout, resources, hasAttrs, attributes, opts)
+			if err != nil {
+				logError("Error %s: %s", rType, err)
+			}
+
+			continue
+		}
+
+		if opts.stdout {
+			err := writeResourcesCsv(os.Stdout, resources, hasAttrs, attributes, opts)
+			if err != nil {
+				logError("Error %s: %s", rType, err)
+			}
+
+			continue
+		}
+
+		var validate func() error
+
+		switch opts.output {
+		case "json", "json-map":
+			var filePath string
+			var err error
+
+			if opts.output == "json" {
+				filePath, err = printResourcesJSON(rType, resources, hasAttrs, attributes, opts)
+			} else {
+				filePath, err = printResourcesJSONMap(rType, resources, hasAttrs, attributes, opts)
+			}
+			if err != nil {
+				logError("Error %s: %s", rType, err)
+				continue
+			}
+
+			validate = func() error { return validateJSONFile(filePath, len(resources), opts.group) }
+		case "parquet":
+			filePath, err := printResourcesParquet(rType, resources, hasAttrs, attributes, opts)
+			if err != nil {
+				logError("Error %s: %s", rType, err)
+				continue
+			}
+
+			validate = func() error { return validateParquetFile(filePath, len(resources)) }
+		default:
+			files, rows, err := printResourcesCsv(ctx, rType, resources, hasAttrs, attributes, opts)
+			if err != nil {
+				logError("Error %s: %s", rType, err)
+				continue
+			}
+
+			validate = func() error { return validateCsvFiles(files, rows, opts.delimiter) }
+		}
+
+		if opts.validateOutput {
+			err := validate()
+			if err != nil {
+				logError("Error: output of %s is invalid: %s", rType, err)
+				continue
+			}
+
+			_, _ = fmt.Printf("validated output of %s (%d rows)\n", rType, len(resources))
+		}
+	}
+
+	if opts.findDuplicates {
+		if opts.stdout {
+			err = writeDuplicatesCsv(os.Stdout, duplicates)
+		} else {
+			err = printDuplicatesCsv(opts.outDir, resourceType, duplicates)
+		}
+		if err != nil {
+			logError("Error: %s", err)
+		}
+	}
+
+	if opts.output == "chargeback" && len(chargeback) > 0 {
+		if opts.stdout {
+			err = writeChargebackCsv(os.Stdout, chargeback)
+		} else {
+			err = printChargebackCsv(opts.outDir, resourceType, chargeback)
+		}
+		if err != nil {
+			logError("Error: %s", err)
+		}
+	}
+
+	return errs
+}
+
+// filterTypesByAttribute returns only the resource types that support the given attribute.
+// The schema of the resource types is looked up via any of the given providers.
+func filterTypesByAttribute(rTypes []string, attribute string,
+	providers map[util.AWSClientKey]provider.TerraformProvider) ([]string, error) {
+	for _, p := range providers {
+		var result []string
+
+		for _, rType := range rTypes {
+			attrs, err := resource.HasAttributes([]string{attribute}, rType, &p)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check if resource type has attribute: %s", err)
+			}
+
+			if attrs[attribute] {
+				result = append(result, rType)
+			}
+		}
+
+		return result, nil
+	}
+
+	return nil, fmt.Errorf("no Terraform AWS Provider to look up which resource types have attribute: %s", attribute)
+}
+
+// containsString returns true if the given string is in the list.
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+// execHook returns a hook that runs the given command for each resource. Each argument of the command
+// is a Go template, which is executed with the resource as data (e.g., {{.ID}} or {{.Region}}).
+// The command is run without a shell, so resource fields can't inject shell syntax.
+func execHook(command string) (func(r aws.Resource) error, error) {
+	var args []*template.Template
+
+	for _, field := range strings.Fields(command) {
+		tmpl, err := template.New("exec").Parse(field)
+		if err != nil {
+			return nil, err
+		}
+
+		args = append(args, tmpl)
+	}
+
+	if len(args) == 0 {
+		return nil, fmt.Errorf("empty command")
+	}
+
+	return func(r aws.Resource) error {
+		var cmdArgs []string
+
+		for _, tmpl := range args {
+			var buf strings.Builder
+
+			err := tmpl.Execute(&buf, r)
+			if err != nil {
+				return err
+			}
+
+			cmdArgs = append(cmdArgs, buf.String())
+		}
+
+		cmd := exec.Command(cmdArgs[0], cmdArgs[1:]...)
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+
+		return cmd.Run()
+	}, nil
+}
+
+// createdWithinCutoff returns the time after which resources must have been created to be created within
+// the given duration until now. It can't be combined with an absolute after time, and the before time
+// (if non-zero) must be later than the cutoff, as no resource would be listed otherwise.
+func createdWithinCutoff(within time.Duration, after, before, now time.Time) (time.Time, error) {
+	if !after.IsZero() {
+		return time.Time{}, fmt.Errorf("can't be combined with --created-after")
+	}
+
+	cutoff := now.Add(-within)
+
+	if !before.IsZero() && !before.After(cutoff) {
+		return time.Time{}, fmt.Errorf("--created-before (%s) must be later than %s", before.Format(time.RFC3339),
+			cutoff.Format(time.RFC3339))
+	}
+
+	return cutoff, nil
+}
+
+// warnStaleStates prints a warning if the state of any of the given resources couldn't be refreshed.
+func warnStaleStates(rType string, resources []aws.Resource) {
+	stale := 0
+	for _, r := range resources {
+		if r.StateStale {
+			stale++
+		}
+	}
+
+	if stale > 0 {
+		fmt.Fprint(os.Stderr, color.YellowString("Warning: state of %d %s resource(s) couldn't be refreshed "+
+			"(see STATE_STALE column)\n", stale, rType))
+	}
+}
+
+// printQuotaUsage prints for each AWS client how many resources of the given type are used
+// out of the service quota of its account and region, or, for quotas that apply to the whole account,
+// once for each profile how many are used across all regions.
+func printQuotaUsage(out io.Writer, rType string, resources []aws.Resource,
+	clients map[util.AWSClientKey]aws.Client) {
+	quota, ok := resource.GetQuota(rType)
+	if !ok {
+		log.WithField("type", rType).Debug("no service quota known for resource type")
+		return
+	}
+
+	// the key of the usage of the quota, which doesn't have a region if the quota applies to the whole account
+	usageKey := func(key util.AWSClientKey) util.AWSClientKey {
+		if quota.Global {
+			key.Region = ""
+		}
+
+		return key
+	}
+
+	counts := map[util.AWSClientKey]int{}
+	for _, r := range resources {
+		counts[usageKey(util.AWSClientKey{Profile: r.Profile, Region: r.Region})]++
+	}
+
+	keys := make([]util.AWSClientKey, 0, len(clients))
+	for k := range clients {
+		keys = append(keys, k)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Profile != keys[j].Profile {
+			return keys[i].Profile < keys[j].Profile
+		}
+
+		return keys[i].Region < keys[j].Region
+	})
+
+	printed := map[util.AWSClientKey]bool{}
+
+	for _, key := range keys {
+		k := usageKey(key)
+		if printed[k] {
+			continue
+		}
+		printed[k] = true
+
+		client := clients[key]
+
+		limit, err := client.GetServiceQuota(quota.ServiceCode, quota.QuotaCode)
+		if err != nil {
+			fmt.Fprint(os.Stderr, color.RedString("Error %s: %s\n", rType, err))
+			continue
+		}
+
+		if quota.Global {
+			_, _ = fmt.Fprintf(out, "%s (profile=%s, all regions): %d of %.0f used\n",
+				rType, key.Profile, counts[k], limit)
+			continue
+		}
+
+		_, _ = fmt.Fprintf(out, "%s (profile=%s, region=%s): %d of %.0f used\n",
+			rType, key.Profile, key.Region, counts[k], limit)
+	}
+}
+
+// listResources lists all resources of the given type across all clients via awsls.ListType, which fetches their
+// state if some of the attributes need to be displayed, and sorts them (see --sort). Errors of single clients are
+// logged and counted, but don't stop listing the other clients; the number of errors is returned.
+func listResources(ctx context.Context, rType string, attributes []string,
+	clients map[util.AWSClientKey]aws.Client, providers map[util.AWSClientKey]provider.TerraformProvider,
+	opts options) ([]aws.Resource, map[string]bool, int) {
+	var mu sync.Mutex
+	// number of errors, each of which has been logged
+	errs := 0
+
+	progress := newProgressPrinter(os.Stderr, isatty.IsTerminal(os.Stderr.Fd()))
+
+	logError := func(format string, a ...interface{}) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		progress.clear()
+		fmt.Fprint(os.Stderr, color.RedString(format+"\n", a...))
+		errs++
+	}
+
+	requiredAttributes := attributes
+	if printsNameColumn(opts) && !containsString(attributes, "tags") {
+		// print the name tag of the state, which may be more up-to-date than the tags returned by the list API,
+		// if returned at all
+		requiredAttributes = append(append([]string{}, attributes...), "tags")
+	}
+
+	resources, hasAttrs, err := awsls.ListType(ctx, rType, clients, providers, awsls.Options{
+		Attributes: requiredAttributes,
+		Filter: awsls.Filter{
+			ResourceGroupARNs:  opts.resourceGroupARNs,
+			IDPrefix:           opts.idPrefix,
+			ID:                 opts.idFilter,
+			CreatedAfter:       opts.createdAfter,
+			CreatedBefore:      opts.createdBefore,
+			KeepUnknownCreated: opts.keepUnknownCreated,
+			Attributes:         opts.attributeFilters,
+			Orphans:            opts.orphans,
+			Tags:               opts.tags,
+			ExcludeTags:        opts.excludeTags,
+			Untagged:           opts.untagged,
+		},
+		Parallel:           opts.parallel,
+		PerTypeTimeout:     opts.perTypeTimeout,
+		IgnoreAccessDenied: opts.ignoreAccessDenied,
+		PreferListAPI:      opts.preferListAPI,
+		WarnOver:           opts.warnOver,
+		AccountSem:         opts.accountSem,
+		RateLimiter:        opts.rateLimiter,
+		StateCache:         opts.stateCache,
+		TaggedResources:    opts.taggedResources,
+		Progress:           progress.client,
+		OnResources: func(rType string, res []aws.Resource, hasAttrs map[string]bool) {
+			if opts.output == "ndjson" {
+				// stream the resources instead of collecting them, so that they are printed as soon as
+				// they are listed (the output of multiple clients is never interleaved)
+				opts.summary.add(rType, res)
+
+				for i := range res {
+					err := writeResourceNDJSON(os.Stdout, &res[i], hasAttrs, attributes, opts.headerCase)
+					if err != nil {
+						logError("Error %s (id=%s): %s", rType, res[i].ID, err)
+					}
+				}
+			}
+
+			if opts.onResource != nil {
+				// clients list in parallel, but the hook is never called concurrently
+				for _, r := range res {
+					err := opts.onResource(r)
+					if err != nil {
+						logError("Error %s (id=%s): %s", rType, r.ID, err)
+					}
+				}
+			}
+		},
+		// already printed
+		Stream: opts.output == "ndjson",
+		OnError: func(err error) {
+			logError("Error %s", err)
+		},
+		OnWarning: func(msg string) {
+			progress.clear()
+			fmt.Fprint(os.Stderr, color.YellowString("Warning: %s\n", msg))
+		},
+	})
+	progress.clear()
+
+	if err != nil {
+		logError("Error %s", err)
+	}
+
+	sortResources(resources, opts.sortBy, hasAttrs)
+
+	return resources, hasAttrs, errs
+}
+
+// sortColumns are the columns (other than attributes) by which resources can be sorted (see --sort).
+var sortColumns = []string{"type", "id", "account_id", "profile", "region", "created"}
+
+// sortResources sorts the resources by the given column or attribute, and ties by region and ID,
+// so that the output doesn't depend on the order in which the clients have been listed.
+// Values that are numbers are compared as such, all others as text.
+func sortResources(resources []aws.Resource, by string, hasAttrs map[string]bool) {
+	type keyed struct {
+		key string
+		r   aws.Resource
+	}
+
+	// the key of each resource is computed once, as reading an attribute from the state isn't cheap
+	sorted := make([]keyed, len(resources))
+	for i := range resources {
+		sorted[i] = keyed{key: sortKey(by, &resources[i], hasAttrs), r: resources[i]}
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if c := compareValues(sorted[i].key, sorted[j].key); c != 0 {
+			return c < 0
+		}
+
+		if sorted[i].r.Region != sorted[j].r.Region {
+			return sorted[i].r.Region < sorted[j].r.Region
+		}
+
+		return sorted[i].r.ID < sorted[j].r.ID
+	})
+
+	for i := range sorted {
+		resources[i] = sorted[i].r
+	}
+}
+
+// sortKey returns the value of a resource to sort by (see --sort).
+func sortKey(by string, r *aws.Resource, hasAttrs map[string]bool) string {
+	switch by {
+	case "type":
+		return r.Type
+	case "id":
+		return r.ID
+	case "account_id":
+		return r.AccountID
+	case "profile":
+		return r.Profile
+	case "region":
+		return r.Region
+	case "created":
+		if r.CreatedAt == nil {
+			return ""
+		}
+
+		// fixed width, so that the lexical order is the chronological one
+		return r.CreatedAt.UTC().Format("2006-01-02T15:04:05.000000000")
+	default:
+		v, _ := attributeValue(by, r, hasAttrs)
+
+		return v
+	}
+}
+
+// compareValues compares two values numerically if both are numbers, otherwise as text.
+func compareValues(a, b string) int {
+	x, errA := strconv.ParseFloat(a, 64)
+	y, errB := strconv.ParseFloat(b, 64)
+
+	if errA == nil && errB == nil {
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		default:
+			return 0
+		}
+	}
+
+	return strings.Compare(a, b)
+}
+
+// progressPrinter reports how many resources of a type have been listed so far by all clients, updating
+// the same line after each page. Clients list in parallel, so their progress is summed up and printed
+// under a mutex, which never interleaves the line. All methods can be called on a nil printer (no progress).
+type progressPrinter struct {
+	mu      sync.Mutex
+	out     io.Writer
+	listed  map[util.AWSClientKey]int
+	printed bool
+}
+
+// newProgressPrinter returns a printer that writes to out, or nil if progress shouldn't be printed
+// (e.g., because out isn't a terminal).
+func newProgressPrinter(out io.Writer, enabled bool) *progressPrinter {
+	if !enabled {
+		return nil
+	}
+
+	return &progressPrinter{out: out, listed: map[util.AWSClientKey]int{}}
+}
+
+// client returns the function to report the progress of listing via the client with the given key.
+func (p *progressPrinter) client(key util.AWSClientKey) aws.ProgressFunc {
+	if p == nil {
+		return nil
+	}
+
+	return func(rType string, listed int) {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+
+		p.listed[key] = listed
+
+		total := 0
+		for _, n := range p.listed {
+			total += n
+		}
+
+		p.printed = true
+		fmt.Fprintf(p.out, "\r%s: %d listed (%s)...", rType, total, plural(len(p.listed), "profile/region"))
+	}
+}
+
+// clear erases the progress line, e.g., before printing an error or once listing is done.
+// The line is printed again with the next progress of any client.
+func (p *progressPrinter) clear() {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.printed {
+		// carriage return and erase the line
+		fmt.Fprint(p.out, "\r\033[K")
+		p.printed = false
+	}
+}
+
+// print resources in csv format, and save it into the output directory.
+// Returns the paths of the written files and the number of rows written.
+func printResourcesCsv(ctx context.Context, resourceType string, resources []aws.Resource, hasAttrs map[string]bool,
+	attributes []string, opts options) ([]string, int, error) {
+	filePath := filepath.Join(opts.outDir, resourceType+".csv")
+	err := createOutDir(opts.outDir)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if opts.expandTags {
+		// the tag columns depend on the tags of all resources, so they must be known before the header is written
+		opts.tagKeys = distinctTagKeys(resources)
+	}
+
+	w := internal.NewRollingCsvWriter(filePath, csvHeader(attributes, opts), int64(opts.maxFileSize))
+	if opts.delimiter != 0 {
+		w.Comma = opts.delimiter
+	}
+
+	rows, err := writeRecordsCsv(w, resources, hasAttrs, attributes, opts)
+	if err != nil {
+		_ = w.Close()
+		return nil, 0, err
+	}
+
+	err = w.Close()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = printedCsvFiles(ctx, w.Files(), opts)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return w.Files(), rows, nil
+}
+
+// printedCsvFiles reports the written csv files, which are uploaded first if the output goes to S3.
+func printedCsvFiles(ctx context.Context, files []string, opts options) error {
+	if opts.s3Output == nil {
+		for _, f := range files {
+			_, _ = fmt.Printf("printed csv file into %s \n", f)
+		}
+
+		return nil
+	}
+
+	urls, err := opts.s3Output.upload(ctx, files)
+	for _, u := range urls {
+		_, _ = fmt.Printf("uploaded csv file to %s \n", u)
+	}
+
+	return err
+}
+
+// createOutDir creates the output directory (see --out-dir), if it doesn't exist yet.
+func createOutDir(outDir string) error {
+	err := os.MkdirAll(outDir, 0755)
+	if err != nil {
+		return fmt.Errorf("failed to create output directory: %s", err)
+	}
+
+	return nil
+}
+
+// validateCsvFiles re-reads the given csv files, which all start with a header,
+// and checks that they are well-formed and contain the expected number of rows in total.
+func validateCsvFiles(files []string, wantRows int, comma rune) error {
+	rows := 0
+
+	for _, f := range files {
+		records, err := readCsvFile(f, comma)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %s", f, err)
+		}
+
+		if len(records) == 0 {
+			return fmt.Errorf("missing header in %s", f)
+		}
+
+		rows += len(records) - 1
+	}
+
+	if rows != wantRows {
+		return fmt.Errorf("expected %d rows, but found %d", wantRows, rows)
+	}
+
+	return nil
+}
+
+// readCsvFile reads all records of a csv file with the given field delimiter.
+func readCsvFile(path string, comma rune) ([][]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.Comma = comma
+
+	return r.ReadAll()
+}
+
+// parseDelimiter parses the field delimiter of the csv output, which must be a single character
+// (or \t for tab) that is valid as delimiter.
+func parseDelimiter(s string) (rune, error) {
+	if s == `\t` {
+		return '\t', nil
+	}
+
+	if utf8.RuneCountInString(s) != 1 {
+		return 0, fmt.Errorf("must be a single character: %q", s)
+	}
+
+	r, _ := utf8.DecodeRuneInString(s)
+	if r == '"' || r == '\r' || r == '\n' || r == utf8.RuneError {
+		return 0, fmt.Errorf("not allowed as delimiter: %q", s)
+	}
+
+	return r, nil
+}
+
+// writeIDs writes the ID of each resource in a separate line, optionally prefixed by its type.
+func writeIDs(out io.Writer, resources []aws.Resource, opts options) {
+	for _, r := range resources {
+		if opts.idsWithType {
+			_, _ = fmt.Fprintf(out, "%s %s\n", r.Type, r.ID)
+			continue
+		}
+
+		_, _ = fmt.Fprintln(out, r.ID)
+	}
+}
+
+// writeResourcesCsv writes the header and a row for each resource in csv format.
+func writeResourcesCsv(out io.Writer, resources []aws.Resource, hasAttrs map[string]bool,
+	attributes []string, opts options) error {
+	w := csv.NewWriter(out)
+	if opts.delimiter != 0 {
+		w.Comma = opts.delimiter
+	}
+
+	if opts.expandTags {
+		opts.tagKeys = distinctTagKeys(resources)
+	}
+
+	err := printHeaderCsv(w, attributes, opts)
+	if err != nil {
+		return err
+	}
+
+	_, err = writeRecordsCsv(w, resources, hasAttrs, attributes, opts)
+
+	return err
+}
+
+// csvWriter writes records in csv format, e.g., a csv.Writer.
+type csvWriter interface {
+	Write(record []string) error
+	Flush()
+	Error() error
+}
+
+// writeRecordsCsv writes a row for each resource in csv format (or one row per element of the exploded attribute,
+// see --explode). Returns the number of rows written.
+func writeRecordsCsv(w csvWriter, resources []aws.Resource, hasAttrs map[string]bool,
+	attributes []string, opts options) (int, error) {
+	explodeIndex := -1
+	if opts.explode != "" {
+		for i, column := range csvHeader(attributes, opts) {
+			if column == opts.explode {
+				explodeIndex = i
+				break
+			}
+		}
+	}
+
+	rows := 0
+
+	for i := range resources {
+		records := [][]string{csvRecord(&resources[i], hasAttrs, attributes, opts)}
+		if explodeIndex >= 0 && hasAttrs[opts.explode] {
+			records = explodeRecord(records[0], explodeIndex, &resources[i], opts)
+		}
+
+		for _, record := range records {
+			err := w.Write(record)
+			if err != nil {
+				return rows, err
+			}
+
+			rows++
+
+			if opts.flushEvery > 0 && rows%opts.flushEvery == 0 {
+				w.Flush()
+				if err := w.Error(); err != nil {
+					return rows, err
+				}
+			}
+		}
+	}
+	w.Flush()
+
+	return rows, w.Error()
+}
+
+// explodeRecord returns a copy of the given record for each element of the list attribute to explode,
+// with the field at index replaced by the element. If the list is empty, a single record
+// with an empty field is returned, so that the resource isn't dropped from the output.
+func explodeRecord(record []string, index int, r *aws.Resource, opts options) [][]string {
+	elements, err := resource.GetAttributeElements(opts.explode, r)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"type": r.Type,
+			"id":   r.ID}).WithError(err).Debug("failed to get elements of attribute to explode")
+
+		return [][]string{record}
+	}
+
+	if len(elements) == 0 {
+		elements = []string{""}
+	}
+
+	var result [][]string
+
+	for _, e := range elements {
+		exploded := append([]string{}, record...)
+		exploded[index] = e
+
+		result = append(result, exploded)
+	}
+
+	return result
+}
+
+// csvRecord returns the fields of a csv row for the given resource.
+func csvRecord(r *aws.Resource, hasAttrs map[string]bool, attributes []string, opts options) []string {
+	// account, profile and region of the AWS client that listed the resource,
+	// so rows of multiple accounts can be told apart
+	resourceItem := []string{r.Type, r.ID, r.AccountID, r.Profile, r.Region}
+	if r.CreatedAt != nil {
+		resourceItem = append(resourceItem, r.CreatedAt.Format("2006-01-02 15:04:05"))
+	} else {
+		resourceItem = append(resourceItem, "")
+	}
+	if opts.flagOlderThan > 0 {
+		resourceItem = append(resourceItem, ageFlag(r, opts))
+	}
+	if opts.warnOnStaleState {
+		resourceItem = append(resourceItem, strconv.FormatBool(r.StateStale))
+	}
+	if opts.nameTag != "" {
+		// the tags of the state, as the list API of many resource types doesn't return tags
+		resourceItem = append(resourceItem, resource.GetTags(r)[opts.nameTag])
+	}
+	for _, attr := range attributes {
+		if opts.expandTags && attr == "tags" {
+			tags := resource.GetTags(r)
+			for _, key := range opts.tagKeys {
+				resourceItem = append(resourceItem, tags[key])
+			}
+
+			continue
+		}
+
+		v, ok := attributeValue(attr, r, hasAttrs)
+		if !ok {
+			v = opts.nullValue
+		}
+		resourceItem = append(resourceItem, v)
+
+		if opts.compareSources && awsls.IsListAPIAttribute(attr) {
+			resourceItem = append(resourceItem, listAPIValue(attr, r, opts))
+		}
+	}
+
+	return resourceItem
+}
+
+// tagColumnPrefix is prepended to the key of a tag to name its column (see --expand-tags).
+const tagColumnPrefix = "tag:"
+
+// distinctTagKeys returns the keys of all tags of the given resources, sorted and without duplicates.
+func distinctTagKeys(resources []aws.Resource) []string {
+	seen := map[string]bool{}
+
+	var result []string
+
+	for i := range resources {
+		for key := range resource.GetTags(&resources[i]) {
+			if !seen[key] {
+				seen[key] = true
+				result = append(result, key)
+			}
+		}
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
+// listAPIColumnSuffix is appended to the header of a column that contains the value of an attribute
+// as returned by the list API (instead of the Terraform state).
+const listAPIColumnSuffix = " (list API)"
+
+// listAPIValue returns the value of the given attribute as returned by the list API of a service.
+func listAPIValue(attr string, r *aws.Resource, opts options) string {
+	if attr == "tags" && r.Tags != nil {
+		return resource.FormatTags(r.Tags)
+	}
+
+	return opts.nullValue
+}
+
+// ageFlag returns a marker if the given resource has been created longer ago than --flag-older-than.
+// Resources without creation time are not flagged.
+func ageFlag(r *aws.Resource, opts options) string {
+	if r.CreatedAt == nil || time.Since(*r.CreatedAt) <= time.Duration(opts.flagOlderThan) {
+		return ""
+	}
+
+	return "OLD"
+}
+
+// attributeValue returns the value of the given attribute of a resource,
+// or false if the attribute isn't supported by the resource type (or the path of a nested value doesn't resolve).
+// The value of an attribute that can't be read is "error".
+func attributeValue(attr string, r *aws.Resource, hasAttrs map[string]bool) (string, bool) {
+	v, ok, err := lookupAttribute(attr, r, hasAttrs)
+	if err != nil {
+		return "error", true
+	}
+
+	return v, ok
+}
+
+// lookupAttribute is like attributeValue, but returns the error of an attribute that can't be read.
+func lookupAttribute(attr string, r *aws.Resource, hasAttrs map[string]bool) (string, bool, error) {
+	// the state isn't fetched if the list API already returned all values (see --prefer-list-api)
+	stateNotFetched := r.UpdatableResource == nil && !r.StateStale
+
+	if _, ok := hasAttrs[attr]; ok && !stateNotFetched {
+		v, err := resource.GetAttribute(attr, r)
+		if errors.Is(err, resource.ErrAttributeNotFound) {
+			// e.g., the path of a nested value into a list that is empty for this resource
+			return "", false, nil
+		}
+
+		if err != nil {
+			log.WithFields(log.Fields{
+				"type": r.Type,
+				"id":   r.ID}).WithError(err).Debug("failed to get attribute")
+
+			return "", false, err
+		}
+
+		return v, true, nil
+	}
+
+	if attr == "tags" && r.Tags != nil {
+		// the Terraform schema of some resource types doesn't expose tags,
+		// so fall back to the tags returned by the list API of the service
+		return resource.FormatTags(r.Tags), true, nil
+	}
+
+	return "", false, nil
+}
+
+// print csv header with fixed type and attributes
+func printHeaderCsv(w csvWriter, attributes []string, opts options) error {
+	return w.Write(csvHeader(attributes, opts))
+}
+
+// headerName returns the given column header in the casing set via --header-case.
+func headerName(name, headerCase string) string {
+	switch headerCase {
+	case "upper":
+		return strings.ToUpper(name)
+	case "lower":
+		return strings.ToLower(name)
+	default:
+		return name
+	}
+}
+
+// csvHeader returns the fields of the csv header.
+func csvHeader(attributes []string, opts options) []string {
+	header := []string{"TYPE", "ID", "ACCOUNT_ID", "PROFILE", "REGION", "CREATED"}
+	if opts.flagOlderThan > 0 {
+		header = append(header, "AGE_FLAG")
+	}
+	if opts.warnOnStaleState {
+		header = append(header, "STATE_STALE")
+	}
+	if opts.nameTag != "" {
+		header = append(header, "NAME")
+	}
+	for _, attribute := range attributes {
+		if opts.expandTags && attribute == "tags" {
+			for _, key := range opts.tagKeys {
+				header = append(header, tagColumnPrefix+key)
+			}
+
+			continue
+		}
+
+		header = append(header, attribute)
+
+		if opts.compareSources && awsls.IsListAPIAttribute(attribute) {
+			header = append(header, attribute+listAPIColumnSuffix)
+		}
+	}
+
+	for i := range header {
+		header[i] = headerName(header[i], opts.headerCase)
+	}
+
+	return header
+}
+
+// printSupportedTypes prints the given resource types, optionally together with the AWS API operation
+// (i.e., the IAM permission needed) for listing each type.
+func printSupportedTypes(out io.Writer, rTypes []string, verbose bool) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	for _, rType := range rTypes {
+		if verbose {
+			_, _ = fmt.Fprintf(w, "%s\t%s\n", rType, aws.ListOperations[rType])
+			continue
+		}
+
+		_, _ = fmt.Fprintln(w, rType)
+	}
+
+	_ = w.Flush()
 }
 
-func printHelp(fs *flag.FlagSet) {
+func printHelp() {
 	fmt.Fprintf(os.Stderr, "\n"+strings.TrimSpace(help)+"\n")
-	fs.PrintDefaults()
+	newFlagSet(&options{}, &flagValues{}).PrintDefaults()
 }
 
 const help = `
 awsls - list AWS resources.
 
 USAGE:
-  $ awsls [flags] 
+  $ awsls [flags] <resource_type glob pattern>...
 
 FLAGS:
 `
@@ -0,0 +1,2128 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apex/log"
+	awsSDK "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/external"
+	"github.com/golang/protobuf/proto"
+	"github.com/jckuester/awsls/aws"
+	"github.com/jckuester/awsls/internal"
+	"github.com/jckuester/awsls/pb"
+	"github.com/jckuester/awsls/pkg/awsls"
+	"github.com/jckuester/awsls/util"
+	terradozerRes "github.com/jckuester/terradozer/pkg/resource"
+	flag "github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xitongsys/parquet-go-source/buffer"
+	"github.com/xitongsys/parquet-go/reader"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestWriteResourcesCsv(t *testing.T) {
+	createdAt := time.Date(2020, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		resources      []aws.Resource
+		compareSources bool
+		want           string
+	}{
+		{
+			name: "no resources",
+			want: "TYPE,ID,ACCOUNT_ID,PROFILE,REGION,CREATED,NAME,tags\n",
+		},
+		{
+			name: "multiple resources",
+			resources: []aws.Resource{
+				{
+					Type:      "aws_instance",
+					ID:        "i-123",
+					AccountID: "123456789012",
+					Profile:   "prod",
+					Region:    "us-east-1",
+					CreatedAt: &createdAt,
+					Tags:      map[string]string{"Name": "foo"},
+				},
+				{
+					Type: "aws_instance",
+					ID:   "i-456",
+				},
+			},
+			want: "TYPE,ID,ACCOUNT_ID,PROFILE,REGION,CREATED,NAME,tags\n" +
+				"aws_instance,i-123,123456789012,prod,us-east-1,2020-07-01 12:00:00,foo,\"{\"\"Name\"\":\"\"foo\"\"}\"\n" +
+				"aws_instance,i-456,,,,,,N/A\n",
+		},
+		{
+			name: "compare sources",
+			resources: []aws.Resource{
+				{
+					Type: "aws_instance",
+					ID:   "i-123",
+					Tags: map[string]string{"Name": "foo"},
+				},
+			},
+			compareSources: true,
+			want: "TYPE,ID,ACCOUNT_ID,PROFILE,REGION,CREATED,NAME,tags,tags (list API)\n" +
+				"aws_instance,i-123,,,,,foo,\"{\"\"Name\"\":\"\"foo\"\"}\",\"{\"\"Name\"\":\"\"foo\"\"}\"\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			err := writeResourcesCsv(&buf, tt.resources, nil, []string{"tags"},
+				options{nameTag: "Name", nullValue: "N/A", compareSources: tt.compareSources})
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, buf.String())
+			// POSIX tools (e.g., wc -l) expect the last line to end with a newline
+			assert.True(t, bytes.HasSuffix(buf.Bytes(), []byte("\n")))
+		})
+	}
+}
+
+func TestWriteResourcesCsv_nameTag(t *testing.T) {
+	state := cty.ObjectVal(map[string]cty.Value{
+		"tags": cty.MapVal(map[string]cty.Value{"Name": cty.StringVal("logs")}),
+	})
+
+	resources := []aws.Resource{
+		{
+			// e.g., S3 buckets, whose list API doesn't return tags
+			Type:              "aws_s3_bucket",
+			ID:                "my-logs",
+			UpdatableResource: terradozerRes.NewWithState("aws_s3_bucket", "my-logs", nil, &state),
+		},
+		{
+			Type: "aws_s3_bucket",
+			ID:   "untagged",
+		},
+	}
+
+	tests := []struct {
+		name    string
+		nameTag string
+		want    string
+	}{
+		{
+			name:    "name tag from state",
+			nameTag: defaultNameTag,
+			want: "TYPE,ID,ACCOUNT_ID,PROFILE,REGION,CREATED,NAME\n" +
+				"aws_s3_bucket,my-logs,,,,,logs\n" +
+				"aws_s3_bucket,untagged,,,,,\n",
+		},
+		{
+			name:    "other name tag",
+			nameTag: "team",
+			want: "TYPE,ID,ACCOUNT_ID,PROFILE,REGION,CREATED,NAME\n" +
+				"aws_s3_bucket,my-logs,,,,,\n" +
+				"aws_s3_bucket,untagged,,,,,\n",
+		},
+		{
+			name: "NAME column omitted",
+			want: "TYPE,ID,ACCOUNT_ID,PROFILE,REGION,CREATED\n" +
+				"aws_s3_bucket,my-logs,,,,\n" +
+				"aws_s3_bucket,untagged,,,,\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			err := writeResourcesCsv(&buf, resources, nil, nil, options{nameTag: tc.nameTag})
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.want, buf.String())
+		})
+	}
+}
+
+func TestWriteIDs(t *testing.T) {
+	resources := []aws.Resource{
+		{Type: "aws_instance", ID: "i-123"},
+		{Type: "aws_instance", ID: "i-456"},
+	}
+
+	tests := []struct {
+		name        string
+		idsWithType bool
+		want        string
+	}{
+		{
+			name: "ids only",
+			want: "i-123\ni-456\n",
+		},
+		{
+			name:        "ids prefixed with type",
+			idsWithType: true,
+			want:        "aws_instance i-123\naws_instance i-456\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			writeIDs(&buf, resources, options{idsWithType: tt.idsWithType})
+
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestWritesToStdout(t *testing.T) {
+	tests := []struct {
+		name string
+		opts options
+		want bool
+	}{
+		{name: "csv", opts: options{output: "csv"}, want: false},
+		{name: "json", opts: options{output: "json"}, want: false},
+		{name: "csv to stdout", opts: options{output: "csv", stdout: true}, want: true},
+		{name: "ids", opts: options{output: "ids"}, want: true},
+		{name: "count", opts: options{output: "csv", counts: &countTable{}}, want: true},
+		{name: "protobuf", opts: options{output: "protobuf"}, want: true},
+		{name: "ndjson", opts: options{output: "ndjson"}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, writesToStdout(tt.opts))
+		})
+	}
+}
+
+func TestWriteResourcesParquet(t *testing.T) {
+	createdAt := time.Date(2020, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	resources := []aws.Resource{
+		{
+			Type:      "aws_instance",
+			ID:        "i-123",
+			AccountID: "123456789012",
+			Region:    "us-west-2",
+			CreatedAt: &createdAt,
+			Tags:      map[string]string{"Name": "foo"},
+		},
+		{
+			Type: "aws_instance",
+			ID:   "i-456",
+		},
+	}
+
+	var buf bytes.Buffer
+
+	err := writeResourcesParquet(&buf, resources, nil, []string{"tags", "instance_type"},
+		options{nameTag: "Name"})
+	require.NoError(t, err)
+
+	pf, err := buffer.NewBufferFile(buf.Bytes())
+	require.NoError(t, err)
+
+	pr, err := reader.NewParquetReader(pf, nil, 1)
+	require.NoError(t, err)
+	defer pr.ReadStop()
+
+	assert.Equal(t, int64(len(resources)), pr.GetNumRows())
+
+	var columns []string
+	for i := 1; i < len(pr.SchemaHandler.Infos); i++ {
+		columns = append(columns, pr.SchemaHandler.GetExName(i))
+	}
+	assert.Equal(t, []string{"TYPE", "ID", "CREATED", "ACCOUNT_ID", "PROFILE", "REGION", "NAME",
+		"tags", "instance_type"}, columns)
+}
+
+func TestExecHook(t *testing.T) {
+	tests := []struct {
+		name       string
+		command    string
+		wantErr    bool
+		wantRunErr bool
+	}{
+		{
+			name:    "command succeeds",
+			command: "true {{.Type}} {{.ID}}",
+		},
+		{
+			name:       "command fails",
+			command:    "false {{.ID}}",
+			wantRunErr: true,
+		},
+		{
+			name:    "empty command",
+			command: " ",
+			wantErr: true,
+		},
+		{
+			name:    "invalid template",
+			command: "echo {{.ID",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hook, err := execHook(tt.command)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			err = hook(aws.Resource{Type: "aws_instance", ID: "i-123"})
+			if tt.wantRunErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateCsvFiles(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		wantRows int
+		wantErr  bool
+	}{
+		{
+			name:     "valid file",
+			content:  "TYPE,ID\naws_instance,i-123\naws_instance,i-456\n",
+			wantRows: 2,
+		},
+		{
+			name:     "missing row",
+			content:  "TYPE,ID\naws_instance,i-123\n",
+			wantRows: 2,
+			wantErr:  true,
+		},
+		{
+			name:     "truncated row",
+			content:  "TYPE,ID\naws_instance,i-123\naws_inst",
+			wantRows: 2,
+			wantErr:  true,
+		},
+		{
+			name:     "empty file",
+			wantRows: 0,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := ioutil.TempFile("", "awsls-*.csv")
+			require.NoError(t, err)
+			defer os.Remove(f.Name())
+
+			_, err = f.WriteString(tt.content)
+			require.NoError(t, err)
+			require.NoError(t, f.Close())
+
+			err = validateCsvFiles([]string{f.Name()}, tt.wantRows, ',')
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateJSONFile(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		group         string
+		wantResources int
+		wantErr       bool
+	}{
+		{
+			name: "resources grouped by account and region",
+			content: `{"123456789012": {"us-east-1": [{"id": "i-123"}, {"id": "i-456"}], "eu-west-1": [{"id": "i-789"}]},
+				"210987654321": {"us-east-1": [{"id": "i-abc"}]}}`,
+			group:         jsonGroupByAccount,
+			wantResources: 4,
+		},
+		{
+			name:          "resources not grouped as expected",
+			content:       `[{"type": "aws_instance", "id": "i-123"}]`,
+			group:         jsonGroupByAccount,
+			wantResources: 1,
+			wantErr:       true,
+		},
+		{
+			name:          "array of resources",
+			content:       `[{"type": "aws_instance", "id": "i-123"}, {"type": "aws_instance", "id": "i-456"}]`,
+			wantResources: 2,
+		},
+		{
+			name:          "resources by key",
+			content:       `{"i-123": {"type": "aws_instance"}, "i-456": {"type": "aws_instance"}}`,
+			wantResources: 2,
+		},
+		{
+			name:          "no resources",
+			content:       `[]`,
+			wantResources: 0,
+		},
+		{
+			name:          "missing resource",
+			content:       `[{"type": "aws_instance", "id": "i-123"}]`,
+			wantResources: 2,
+			wantErr:       true,
+		},
+		{
+			name:          "truncated file",
+			content:       `[{"type": "aws_instance", "id": "i-123"}, {"type": "aws_inst`,
+			wantResources: 2,
+			wantErr:       true,
+		},
+		{
+			name:          "empty file",
+			wantResources: 0,
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := ioutil.TempFile("", "awsls-*.json")
+			require.NoError(t, err)
+			defer os.Remove(f.Name())
+
+			_, err = f.WriteString(tt.content)
+			require.NoError(t, err)
+			require.NoError(t, f.Close())
+
+			err = validateJSONFile(f.Name(), tt.wantResources, tt.group)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestPrintResourcesCsv_outDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "awsls")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	outDir := filepath.Join(dir, "nested", "out")
+
+	files, rows, err := printResourcesCsv(context.Background(), "aws_instance",
+		[]aws.Resource{{Type: "aws_instance", ID: "i-123"}}, nil, nil, options{outDir: outDir})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{filepath.Join(outDir, "aws_instance.csv")}, files)
+	assert.Equal(t, 1, rows)
+
+	info, err := os.Stat(outDir)
+	require.NoError(t, err)
+	assert.True(t, info.IsDir())
+
+	// a file where the directory should be
+	_, _, err = printResourcesCsv(context.Background(), "aws_instance", nil, nil, nil, options{outDir: files[0]})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to create output directory")
+}
+
+func TestPrintResources_trailingNewline(t *testing.T) {
+	resources := []aws.Resource{{Type: "aws_instance", ID: "i-123"}, {Type: "aws_instance", ID: "i-456"}}
+
+	tests := []struct {
+		name      string
+		resources []aws.Resource
+		// print writes the resources into the output directory and returns the path of the written file
+		print func(resources []aws.Resource, opts options) (string, error)
+		want  string
+	}{
+		{
+			name:      "csv",
+			resources: resources,
+			print: func(resources []aws.Resource, opts options) (string, error) {
+				files, _, err := printResourcesCsv(context.Background(), "aws_instance", resources, nil, nil, opts)
+				if err != nil {
+					return "", err
+				}
+
+				return files[0], nil
+			},
+			want: "TYPE,ID,ACCOUNT_ID,PROFILE,REGION,CREATED\n" +
+				"aws_instance,i-123,,,,\n" +
+				"aws_instance,i-456,,,,\n",
+		},
+		{
+			name: "csv without resources",
+			print: func(resources []aws.Resource, opts options) (string, error) {
+				files, _, err := printResourcesCsv(context.Background(), "aws_instance", resources, nil, nil, opts)
+				if err != nil {
+					return "", err
+				}
+
+				return files[0], nil
+			},
+			want: "TYPE,ID,ACCOUNT_ID,PROFILE,REGION,CREATED\n",
+		},
+		{
+			name:      "json",
+			resources: resources,
+			print: func(resources []aws.Resource, opts options) (string, error) {
+				return printResourcesJSON("aws_instance", resources, nil, nil, opts)
+			},
+		},
+		{
+			name:      "json-map",
+			resources: resources,
+			print: func(resources []aws.Resource, opts options) (string, error) {
+				return printResourcesJSONMap("aws_instance", resources, nil, nil, opts)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "awsls")
+			require.NoError(t, err)
+			defer os.RemoveAll(dir)
+
+			path, err := tt.print(tt.resources, options{outDir: dir, jsonKey: "id"})
+			require.NoError(t, err)
+
+			content, err := ioutil.ReadFile(path)
+			require.NoError(t, err)
+
+			assert.True(t, strings.HasSuffix(string(content), "\n"), "file must end with a newline")
+
+			if tt.want != "" {
+				assert.Equal(t, tt.want, string(content))
+			}
+		})
+	}
+}
+
+func TestWriteResourcesJSON(t *testing.T) {
+	createdAt := time.Date(2020, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	resources := []aws.Resource{
+		{Type: "aws_iam_role", ID: "foo", CreatedAt: &createdAt, Tags: map[string]string{"Team": "a"}},
+		{Type: "aws_iam_role", ID: "bar"},
+	}
+
+	var buf bytes.Buffer
+
+	err := writeResourcesJSON(&buf, resources, nil, []string{"tags"}, options{headerCase: "as-is"})
+	require.NoError(t, err)
+
+	assert.Equal(t, `[
+  {
+    "type": "aws_iam_role",
+    "id": "foo",
+    "account_id": "",
+    "profile": "",
+    "region": "",
+    "created": "2020-07-01T12:00:00Z",
+    "tags": "{\"Team\":\"a\"}"
+  },
+  {
+    "type": "aws_iam_role",
+    "id": "bar",
+    "account_id": "",
+    "profile": "",
+    "region": "",
+    "created": null,
+    "tags": null
+  }
+]
+`, buf.String())
+}
+
+func TestWriteResourcesJSON_groupByAccount(t *testing.T) {
+	resources := []aws.Resource{
+		{Type: "aws_iam_role", ID: "foo", AccountID: "123456789012", Region: "us-east-1"},
+		{Type: "aws_iam_role", ID: "bar", AccountID: "210987654321", Region: "us-east-1"},
+		{Type: "aws_iam_role", ID: "baz", AccountID: "123456789012", Region: "eu-west-1"},
+		{Type: "aws_iam_role", ID: "qux", AccountID: "123456789012", Region: "us-east-1"},
+	}
+
+	var buf bytes.Buffer
+
+	err := writeResourcesJSON(&buf, resources, nil, nil, options{group: jsonGroupByAccount})
+	require.NoError(t, err)
+
+	var got map[string]map[string][]struct {
+		ID string `json:"id"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+
+	ids := map[string]map[string][]string{}
+	for account, byRegion := range got {
+		ids[account] = map[string][]string{}
+		for region, rs := range byRegion {
+			for _, r := range rs {
+				ids[account][region] = append(ids[account][region], r.ID)
+			}
+		}
+	}
+
+	assert.Equal(t, map[string]map[string][]string{
+		"123456789012": {"us-east-1": {"foo", "qux"}, "eu-west-1": {"baz"}},
+		"210987654321": {"us-east-1": {"bar"}},
+	}, ids)
+}
+
+func TestWriteResourceNDJSON(t *testing.T) {
+	createdAt := time.Date(2020, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	resources := []aws.Resource{
+		{Type: "aws_iam_role", ID: "foo", Profile: "prod", Region: "us-east-1", CreatedAt: &createdAt,
+			Tags: map[string]string{"Team": "a"}},
+		{Type: "aws_iam_role", ID: "bar"},
+	}
+
+	var buf bytes.Buffer
+
+	for i := range resources {
+		err := writeResourceNDJSON(&buf, &resources[i], nil, []string{"tags"}, "as-is")
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, `{"type":"aws_iam_role","id":"foo","account_id":"","profile":"prod","region":"us-east-1",`+
+		`"created":"2020-07-01T12:00:00Z","tags":"{\"Team\":\"a\"}"}`+"\n"+
+		`{"type":"aws_iam_role","id":"bar","account_id":"","profile":"","region":"","created":null,`+
+		`"tags":null}`+"\n", buf.String())
+}
+
+func TestJSONResource_MarshalJSON(t *testing.T) {
+	arn := "arn:aws:iam::123456789012:role/foo"
+
+	r := jsonResource{
+		Type:       "aws_iam_role",
+		ID:         "foo",
+		Attributes: map[string]interface{}{"arn": arn, "id": arn, "tags": nil},
+		attributes: []string{"arn", "id", "tags"},
+	}
+
+	got, err := json.Marshal(r)
+	require.NoError(t, err)
+
+	// the id attribute doesn't replace the id of the resource
+	assert.Equal(t, `{"type":"aws_iam_role","id":"foo","account_id":"","profile":"","region":"","created":null,`+
+		`"arn":"arn:aws:iam::123456789012:role/foo","tags":null}`, string(got))
+}
+
+func TestNewJSONResource_attributeValues(t *testing.T) {
+	state := cty.ObjectVal(map[string]cty.Value{
+		"id":         cty.StringVal("vol-123"),
+		"kms_key_id": cty.StringVal(""),
+		// not an integer, so it can't be read
+		"size": cty.NumberFloatVal(1.5),
+	})
+
+	r := aws.Resource{
+		Type:              "aws_ebs_volume",
+		ID:                "vol-123",
+		UpdatableResource: terradozerRes.NewWithState("aws_ebs_volume", "vol-123", nil, &state),
+	}
+
+	hasAttrs := map[string]bool{"kms_key_id": true, "size": true}
+
+	got, err := json.Marshal(newJSONResource(&r, hasAttrs, []string{"kms_key_id", "iops", "size"}, "as-is"))
+	require.NoError(t, err)
+
+	var values map[string]interface{}
+	require.NoError(t, json.Unmarshal(got, &values))
+
+	// empty, not supported by the type, and failed to read
+	assert.Equal(t, "", values["kms_key_id"])
+	assert.Contains(t, values, "iops")
+	assert.Nil(t, values["iops"])
+	require.IsType(t, map[string]interface{}{}, values["size"])
+	assert.NotEmpty(t, values["size"].(map[string]interface{})["error"])
+}
+
+func TestWriteResourcesJSONMap(t *testing.T) {
+	createdAt := time.Date(2020, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		resources      []aws.Resource
+		onDuplicateKey string
+		want           string
+		wantErr        string
+	}{
+		{
+			name: "keyed by id",
+			resources: []aws.Resource{
+				{Type: "aws_iam_role", ID: "foo", CreatedAt: &createdAt, Tags: map[string]string{"Team": "a"}},
+				{Type: "aws_iam_role", ID: "bar"},
+			},
+			want: `{
+  "bar": {
+    "type": "aws_iam_role",
+    "id": "bar",
+    "account_id": "",
+    "profile": "",
+    "region": "",
+    "created": null,
+    "tags": null
+  },
+  "foo": {
+    "type": "aws_iam_role",
+    "id": "foo",
+    "account_id": "",
+    "profile": "",
+    "region": "",
+    "created": "2020-07-01T12:00:00Z",
+    "tags": "{\"Team\":\"a\"}"
+  }
+}
+`,
+		},
+		{
+			name: "duplicate key is an error",
+			resources: []aws.Resource{
+				{Type: "aws_iam_role", ID: "foo"},
+				{Type: "aws_iam_role", ID: "foo"},
+			},
+			onDuplicateKey: "error",
+			wantErr:        "duplicate key: foo",
+		},
+		{
+			name: "duplicate key gets suffix",
+			resources: []aws.Resource{
+				{Type: "aws_iam_role", ID: "foo"},
+				{Type: "aws_iam_role", ID: "foo"},
+			},
+			onDuplicateKey: "suffix",
+			want: `{
+  "foo": {
+    "type": "aws_iam_role",
+    "id": "foo",
+    "account_id": "",
+    "profile": "",
+    "region": "",
+    "created": null,
+    "tags": null
+  },
+  "foo#2": {
+    "type": "aws_iam_role",
+    "id": "foo",
+    "account_id": "",
+    "profile": "",
+    "region": "",
+    "created": null,
+    "tags": null
+  }
+}
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			err := writeResourcesJSONMap(&buf, tt.resources, nil, []string{"tags"},
+				options{jsonKey: "id", onDuplicateKey: tt.onDuplicateKey})
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestWriteChargebackCsv(t *testing.T) {
+	resources := []aws.Resource{
+		{Type: "aws_instance", ID: "i-1", Tags: map[string]string{"Team": "web"}},
+		{Type: "aws_instance", ID: "i-2", Tags: map[string]string{"Team": "data"}},
+		{Type: "aws_instance", ID: "i-3", Tags: map[string]string{"Team": "web"}},
+		{Type: "aws_instance", ID: "i-4"},
+	}
+
+	var buf bytes.Buffer
+
+	err := writeChargebackCsv(&buf, countByOwner("aws_instance", resources, "Team"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "TYPE,OWNER,COUNT\n"+
+		"aws_instance,data,1\n"+
+		"aws_instance,unassigned,1\n"+
+		"aws_instance,web,2\n", buf.String())
+}
+
+func TestCountTable(t *testing.T) {
+	var counts countTable
+
+	counts.add("aws_instance", []aws.Resource{
+		{Type: "aws_instance", ID: "i-1", Profile: "prod", Region: "us-west-2"},
+		{Type: "aws_instance", ID: "i-2", Profile: "dev", Region: "us-east-1"},
+		{Type: "aws_instance", ID: "i-3", Profile: "prod", Region: "us-west-2"},
+		{Type: "aws_instance", ID: "i-4", Profile: "prod", Region: "eu-west-1"},
+	})
+	counts.add("aws_vpc", []aws.Resource{
+		{Type: "aws_vpc", ID: "vpc-1", Profile: "dev", Region: "us-east-1"},
+	})
+
+	var buf bytes.Buffer
+
+	err := counts.write(&buf)
+	require.NoError(t, err)
+
+	assert.Equal(t, "TYPE          PROFILE  REGION     COUNT\n"+
+		"aws_instance  dev      us-east-1  1\n"+
+		"aws_instance  prod     eu-west-1  1\n"+
+		"aws_instance  prod     us-west-2  2\n"+
+		"aws_vpc       dev      us-east-1  1\n", buf.String())
+}
+
+func TestRunSummary(t *testing.T) {
+	tests := []struct {
+		name       string
+		resources  map[string][]aws.Resource
+		clientKeys []util.AWSClientKey
+		want       string
+	}{
+		{
+			name: "multiple regions and profiles",
+			resources: map[string][]aws.Resource{
+				"aws_instance": {
+					{Type: "aws_instance", ID: "i-1", Profile: "prod", Region: "us-west-2"},
+					{Type: "aws_instance", ID: "i-2", Profile: "dev", Region: "us-east-1"},
+				},
+				"aws_vpc": {
+					{Type: "aws_vpc", ID: "vpc-1", Profile: "dev", Region: "us-east-1"},
+				},
+			},
+			clientKeys: []util.AWSClientKey{
+				{Profile: "dev", Region: "us-east-1"},
+				{Profile: "dev", Region: "us-west-2"},
+				{Profile: "prod", Region: "us-east-1"},
+				{Profile: "prod", Region: "us-west-2"},
+			},
+			want: "found 3 resources across 2 regions, 2 profiles (aws_instance: 2, aws_vpc: 1)\n" +
+				"by region: us-east-1: 2, us-west-2: 1\n",
+		},
+		{
+			name: "single region",
+			resources: map[string][]aws.Resource{
+				"aws_vpc": {
+					{Type: "aws_vpc", ID: "vpc-1", Region: "us-east-1"},
+				},
+			},
+			clientKeys: []util.AWSClientKey{{Region: "us-east-1"}},
+			want:       "found 1 resource across 1 region, 1 profile (aws_vpc: 1)\n",
+		},
+		{
+			name:       "no resources",
+			clientKeys: []util.AWSClientKey{{Region: "us-east-1"}, {Region: "us-west-2"}},
+			want:       "found 0 resources across 2 regions, 1 profile\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			summary := newRunSummary()
+			for rType, resources := range tc.resources {
+				summary.add(rType, resources)
+			}
+
+			var buf bytes.Buffer
+
+			err := summary.write(&buf, tc.clientKeys)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.want, buf.String())
+		})
+	}
+}
+
+func TestWriteDryRun(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := writeDryRun(&buf, []string{"aws_instance", "aws_vpc"}, []util.AWSClientKey{
+		{Profile: "prod", Region: "us-west-2"},
+		{Profile: "dev", Region: "us-east-1"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "TYPE          PROFILE  REGION\n"+
+		"aws_instance  dev      us-east-1\n"+
+		"aws_instance  prod     us-west-2\n"+
+		"aws_vpc       dev      us-east-1\n"+
+		"aws_vpc       prod     us-west-2\n"+
+		"\nqueries in total: 4 (2 types x 2 profile/region combinations)\n", buf.String())
+}
+
+func TestPlannedTypes(t *testing.T) {
+	got, err := plannedTypes([]string{"aws_instance", "aws_vpc", "aws_vpc_endpoint"},
+		options{excludeTypes: []string{"aws_vpc_*"}})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"aws_instance", "aws_vpc"}, got)
+}
+
+func TestPrintUnmatchedPatterns(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "all patterns match",
+			patterns: []string{"aws_instance", "aws_vpc*"},
+			want:     "[]\n",
+		},
+		{
+			name:     "some patterns match nothing",
+			patterns: []string{"aws_instance", "aws_foo*", "aws_bar"},
+			want:     "[\"aws_foo*\",\"aws_bar\"]\n",
+		},
+		{
+			name:     "invalid pattern",
+			patterns: []string{"aws_["},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			err := printUnmatchedPatterns(&buf, tt.patterns)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestWriteResourcesCsv_explode(t *testing.T) {
+	withGroups := cty.ObjectVal(map[string]cty.Value{
+		"security_groups": cty.SetVal([]cty.Value{cty.StringVal("sg-1"), cty.StringVal("sg-2")}),
+	})
+	withoutGroups := cty.ObjectVal(map[string]cty.Value{
+		"security_groups": cty.SetValEmpty(cty.String),
+	})
+
+	resources := []aws.Resource{
+		{
+			Type:              "aws_instance",
+			ID:                "i-123",
+			UpdatableResource: terradozerRes.NewWithState("aws_instance", "i-123", nil, &withGroups),
+		},
+		{
+			Type:              "aws_instance",
+			ID:                "i-456",
+			UpdatableResource: terradozerRes.NewWithState("aws_instance", "i-456", nil, &withoutGroups),
+		},
+	}
+
+	var buf bytes.Buffer
+
+	err := writeResourcesCsv(&buf, resources, map[string]bool{"security_groups": true}, []string{"security_groups"},
+		options{nullValue: "N/A", explode: "security_groups"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "TYPE,ID,ACCOUNT_ID,PROFILE,REGION,CREATED,security_groups\n"+
+		"aws_instance,i-123,,,,,sg-1\n"+
+		"aws_instance,i-123,,,,,sg-2\n"+
+		"aws_instance,i-456,,,,,\n", buf.String())
+}
+
+func TestWriteResourcesCsv_attributePath(t *testing.T) {
+	withDevice := cty.ObjectVal(map[string]cty.Value{
+		"root_block_device": cty.ListVal([]cty.Value{
+			cty.ObjectVal(map[string]cty.Value{"volume_size": cty.NumberIntVal(8)}),
+		}),
+	})
+	withoutDevice := cty.ObjectVal(map[string]cty.Value{
+		"root_block_device": cty.ListValEmpty(cty.Object(map[string]cty.Type{"volume_size": cty.Number})),
+	})
+
+	resources := []aws.Resource{
+		{
+			Type:              "aws_instance",
+			ID:                "i-123",
+			UpdatableResource: terradozerRes.NewWithState("aws_instance", "i-123", nil, &withDevice),
+		},
+		{
+			Type:              "aws_instance",
+			ID:                "i-456",
+			UpdatableResource: terradozerRes.NewWithState("aws_instance", "i-456", nil, &withoutDevice),
+		},
+	}
+
+	var buf bytes.Buffer
+
+	err := writeResourcesCsv(&buf, resources, map[string]bool{"root_block_device.0.volume_size": true},
+		[]string{"root_block_device.0.volume_size"}, options{nullValue: "N/A"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "TYPE,ID,ACCOUNT_ID,PROFILE,REGION,CREATED,root_block_device.0.volume_size\n"+
+		"aws_instance,i-123,,,,,8\n"+
+		"aws_instance,i-456,,,,,N/A\n", buf.String())
+}
+
+func TestWriteResourcesCsv_expandTags(t *testing.T) {
+	resources := []aws.Resource{
+		{Type: "aws_instance", ID: "i-123", Tags: map[string]string{"Environment": "prod", "Owner": "alice"}},
+		{Type: "aws_instance", ID: "i-456", Tags: map[string]string{"Environment": "dev", "Team": "a,b"}},
+		{Type: "aws_instance", ID: "i-789"},
+	}
+
+	var buf bytes.Buffer
+
+	err := writeResourcesCsv(&buf, resources, nil, []string{"instance_type", "tags"},
+		options{nullValue: "N/A", expandTags: true})
+	require.NoError(t, err)
+
+	assert.Equal(t, "TYPE,ID,ACCOUNT_ID,PROFILE,REGION,CREATED,instance_type,tag:Environment,tag:Owner,tag:Team\n"+
+		"aws_instance,i-123,,,,,N/A,prod,alice,\n"+
+		"aws_instance,i-456,,,,,N/A,dev,,\"a,b\"\n"+
+		"aws_instance,i-789,,,,,N/A,,,\n", buf.String())
+}
+
+func TestWriteResourcesCsv_flagOlderThan(t *testing.T) {
+	old := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	recent := time.Now().UTC().Add(-time.Hour)
+
+	resources := []aws.Resource{
+		{Type: "aws_instance", ID: "i-old", CreatedAt: &old},
+		{Type: "aws_instance", ID: "i-recent", CreatedAt: &recent},
+		{Type: "aws_instance", ID: "i-unknown"},
+	}
+
+	var buf bytes.Buffer
+
+	err := writeResourcesCsv(&buf, resources, nil, nil,
+		options{flagOlderThan: internal.DurationFlag(365 * 24 * time.Hour)})
+	require.NoError(t, err)
+
+	assert.Equal(t, "TYPE,ID,ACCOUNT_ID,PROFILE,REGION,CREATED,AGE_FLAG\n"+
+		"aws_instance,i-old,,,,2000-01-01 00:00:00,OLD\n"+
+		"aws_instance,i-recent,,,,"+recent.Format("2006-01-02 15:04:05")+",\n"+
+		"aws_instance,i-unknown,,,,,\n", buf.String())
+}
+
+func TestWriteResourcesCsv_warnOnStaleState(t *testing.T) {
+	resources := []aws.Resource{
+		{Type: "aws_instance", ID: "i-refreshed"},
+		{Type: "aws_instance", ID: "i-stale", StateStale: true},
+	}
+
+	var buf bytes.Buffer
+
+	err := writeResourcesCsv(&buf, resources, nil, nil, options{warnOnStaleState: true})
+	require.NoError(t, err)
+
+	assert.Equal(t, "TYPE,ID,ACCOUNT_ID,PROFILE,REGION,CREATED,STATE_STALE\n"+
+		"aws_instance,i-refreshed,,,,,false\n"+
+		"aws_instance,i-stale,,,,,true\n", buf.String())
+}
+
+func TestSampleRegions(t *testing.T) {
+	regions := []string{"us-east-1", "us-west-2", "eu-west-1", "eu-central-1", "ap-southeast-1"}
+
+	actual := sampleRegions(regions, 2, 42)
+
+	assert.Len(t, actual, 2)
+	assert.Subset(t, regions, actual)
+	assert.Equal(t, actual, sampleRegions(regions, 2, 42), "same seed must pick the same regions")
+
+	assert.Equal(t, regions, sampleRegions(regions, 10, 42))
+}
+
+func TestWriteResourcesProtobuf(t *testing.T) {
+	createdAt := time.Date(2020, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	resources := []aws.Resource{
+		{Type: "aws_iam_role", ID: "foo", Profile: "dev", Region: "us-west-2", AccountID: "123456789012",
+			CreatedAt: &createdAt, Tags: map[string]string{"Team": "a"}},
+		{Type: "aws_iam_role", ID: "bar"},
+	}
+
+	var buf bytes.Buffer
+
+	err := writeResourcesProtobuf(&buf, resources, nil, []string{"tags"})
+	require.NoError(t, err)
+
+	var got []*pb.Resource
+
+	data := buf.Bytes()
+	for len(data) > 0 {
+		size, n := proto.DecodeVarint(data)
+		require.NotZero(t, n)
+
+		var msg pb.Resource
+		require.NoError(t, proto.Unmarshal(data[n:n+int(size)], &msg))
+		got = append(got, &msg)
+
+		data = data[n+int(size):]
+	}
+
+	require.Len(t, got, 2)
+
+	assert.Equal(t, "aws_iam_role", got[0].Type)
+	assert.Equal(t, "foo", got[0].Id)
+	assert.Equal(t, "dev", got[0].Profile)
+	assert.Equal(t, "us-west-2", got[0].Region)
+	assert.Equal(t, "123456789012", got[0].AccountId)
+	assert.Equal(t, createdAt.Unix(), got[0].Created.Seconds)
+	assert.Equal(t, map[string]string{"tags": `{"Team":"a"}`}, got[0].Attributes)
+
+	assert.Equal(t, "bar", got[1].Id)
+	assert.Nil(t, got[1].Created)
+	assert.Empty(t, got[1].Attributes)
+}
+
+func TestPrintSupportDiff(t *testing.T) {
+	support := []attributeSupport{
+		{
+			"aws_instance": {"tags": true},
+			"aws_vpc":      {"tags": true},
+		},
+		{
+			"aws_instance": {"tags": true, "instance_state": true},
+			"aws_vpc":      {"tags": true},
+			"aws_lb":       {"tags": true},
+		},
+	}
+
+	var buf bytes.Buffer
+
+	printSupportDiff(&buf, []string{"2.68.0", "3.0.0"}, support,
+		[]string{"aws_instance", "aws_lb", "aws_vpc"}, []string{"instance_state", "tags"})
+
+	assert.Equal(t, "TYPE          ATTRIBUTE       2.68.0  3.0.0\n"+
+		"aws_instance  instance_state  no      yes\n"+
+		"aws_lb        -               no      yes\n"+
+		"\n2 difference(s) in support between provider versions 2.68.0, 3.0.0\n", buf.String())
+}
+
+func TestHeaderCase(t *testing.T) {
+	resources := []aws.Resource{
+		{Type: "aws_iam_role", ID: "foo", Tags: map[string]string{"Team": "a"}},
+	}
+
+	tests := []struct {
+		headerCase string
+		wantCsv    string
+		wantJSON   string
+	}{
+		{
+			headerCase: "as-is",
+			wantCsv:    "TYPE,ID,ACCOUNT_ID,PROFILE,REGION,CREATED,tags\n",
+			wantJSON: `[{"type":"aws_iam_role","id":"foo","account_id":"",` +
+				`"profile":"","region":"","created":null,"tags":"{\"Team\":\"a\"}"}]`,
+		},
+		{
+			headerCase: "upper",
+			wantCsv:    "TYPE,ID,ACCOUNT_ID,PROFILE,REGION,CREATED,TAGS\n",
+			wantJSON: `[{"TYPE":"aws_iam_role","ID":"foo","ACCOUNT_ID":"",` +
+				`"PROFILE":"","REGION":"","CREATED":null,"TAGS":"{\"Team\":\"a\"}"}]`,
+		},
+		{
+			headerCase: "lower",
+			wantCsv:    "type,id,account_id,profile,region,created,tags\n",
+			wantJSON: `[{"type":"aws_iam_role","id":"foo","account_id":"",` +
+				`"profile":"","region":"","created":null,"tags":"{\"Team\":\"a\"}"}]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.headerCase, func(t *testing.T) {
+			var csvBuf bytes.Buffer
+
+			err := writeResourcesCsv(&csvBuf, resources, nil, []string{"tags"}, options{headerCase: tt.headerCase})
+			require.NoError(t, err)
+			assert.True(t, strings.HasPrefix(csvBuf.String(), tt.wantCsv))
+
+			var jsonBuf bytes.Buffer
+
+			err = writeResourcesJSON(&jsonBuf, resources, nil, []string{"tags"}, options{headerCase: tt.headerCase})
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.wantJSON, jsonBuf.String())
+		})
+	}
+}
+
+func TestIsSemver(t *testing.T) {
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{"2.68.0", true},
+		{"3.0.0-beta1", true},
+		{"v2.68.0", false},
+		{"2.68", false},
+		{"latest", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			assert.Equal(t, tt.want, isSemver(tt.version))
+		})
+	}
+}
+
+func TestFindDuplicates(t *testing.T) {
+	resources := []aws.Resource{
+		{Type: "aws_instance", ID: "i-1", AccountID: "111", Profile: "dev", Region: "us-east-1",
+			Tags: map[string]string{"Name": "web"}},
+		{Type: "aws_instance", ID: "i-2", AccountID: "222", Profile: "prod", Region: "eu-west-1",
+			Tags: map[string]string{"Name": "web"}},
+		{Type: "aws_instance", ID: "i-3", AccountID: "111", Profile: "dev", Region: "us-east-1",
+			Tags: map[string]string{"Name": "db"}},
+		{Type: "aws_instance", ID: "i-4", AccountID: "111", Profile: "dev", Region: "us-east-1"},
+		{Type: "aws_instance", ID: "i-5", AccountID: "222", Profile: "prod", Region: "eu-west-1"},
+	}
+
+	groups := findDuplicates("aws_instance", resources, nil, "", "Name")
+
+	var buf bytes.Buffer
+
+	err := writeDuplicatesCsv(&buf, groups)
+	require.NoError(t, err)
+
+	assert.Equal(t, "TYPE,KEY,ACCOUNT_ID,PROFILE,REGION,ID\n"+
+		"aws_instance,web,111,dev,us-east-1,i-1\n"+
+		"aws_instance,web,222,prod,eu-west-1,i-2\n", buf.String())
+}
+
+func TestLoadQuery(t *testing.T) {
+	f, err := ioutil.TempFile("", "query-*.yaml")
+	require.NoError(t, err)
+	defer os.Remove(f.Name())
+
+	_, err = f.WriteString(`types: [aws_instance, "aws_iam_*"]
+attributes: [instance_type, tags]
+attribute_filters:
+  - types: [aws_instance]
+    attribute: instance_type
+    values: [t3.micro]
+tags: [Team=platform]
+exclude_tags: [Environment=dev]
+created_after: 2020-01-01
+created_before: 2021-01-01T00:00:00Z
+`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	q, err := loadQuery(f.Name())
+	require.NoError(t, err)
+
+	var opts options
+	require.NoError(t, q.apply(&opts))
+
+	assert.Equal(t, []string{"aws_instance", "aws_iam_*"}, q.Types)
+	assert.Equal(t, []string{"instance_type", "tags"}, q.Attributes)
+	assert.Equal(t, []awsls.AttributeFilter{
+		{Types: []string{"aws_instance"}, Attribute: "instance_type", Values: []string{"t3.micro"}},
+	}, opts.attributeFilters)
+	assert.Equal(t, []awsls.TagFilter{{Key: "Team", Value: "platform", HasValue: true}}, opts.tags)
+	assert.Equal(t, []awsls.TagFilter{{Key: "Environment", Value: "dev", HasValue: true}}, opts.excludeTags)
+	assert.Equal(t, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), opts.createdAfter)
+	assert.Equal(t, time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), opts.createdBefore)
+}
+
+func TestLoadQuery_unknownKey(t *testing.T) {
+	f, err := ioutil.TempFile("", "query-*.yaml")
+	require.NoError(t, err)
+	defer os.Remove(f.Name())
+
+	_, err = f.WriteString("typo: [aws_instance]\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	_, err = loadQuery(f.Name())
+	assert.Error(t, err)
+}
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		content string
+	}{
+		{
+			name:    "yaml",
+			pattern: "awsls-*.yaml",
+			content: `profiles: [dev, prod]
+regions: [us-east-1, eu-west-1]
+types: [aws_instance, "aws_iam_*"]
+attributes: [instance_type, tags]
+provider_version: 2.68.0
+`,
+		},
+		{
+			name:    "json",
+			pattern: "awsls-*.json",
+			content: `{"profiles": ["dev", "prod"], "regions": ["us-east-1", "eu-west-1"],
+"types": ["aws_instance", "aws_iam_*"], "attributes": ["instance_type", "tags"], "provider_version": "2.68.0"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := ioutil.TempFile("", tt.pattern)
+			require.NoError(t, err)
+			defer os.Remove(f.Name())
+
+			_, err = f.WriteString(tt.content)
+			require.NoError(t, err)
+			require.NoError(t, f.Close())
+
+			got, err := loadConfig(f.Name())
+			require.NoError(t, err)
+
+			assert.Equal(t, &config{
+				Profiles:        []string{"dev", "prod"},
+				Regions:         []string{"us-east-1", "eu-west-1"},
+				Types:           []string{"aws_instance", "aws_iam_*"},
+				Attributes:      []string{"instance_type", "tags"},
+				ProviderVersion: "2.68.0",
+			}, got)
+		})
+	}
+}
+
+func TestLoadConfig_unknownKey(t *testing.T) {
+	f, err := ioutil.TempFile("", "awsls-*.yaml")
+	require.NoError(t, err)
+	defer os.Remove(f.Name())
+
+	_, err = f.WriteString("profile: [dev]\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	_, err = loadConfig(f.Name())
+	assert.Error(t, err)
+}
+
+func TestCreatedWithinCutoff(t *testing.T) {
+	now := time.Date(2020, 6, 8, 12, 0, 0, 0, time.UTC)
+	weekAgo := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		after   time.Time
+		before  time.Time
+		want    time.Time
+		wantErr string
+	}{
+		{
+			name: "relative to now",
+			want: weekAgo,
+		},
+		{
+			name:   "with created-before",
+			before: time.Date(2020, 6, 5, 0, 0, 0, 0, time.UTC),
+			want:   weekAgo,
+		},
+		{
+			name:    "created-before earlier than cutoff",
+			before:  time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC),
+			wantErr: "must be later than 2020-06-01T12:00:00Z",
+		},
+		{
+			name:    "with created-after",
+			after:   time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC),
+			wantErr: "can't be combined with --created-after",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := createdWithinCutoff(7*24*time.Hour, tc.after, tc.before, now)
+			if tc.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestParseDelimiter(t *testing.T) {
+	tests := []struct {
+		arg     string
+		want    rune
+		wantErr bool
+	}{
+		{arg: ",", want: ','},
+		{arg: ";", want: ';'},
+		{arg: `\t`, want: '\t'},
+		{arg: "\t", want: '\t'},
+		{arg: "", wantErr: true},
+		{arg: ",,", wantErr: true},
+		{arg: `"`, wantErr: true},
+		{arg: "\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.arg, func(t *testing.T) {
+			got, err := parseDelimiter(tt.arg)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestWriteResourcesCsv_delimiter(t *testing.T) {
+	resources := []aws.Resource{
+		{Type: "aws_instance", ID: "i-1", Tags: map[string]string{"Name": "a,b"}},
+	}
+
+	var buf bytes.Buffer
+
+	err := writeResourcesCsv(&buf, resources, nil, nil, options{nameTag: "Name", delimiter: '\t'})
+	require.NoError(t, err)
+
+	assert.Equal(t, "TYPE\tID\tACCOUNT_ID\tPROFILE\tREGION\tCREATED\tNAME\n"+
+		"aws_instance\ti-1\t\t\t\t\ta,b\n", buf.String())
+}
+
+func TestWriteCombinedCsv(t *testing.T) {
+	state := cty.ObjectVal(map[string]cty.Value{"size": cty.NumberIntVal(8)})
+
+	c := &combinedOutput{}
+	c.add([]aws.Resource{{Type: "aws_instance", ID: "i-123", Tags: map[string]string{"Name": "foo"}}},
+		map[string]bool{}, []string{"instance_type", "tags"})
+	c.add([]aws.Resource{{Type: "aws_ebs_volume", ID: "vol-123",
+		UpdatableResource: terradozerRes.NewWithState("aws_ebs_volume", "vol-123", nil, &state)}},
+		map[string]bool{"size": true}, []string{"size"})
+
+	var buf bytes.Buffer
+
+	err := writeCombinedCsv(&buf, c, options{nullValue: "N/A"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "TYPE,ID,ACCOUNT_ID,PROFILE,REGION,CREATED,instance_type,tags,size\n"+
+		"aws_ebs_volume,vol-123,,,,,N/A,N/A,8\n"+
+		"aws_instance,i-123,,,,,N/A,\"{\"\"Name\"\":\"\"foo\"\"}\",N/A\n", buf.String())
+}
+
+func TestCombinedOutput_resources(t *testing.T) {
+	c := &combinedOutput{}
+	c.add([]aws.Resource{
+		{Type: "aws_vpc", ID: "vpc-2", AccountID: "111111111111", Profile: "a", Region: "us-west-2"},
+		{Type: "aws_vpc", ID: "vpc-1", AccountID: "222222222222", Profile: "b", Region: "us-east-1"},
+		{Type: "aws_vpc", ID: "vpc-3", AccountID: "111111111111", Profile: "a", Region: "us-east-1"},
+	}, nil, nil)
+	c.add([]aws.Resource{
+		{Type: "aws_instance", ID: "i-2", AccountID: "111111111111", Profile: "a", Region: "us-east-1"},
+		{Type: "aws_instance", ID: "i-1", AccountID: "111111111111", Profile: "a", Region: "us-east-1"},
+		{Type: "aws_instance", ID: "i-3", AccountID: "111111111111", Profile: "a", Region: "us-west-2"},
+	}, nil, nil)
+
+	var got []string
+	for _, r := range c.resources() {
+		got = append(got, strings.Join([]string{r.AccountID, r.Region, r.Type, r.ID}, " "))
+	}
+
+	assert.Equal(t, []string{
+		"111111111111 us-east-1 aws_instance i-1",
+		"111111111111 us-east-1 aws_instance i-2",
+		"111111111111 us-east-1 aws_vpc vpc-3",
+		"111111111111 us-west-2 aws_instance i-3",
+		"111111111111 us-west-2 aws_vpc vpc-2",
+		"222222222222 us-east-1 aws_vpc vpc-1",
+	}, got)
+}
+
+func TestWriteCombinedJSON(t *testing.T) {
+	c := &combinedOutput{}
+	c.add([]aws.Resource{{Type: "aws_instance", ID: "i-123"}}, nil, []string{"instance_type"})
+	c.add([]aws.Resource{{Type: "aws_vpc", ID: "vpc-123"}}, nil, []string{"cidr_block"})
+
+	var buf bytes.Buffer
+
+	err := writeCombinedJSON(&buf, c, options{headerCase: "as-is"})
+	require.NoError(t, err)
+
+	var got []map[string]interface{}
+	err = json.Unmarshal(buf.Bytes(), &got)
+	require.NoError(t, err)
+
+	require.Len(t, got, 2)
+	assert.Equal(t, "aws_instance", got[0]["type"])
+	assert.Equal(t, "aws_vpc", got[1]["type"])
+	assert.Equal(t, map[string]interface{}{"type": "aws_vpc", "id": "vpc-123", "account_id": "", "profile": "",
+		"region": "", "created": nil, "instance_type": nil, "cidr_block": nil}, got[1])
+}
+
+func TestLogLevel(t *testing.T) {
+	tests := []struct {
+		name    string
+		verbose int
+		debug   bool
+		quiet   bool
+		want    log.Level
+	}{
+		{name: "default", want: log.WarnLevel},
+		{name: "-v", verbose: 1, want: log.InfoLevel},
+		{name: "-vv", verbose: 2, want: log.DebugLevel},
+		{name: "-vvv", verbose: 3, want: log.DebugLevel},
+		{name: "--debug", debug: true, want: log.DebugLevel},
+		{name: "--quiet", quiet: true, want: log.ErrorLevel},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, logLevel(tc.verbose, tc.debug, tc.quiet))
+		})
+	}
+}
+
+func TestColorDisabled(t *testing.T) {
+	oldNoColor, ok := os.LookupEnv("NO_COLOR")
+	if ok {
+		defer os.Setenv("NO_COLOR", oldNoColor)
+	} else {
+		defer os.Unsetenv("NO_COLOR")
+	}
+
+	require.NoError(t, os.Unsetenv("NO_COLOR"))
+
+	assert.False(t, colorDisabled(false, true))
+	assert.True(t, colorDisabled(true, true))
+	assert.True(t, colorDisabled(false, false), "not a terminal")
+
+	require.NoError(t, os.Setenv("NO_COLOR", "1"))
+
+	assert.True(t, colorDisabled(false, true))
+}
+
+func TestSortResources(t *testing.T) {
+	older := time.Date(2020, 7, 1, 12, 0, 0, 0, time.UTC)
+	newer := time.Date(2020, 7, 2, 12, 0, 0, 0, time.UTC)
+
+	state := func(size int64) terradozerRes.UpdatableResource {
+		s := cty.ObjectVal(map[string]cty.Value{"size": cty.NumberIntVal(size)})
+		return terradozerRes.NewWithState("aws_ebs_volume", "", nil, &s)
+	}
+
+	tests := []struct {
+		name      string
+		by        string
+		resources []aws.Resource
+		want      []string
+	}{
+		{
+			name: "by id, ties by region",
+			by:   "id",
+			resources: []aws.Resource{
+				{ID: "b", Region: "us-west-2"},
+				{ID: "a", Region: "us-west-2"},
+				{ID: "a", Region: "eu-west-1"},
+			},
+			want: []string{"eu-west-1/a", "us-west-2/a", "us-west-2/b"},
+		},
+		{
+			name: "by region, ties by id",
+			by:   "region",
+			resources: []aws.Resource{
+				{ID: "b", Region: "us-west-2"},
+				{ID: "c", Region: "eu-west-1"},
+				{ID: "a", Region: "us-west-2"},
+			},
+			want: []string{"eu-west-1/c", "us-west-2/a", "us-west-2/b"},
+		},
+		{
+			name: "by created, unknown first",
+			by:   "created",
+			resources: []aws.Resource{
+				{ID: "a", CreatedAt: &newer},
+				{ID: "b", CreatedAt: &older},
+				{ID: "c"},
+			},
+			want: []string{"/c", "/b", "/a"},
+		},
+		{
+			name: "by attribute, compared numerically",
+			by:   "size",
+			resources: []aws.Resource{
+				{ID: "a", UpdatableResource: state(100)},
+				{ID: "b", UpdatableResource: state(8)},
+				{ID: "c", UpdatableResource: state(20)},
+			},
+			want: []string{"/b", "/c", "/a"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sortResources(tt.resources, tt.by, map[string]bool{"size": true})
+
+			var got []string
+			for _, r := range tt.resources {
+				got = append(got, r.Region+"/"+r.ID)
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	f, err := ioutil.TempFile("", "expected-*.csv")
+	require.NoError(t, err)
+	defer os.Remove(f.Name())
+
+	_, err = f.WriteString("TYPE,ID,ACCOUNT_ID,PROFILE,REGION,CREATED\n" +
+		"aws_instance,i-1,\n" +
+		"aws_instance,i-2,\n" +
+		"aws_vpc,vpc-1,\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	expected, err := loadExpected(f.Name(), "id")
+	require.NoError(t, err)
+
+	resources := []aws.Resource{
+		{Type: "aws_instance", ID: "i-1"},
+		{Type: "aws_instance", ID: "i-3"},
+	}
+
+	missing, extra := reconcile("aws_instance", expected, resources, nil, "id")
+	assert.Equal(t, []string{"i-2"}, missing)
+	assert.Equal(t, []string{"i-3"}, extra)
+
+	var buf bytes.Buffer
+
+	writeReconciliation(&buf, "aws_instance", missing, extra)
+	assert.Equal(t, "MISSING aws_instance i-2\nEXTRA   aws_instance i-3\n", buf.String())
+}
+
+func TestParseOptions(t *testing.T) {
+	opts, err := parseOptions([]string{"-p", "dev,prod", "-r", "us-east-1", "--output", "json",
+		"--tags", "env=prod", "--rate-limit", "2", "aws_vpc", "aws_iam_ro*"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "json", opts.output)
+	assert.Equal(t, defaultNameTag, opts.nameTag)
+	assert.Equal(t, []awsls.TagFilter{{Key: "env", Value: "prod", HasValue: true}}, opts.tags)
+	assert.NotNil(t, opts.rateLimiter)
+	assert.Equal(t, ',', opts.delimiter)
+	assert.Equal(t, []string{"dev", "prod"}, []string(opts.setup.profiles))
+	assert.Equal(t, []string{"us-east-1"}, []string(opts.setup.regions))
+	assert.Equal(t, []string{"2.68.0"}, []string(opts.setup.providerVersions))
+	assert.Equal(t, []string{"aws_vpc", "aws_iam_role"}, opts.setup.resourceTypes)
+}
+
+func TestParseOptions_noNameTag(t *testing.T) {
+	opts, err := parseOptions([]string{"--name-tag", "", "aws_vpc"})
+	require.NoError(t, err)
+
+	assert.Empty(t, opts.nameTag)
+	assert.False(t, printsNameColumn(opts))
+}
+
+func TestPrintsNameColumn(t *testing.T) {
+	tests := []struct {
+		name string
+		opts options
+		want bool
+	}{
+		{name: "csv", opts: options{output: "csv", nameTag: "Name"}, want: true},
+		{name: "parquet", opts: options{output: "parquet", nameTag: "Name"}, want: true},
+		{name: "omitted", opts: options{output: "csv"}, want: false},
+		{name: "json", opts: options{output: "json", nameTag: "Name"}, want: false},
+		{name: "ids", opts: options{output: "ids", nameTag: "Name"}, want: false},
+		{name: "count", opts: options{output: "csv", nameTag: "Name", counts: &countTable{}}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, printsNameColumn(tt.opts))
+		})
+	}
+}
+
+func TestParseOptions_invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "help",
+			args:    []string{"--help"},
+			wantErr: flag.ErrHelp.Error(),
+		},
+		{
+			name:    "unknown flag",
+			args:    []string{"--foo", "aws_vpc"},
+			wantErr: "unknown flag: --foo",
+		},
+		{
+			name:    "missing resource type",
+			args:    []string{"-p", "dev"},
+			wantErr: "missing argument: resource type glob pattern",
+		},
+		{
+			name:    "unknown output format",
+			args:    []string{"--output", "xml", "aws_vpc"},
+			wantErr: "unknown output format: xml",
+		},
+		{
+			name:    "invalid tag",
+			args:    []string{"--tags", "=prod", "aws_vpc"},
+			wantErr: "invalid --tags: missing tag key: =prod",
+		},
+		{
+			name:    "profiles and all profiles",
+			args:    []string{"-p", "dev", "--all-profiles", "aws_vpc"},
+			wantErr: "--profiles and --all-profiles flag cannot be used together",
+		},
+		{
+			name:    "dry run with all regions",
+			args:    []string{"--dry-run", "--all-regions", "aws_vpc"},
+			wantErr: "--dry-run can't be used together with --all-regions or --account-id",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseOptions(tt.args)
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestParseOptions_listTypes(t *testing.T) {
+	// the resource types are matched when listed, so they aren't required
+	opts, err := parseOptions([]string{"--list-types"})
+	require.NoError(t, err)
+
+	assert.True(t, opts.setup.listTypes)
+	assert.Nil(t, opts.setup.resourceTypePatterns)
+}
+
+func TestMatchTypes(t *testing.T) {
+	got, err := matchTypes([]string{"aws_vpc", "aws_vpc_endpoint*", "aws_vpc*"}, false)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"aws_vpc",
+		"aws_vpc_endpoint",
+		"aws_vpc_endpoint_connection_notification",
+		"aws_vpc_endpoint_service",
+		"aws_vpc_peering_connection",
+	}, got)
+
+	_, err = matchTypes([]string{"aws_["}, false)
+	assert.Error(t, err)
+}
+
+func TestMatchTypes_exact(t *testing.T) {
+	got, err := matchTypes([]string{"aws_vpc", "vpc", "aws_iam_role"}, true)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"aws_vpc", "aws_iam_role"}, got)
+
+	_, err = matchTypes([]string{"aws_vpc", "aws_vpc*"}, true)
+	assert.EqualError(t, err, "not a supported resource type: aws_vpc*")
+}
+
+func TestPrintSupportedTypes(t *testing.T) {
+	var buf bytes.Buffer
+
+	printSupportedTypes(&buf, []string{"aws_iam_role", "aws_vpc"}, false)
+	assert.Equal(t, "aws_iam_role\naws_vpc\n", buf.String())
+
+	buf.Reset()
+
+	printSupportedTypes(&buf, []string{"aws_iam_role", "aws_vpc"}, true)
+	assert.Equal(t, "aws_iam_role  iam:ListRoles\naws_vpc       ec2:DescribeVpcs\n", buf.String())
+}
+
+func TestParseS3OutDir(t *testing.T) {
+	tests := []struct {
+		outDir  string
+		want    *s3Output
+		wantErr bool
+	}{
+		{outDir: "aws-resources"},
+		{outDir: "s3://my-bucket", want: &s3Output{bucket: "my-bucket"}},
+		{outDir: "s3://my-bucket/", want: &s3Output{bucket: "my-bucket"}},
+		{outDir: "s3://my-bucket/inventory", want: &s3Output{bucket: "my-bucket", prefix: "inventory/"}},
+		{outDir: "s3://my-bucket/inventory/daily/", want: &s3Output{bucket: "my-bucket", prefix: "inventory/daily/"}},
+		{outDir: "s3://", wantErr: true},
+		{outDir: "s3:///inventory", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.outDir, func(t *testing.T) {
+			got, err := parseS3OutDir(tc.outDir)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestS3Output_upload(t *testing.T) {
+	uploaded := map[string]string{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		uploaded[r.Method+" "+r.URL.Path] = string(body)
+	}))
+	defer server.Close()
+
+	clients, err := util.NewAWSClientPool(nil, []string{"us-test-1"},
+		external.WithCredentialsProvider{CredentialsProvider: awsSDK.NewStaticCredentialsProvider("test", "test", "")},
+		util.WithEndpointURL(server.URL),
+		util.WithMaxRetries(0))
+	require.NoError(t, err)
+
+	dir, err := ioutil.TempDir("", "awsls")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "aws_vpc.csv")
+	err = ioutil.WriteFile(file, []byte("TYPE,ID\naws_vpc,vpc-1\n"), 0644)
+	require.NoError(t, err)
+
+	client := clients[util.AWSClientKey{Region: "us-test-1"}].S3conn
+	client.ForcePathStyle = true
+
+	o := &s3Output{bucket: "my-bucket", prefix: "inventory/", client: client}
+
+	got, err := o.upload(context.Background(), []string{file})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"s3://my-bucket/inventory/aws_vpc.csv"}, got)
+	assert.Equal(t, map[string]string{"PUT /my-bucket/inventory/aws_vpc.csv": "TYPE,ID\naws_vpc,vpc-1\n"}, uploaded)
+}
+
+func TestPrintedCsvFiles_uploadCanceled(t *testing.T) {
+	uploaded := false
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		uploaded = true
+	}))
+	defer server.Close()
+
+	clients, err := util.NewAWSClientPool(nil, []string{"us-test-1"},
+		external.WithCredentialsProvider{CredentialsProvider: awsSDK.NewStaticCredentialsProvider("test", "test", "")},
+		util.WithEndpointURL(server.URL),
+		util.WithMaxRetries(0))
+	require.NoError(t, err)
+
+	dir, err := ioutil.TempDir("", "awsls")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "aws_vpc.csv")
+	err = ioutil.WriteFile(file, []byte("TYPE,ID\naws_vpc,vpc-1\n"), 0644)
+	require.NoError(t, err)
+
+	client := *clients[util.AWSClientKey{Region: "us-test-1"}].S3conn
+	client.ForcePathStyle = true
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err = printedCsvFiles(ctx, []string{file}, options{
+		s3Output: &s3Output{bucket: "my-bucket", client: &client},
+	})
+	assert.Error(t, err)
+	assert.False(t, uploaded)
+}
+
+func TestFirstClientKey(t *testing.T) {
+	got := firstClientKey([]util.AWSClientKey{
+		{Profile: "prod", Region: "eu-west-1"},
+		{Profile: "dev", Region: "us-east-1"},
+		{Profile: "dev", Region: "eu-west-1"},
+	})
+
+	assert.Equal(t, util.AWSClientKey{Profile: "dev", Region: "eu-west-1"}, got)
+}
+
+func TestListResources_perTypeTimeout(t *testing.T) {
+	canceled := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("Action") == "GetCallerIdentity" {
+			_, _ = w.Write([]byte(`<GetCallerIdentityResponse><GetCallerIdentityResult>` +
+				`<Account>000000000000</Account></GetCallerIdentityResult></GetCallerIdentityResponse>`))
+			return
+		}
+
+		// listing hangs until the request is canceled
+		<-r.Context().Done()
+		close(canceled)
+	}))
+	defer server.Close()
+
+	clients, err := util.NewAWSClientPool(nil, []string{"us-test-1"},
+		external.WithCredentialsProvider{CredentialsProvider: awsSDK.NewStaticCredentialsProvider("test", "test", "")},
+		util.WithEndpointURL(server.URL),
+		util.WithMaxRetries(0))
+	require.NoError(t, err)
+
+	hookCalled := false
+
+	opts := options{
+		parallel:       1,
+		perTypeTimeout: 100 * time.Millisecond,
+		sortBy:         "id",
+		onResource: func(aws.Resource) error {
+			hookCalled = true
+			return nil
+		},
+	}
+
+	resources, _, errs := listResources(context.Background(), "aws_vpc", nil, clients, nil, opts)
+
+	select {
+	case <-canceled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("request in flight has not been canceled after the timeout")
+	}
+
+	assert.Empty(t, resources)
+	assert.Equal(t, 0, errs, "a timeout is reported as a warning, not as an error per client")
+	assert.False(t, hookCalled)
+}
+
+func TestProgressPrinter(t *testing.T) {
+	var buf bytes.Buffer
+
+	p := newProgressPrinter(&buf, true)
+
+	dev := p.client(util.AWSClientKey{Profile: "dev", Region: "us-east-1"})
+	prod := p.client(util.AWSClientKey{Profile: "prod", Region: "us-east-1"})
+
+	dev("aws_instance", 100)
+	prod("aws_instance", 50)
+	dev("aws_instance", 200)
+	p.clear()
+	// nothing is erased twice
+	p.clear()
+
+	assert.Equal(t, "\raws_instance: 100 listed (1 profile/region)..."+
+		"\raws_instance: 150 listed (2 profile/regions)..."+
+		"\raws_instance: 250 listed (2 profile/regions)..."+
+		"\r\033[K", buf.String())
+}
+
+func TestProgressPrinter_disabled(t *testing.T) {
+	p := newProgressPrinter(&bytes.Buffer{}, false)
+
+	assert.Nil(t, p.client(util.AWSClientKey{Region: "us-east-1"}))
+	p.clear()
+}
+
+func TestAttributeValue(t *testing.T) {
+	state := cty.ObjectVal(map[string]cty.Value{
+		"instance_type": cty.StringVal("t2.micro"),
+		"tags":          cty.MapVal(map[string]cty.Value{"Team": cty.StringVal("state")}),
+	})
+
+	hasAttrs := map[string]bool{"instance_type": true, "tags": true}
+
+	tests := []struct {
+		name     string
+		attr     string
+		resource aws.Resource
+		want     string
+		wantOk   bool
+	}{
+		{
+			name: "from state",
+			attr: "tags",
+			resource: aws.Resource{Type: "aws_instance", ID: "i-1", Tags: map[string]string{"Team": "list"},
+				UpdatableResource: terradozerRes.NewWithState("aws_instance", "i-1", nil, &state)},
+			want:   `{"Team":"state"}`,
+			wantOk: true,
+		},
+		{
+			name:     "state not fetched as tags have been returned by the list API",
+			attr:     "tags",
+			resource: aws.Resource{Type: "aws_instance", ID: "i-1", Tags: map[string]string{"Team": "list"}},
+			want:     `{"Team":"list"}`,
+			wantOk:   true,
+		},
+		{
+			name:     "state not fetched and no value of the list API",
+			attr:     "instance_type",
+			resource: aws.Resource{Type: "aws_instance", ID: "i-1"},
+		},
+		{
+			name:     "state couldn't be fetched",
+			attr:     "instance_type",
+			resource: aws.Resource{Type: "aws_instance", ID: "i-1", StateStale: true},
+			want:     "error",
+			wantOk:   true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := attributeValue(tc.attr, &tc.resource, hasAttrs)
+
+			assert.Equal(t, tc.wantOk, ok)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestPrintQuotaUsage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"Quota": {"Value": 100}}`))
+	}))
+	defer server.Close()
+
+	clients, err := util.NewAWSClientPool(nil, []string{"us-test-1", "us-test-2"},
+		external.WithCredentialsProvider{CredentialsProvider: awsSDK.NewStaticCredentialsProvider("test", "test", "")},
+		util.WithEndpointURL(server.URL),
+		util.WithMaxRetries(0))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		rType     string
+		resources []aws.Resource
+		want      string
+	}{
+		{
+			name:  "quota per account and region",
+			rType: "aws_vpc",
+			resources: []aws.Resource{
+				{Type: "aws_vpc", ID: "vpc-1", Region: "us-test-1"},
+				{Type: "aws_vpc", ID: "vpc-2", Region: "us-test-1"},
+				{Type: "aws_vpc", ID: "vpc-3", Region: "us-test-2"},
+			},
+			want: "aws_vpc (profile=, region=us-test-1): 2 of 100 used\n" +
+				"aws_vpc (profile=, region=us-test-2): 1 of 100 used\n",
+		},
+		{
+			name:  "quota per account",
+			rType: "aws_s3_bucket",
+			resources: []aws.Resource{
+				{Type: "aws_s3_bucket", ID: "foo", Region: "us-test-1"},
+				{Type: "aws_s3_bucket", ID: "bar", Region: "us-test-2"},
+			},
+			want: "aws_s3_bucket (profile=, all regions): 2 of 100 used\n",
+		},
+		{
+			name:  "no known quota",
+			rType: "aws_iam_role",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+
+			printQuotaUsage(&out, tt.rType, tt.resources, clients)
+
+			assert.Equal(t, tt.want, out.String())
+		})
+	}
+}
+
+// flushRecorder is a csvWriter that records the number of rows written at each flush.
+type flushRecorder struct {
+	rows    int
+	flushes []int
+}
+
+func (w *flushRecorder) Write([]string) error {
+	w.rows++
+	return nil
+}
+
+func (w *flushRecorder) Flush() {
+	w.flushes = append(w.flushes, w.rows)
+}
+
+func (w *flushRecorder) Error() error {
+	return nil
+}
+
+func TestWriteRecordsCsv_flushEvery(t *testing.T) {
+	tests := []struct {
+		name       string
+		resources  int
+		flushEvery int
+		// number of rows written at each flush
+		wantFlushes []int
+	}{
+		{
+			name:        "flush once at the end by default",
+			resources:   5,
+			wantFlushes: []int{5},
+		},
+		{
+			name:        "flush every 2 rows",
+			resources:   5,
+			flushEvery:  2,
+			wantFlushes: []int{2, 4, 5},
+		},
+		{
+			name:        "flush every row",
+			resources:   3,
+			flushEvery:  1,
+			wantFlushes: []int{1, 2, 3, 3},
+		},
+		{
+			name:        "fewer rows than flush interval",
+			resources:   3,
+			flushEvery:  10,
+			wantFlushes: []int{3},
+		},
+		{
+			name:        "no rows",
+			flushEvery:  2,
+			wantFlushes: []int{0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resources []aws.Resource
+			for i := 0; i < tt.resources; i++ {
+				resources = append(resources, aws.Resource{Type: "aws_vpc", ID: fmt.Sprintf("vpc-%d", i)})
+			}
+
+			w := &flushRecorder{}
+
+			rows, err := writeRecordsCsv(w, resources, nil, nil, options{flushEvery: tt.flushEvery})
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.resources, rows)
+			assert.Equal(t, tt.wantFlushes, w.flushes)
+		})
+	}
+}
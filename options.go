@@ -0,0 +1,639 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/jckuester/awsls/internal"
+	"github.com/jckuester/awsls/pkg/awsls"
+	"github.com/jckuester/awsls/resource"
+	"github.com/mattn/go-isatty"
+	flag "github.com/spf13/pflag"
+	"golang.org/x/time/rate"
+)
+
+// setupOptions are the settings given via flags that are only needed to set up a run,
+// e.g., the accounts and regions to list resources in and how to create the clients for them.
+type setupOptions struct {
+	debug             bool
+	verbose           int
+	quiet             bool
+	version           bool
+	listSupported     bool
+	listTypes         bool
+	logFile           string
+	profiles          internal.CommaSeparatedListFlag
+	allProfiles       bool
+	regions           internal.CommaSeparatedListFlag
+	allRegions        bool
+	defaultRegionOnly bool
+	sampleRegions     int
+	// seed is random unless given via --seed
+	seed               int64
+	accountID          string
+	accessKeyID        string
+	secretAccessKey    string
+	sessionToken       string
+	assumeRoleARN      string
+	externalID         string
+	awsConfigFile      string
+	awsCredentialsFile string
+	userAgent          string
+	maxRetries         int
+	endpointURL        string
+	noVerifySSL        bool
+	providerDir        string
+	providerVersions   internal.CommaSeparatedListFlag
+	cacheDir           string
+	cacheTTL           time.Duration
+	noCache            bool
+	refreshCache       bool
+	dryRun             bool
+	reportUnmatched    bool
+	exact              bool
+	attributes         internal.CommaSeparatedListFlag
+	// resourceTypePatterns are the glob patterns given as arguments (or via --query or --config)
+	resourceTypePatterns []string
+	// resourceTypes are the supported resource types matched by resourceTypePatterns
+	resourceTypes []string
+}
+
+// staticCredentials returns true if credentials are given via flags (instead of profiles).
+func (s setupOptions) staticCredentials() bool {
+	return s.accessKeyID != "" || s.secretAccessKey != "" || s.sessionToken != ""
+}
+
+// flagValues are the values of flags that are only parsed into options (see parseOptions).
+type flagValues struct {
+	configFile            string
+	queryFile             string
+	expectedFile          string
+	createdBefore         string
+	createdAfter          string
+	createdWithin         internal.DurationFlag
+	excludeTypes          internal.CommaSeparatedListFlag
+	count                 bool
+	combined              bool
+	noColor               bool
+	instanceTypes         internal.CommaSeparatedListFlag
+	concurrencyPerAccount int
+	execCommand           string
+	tags                  internal.CommaSeparatedListFlag
+	excludeTags           internal.CommaSeparatedListFlag
+	rateLimit             float64
+	idFilter              string
+	delimiter             string
+}
+
+// newFlagSet returns the flags of awsls, whose values are stored in opts and v when parsed.
+func newFlagSet(opts *options, v *flagValues) *flag.FlagSet {
+	s := &opts.setup
+
+	flags := flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+
+	// help is printed by the caller of parseOptions, also for invalid flags
+	flags.Usage = func() {}
+
+	flags.BoolVar(&s.debug, "debug", false, "Enable debug logging (same as -vv)")
+	flags.VarP(&s.profiles, "profiles", "p",
+		"Comma-separated list of named AWS profiles for accounts to list resources in")
+	flags.BoolVar(&s.allProfiles, "all-profiles", false, "List resources for all profiles in ~/.aws/config")
+	flags.VarP(&s.regions, "regions", "r", "Comma-separated list of regions to list resources in")
+	flags.VarP(&s.attributes, "attributes", "a", "Comma-separated list of attributes to show for each resource "+
+		"(default: depends on the resource type, or tags)")
+	flags.StringVar(&s.accessKeyID, "access-key-id", "", "AWS access key ID of an account to list resources in "+
+		"(insecure, prefer the AWS_ACCESS_KEY_ID env instead)")
+	flags.StringVar(&s.secretAccessKey, "secret-access-key", "", "AWS secret access key of an account to list "+
+		"resources in (insecure, prefer the AWS_SECRET_ACCESS_KEY env instead)")
+	flags.StringVar(&s.sessionToken, "session-token", "", "AWS session token for temporary credentials "+
+		"(insecure, prefer the AWS_SESSION_TOKEN env instead)")
+	flags.BoolVar(&opts.ignoreAccessDenied, "ignore-access-denied", false,
+		"Only log errors caused by missing permissions for listing a resource type at debug level")
+	flags.IntVar(&opts.flushEvery, "flush-every", 0,
+		"Flush the csv output to disk every N rows (default: flush once after all rows are written)")
+	flags.DurationVar(&opts.perTypeTimeout, "per-type-timeout", 0,
+		"Maximum time to list a single resource type across all accounts and regions (e.g., 5m)")
+	flags.BoolVar(&opts.withQuota, "with-quota", false,
+		"Print how much of the service quota for each resource type is used per account and region")
+	flags.StringVar(&opts.nameTag, "name-tag", defaultNameTag,
+		"Key of the tag whose value is printed in the NAME column of the csv and parquet output "+
+			"(blank if a resource lacks the tag; --name-tag '' omits the column)")
+	flags.StringVar(&opts.nullValue, "null-value", "N/A",
+		"Value printed for attributes that are not supported by a resource type")
+	flags.Var(&v.instanceTypes, "instance-type",
+		"Comma-separated list of instance types (e.g., t3.micro,t3.small) to filter resources of type aws_instance by")
+	flags.StringVar(&opts.resourceGroup, "resource-group", "",
+		"Name of a resource group to list only the resources of that are members of it")
+	flags.BoolVar(&opts.orphans, "orphans", false,
+		"List only resources that are not used by any other resource (e.g., unassociated Elastic IPs, "+
+			"unattached EBS volumes or security groups)")
+	flags.IntVar(&v.concurrencyPerAccount, "concurrency-per-account", 0,
+		"Maximum number of in-flight API requests per AWS account across all its regions (default: no limit)")
+	flags.BoolVar(&opts.compareSources, "compare-sources", false,
+		"Print attributes that are returned by the list API of a service (i.e., tags) also in an adjacent column, "+
+			"next to the value from the Terraform state")
+	flags.StringVarP(&opts.output, "output", "o", "csv",
+		"Output format: csv, parquet, json or json-map (one file per resource type), "+
+			"ids (resource IDs to stdout, one per line), chargeback (resource counts per owner, see --owner-tag), "+
+			"protobuf (length-delimited messages to stdout, see pb/resource.proto), "+
+			"or ndjson (one JSON object per line to stdout, as soon as a resource is listed)")
+	flags.BoolVar(&opts.idsWithType, "ids-with-type", false,
+		"Together with --output ids, prefix each ID with its resource type")
+	flags.Var(&opts.maxFileSize, "max-file-size",
+		"Roll the csv output of a resource type into multiple numbered files of at most this size "+
+			"(e.g., 100MB; units are powers of 1024)")
+	flags.StringVar(&opts.onlyTypesWithAttribute, "only-types-with-attribute", "",
+		"List only resource types that support the given attribute (e.g., kms_key_id), which is printed as well")
+	flags.StringVar(&opts.idPrefix, "id-prefix", "",
+		"List only resources whose ID starts with the given prefix (e.g., i-0abc)")
+	flags.StringVar(&v.idFilter, "id-filter", "",
+		"List only resources whose ID matches the given regular expression (e.g., '^prod-')")
+	flags.StringVar(&v.execCommand, "exec", "",
+		"Command to run for each listed resource, with fields of the resource as Go template (e.g., 'echo {{.ID}}')")
+	flags.StringVar(&v.delimiter, "delimiter", ",", "Field delimiter of the csv output (a single character, "+
+		"e.g., ';' or '\\t' for tab-separated values)")
+	flags.BoolVar(&s.dryRun, "dry-run", false, "Print the (type, profile, region) queries that would run and exit "+
+		"without calling AWS (e.g., to catch accidentally huge scans)")
+	flags.BoolVar(&v.combined, "combined", false, "Write the resources of all types into a single csv file "+
+		"(or JSON array) with a column for each attribute of any type (instead of a file per type), "+
+		"sorted by account, region, type and ID")
+	flags.BoolVar(&opts.expandTags, "expand-tags", false, "Print a tag:<key> csv column for each distinct tag key "+
+		"of the resources of a type instead of a single tags column (blank if a resource lacks the tag)")
+	flags.StringVar(&opts.outDir, "out-dir", "aws-resources",
+		"Directory into which the output files are written (created if it doesn't exist), or an S3 URL "+
+			"(e.g., s3://my-bucket/prefix/) to upload the csv files to")
+	flags.BoolVar(&opts.validateOutput, "validate-output", false,
+		"Re-read each written csv, parquet or json file to check that it is well-formed and contains all rows "+
+			"(exit code 1 if not)")
+	flags.Var(&v.tags, "tags", "Comma-separated list of tags (key=value, or key=, i.e., empty value, "+
+		"for a tag that must be absent) that resources must all match to be listed")
+	flags.Var(&v.excludeTags, "exclude-tag",
+		"Comma-separated list of tags (key=value, or key only for any value) to exclude resources carrying any of them")
+	flags.BoolVar(&opts.untagged, "untagged", false,
+		"List only resources without any tags (resource types that don't support tags are skipped)")
+	flags.StringVar(&s.userAgent, "user-agent", internal.UserAgent(),
+		"Product token added to the User-Agent header of all AWS API requests")
+	flags.IntVar(&s.maxRetries, "max-retries", 5, "Maximum number of retries (with exponential backoff) of "+
+		"AWS API requests that fail because of throttling or transient errors")
+	flags.Float64Var(&v.rateLimit, "rate-limit", 0, "Maximum number of AWS API requests per second across all "+
+		"profiles and regions (0 for no limit); each resource read of the Terraform AWS Provider counts as "+
+		"one request, although it can send several")
+	flags.StringVar(&s.endpointURL, "endpoint-url", "", "Send the requests of all services (also the ones of the "+
+		"Terraform AWS Provider) to this endpoint instead of AWS, e.g., http://localhost:4566 for LocalStack")
+	flags.StringVar(&s.providerDir, "provider-dir", awsls.DefaultProviderDir, "Directory into which the "+
+		"Terraform AWS Provider is installed (created if it doesn't exist; e.g., a build cache path in CI)")
+	flags.BoolVar(&s.noVerifySSL, "no-verify-ssl", false, "Don't verify the TLS certificates of endpoints "+
+		"(e.g., self-signed ones of --endpoint-url)")
+	flags.IntVar(&opts.warnOver, "warn-over", 10000,
+		"Warn if more than this number of resources of a single type are listed (0 to disable)")
+	flags.StringVar(&opts.jsonKey, "key", "id",
+		"Together with --output json-map, the attribute to key the resources by")
+	flags.StringVar(&opts.onDuplicateKey, "on-duplicate-key", "error",
+		"Together with --output json-map, how to handle resources with the same key: error or suffix (e.g., key#2)")
+	flags.StringVar(&opts.group, "group", "",
+		"Together with --output json, group the resources by account ID and region (json-by-account) "+
+			"instead of printing a flat array")
+	flags.BoolVar(&opts.preferListAPI, "prefer-list-api", false,
+		"Take attributes from the list API of a service if it returns them (i.e., tags), "+
+			"and only fetch the Terraform state for the remaining ones")
+	flags.StringVar(&s.logFile, "log-file", "", "Write all log output into the given file instead of stderr")
+	flags.StringVar(&opts.ownerTag, "owner-tag", "Owner",
+		"Together with --output chargeback, the key of the tag whose value is the owner of a resource")
+	flags.BoolVar(&s.reportUnmatched, "report-unmatched", false,
+		"Print the resource type patterns that match no supported type to stderr as JSON array")
+	flags.BoolVar(&s.exact, "exact", false,
+		"Treat each resource type argument as an exact type name instead of a glob pattern "+
+			"(error if it isn't a supported type)")
+	flags.StringVar(&opts.explode, "explode", "",
+		"List attribute (e.g., security_groups) to print one csv row per element of, duplicating the other columns")
+	flags.BoolVar(&s.defaultRegionOnly, "default-region-only", false,
+		"List resources only in the default region of each profile (ignores --regions)")
+	flags.Var(&opts.flagOlderThan, "flag-older-than",
+		"Add an AGE_FLAG column that marks resources created longer ago than this (e.g., 365d or 720h)")
+	flags.BoolVar(&s.allRegions, "all-regions", false, "List resources in all regions enabled for the account "+
+		"(looked up once via the first profile)")
+	flags.IntVar(&s.sampleRegions, "sample-regions", 0,
+		"List resources only in N randomly picked regions out of the ones given via --regions (or --all-regions)")
+	flags.Int64Var(&s.seed, "seed", 0, "Seed for picking random regions via --sample-regions (default: random)")
+	flags.StringVar(&s.awsConfigFile, "aws-config", "",
+		"Path to the shared AWS config file to load profiles from (default: ~/.aws/config)")
+	flags.StringVar(&s.awsCredentialsFile, "aws-credentials", "",
+		"Path to the shared AWS credentials file to load credentials from (default: ~/.aws/credentials)")
+	flags.BoolVar(&opts.warnOnStaleState, "warn-on-stale-state", false,
+		"Add a STATE_STALE column that marks resources whose state couldn't be refreshed "+
+			"(their attribute values may be outdated or missing)")
+	flags.Var(&s.providerVersions, "provider-version", "Version of the Terraform AWS Provider used to fetch "+
+		"resource attributes (default: 2.68.0); given multiple comma-separated versions, the resource types and "+
+		"attributes whose support differs between them are printed instead")
+	flags.StringVar(&opts.sortBy, "sort", "id", "Column to sort the resources of each type by: type, id, "+
+		"account_id, profile, region, created, or an attribute (ties are sorted by region and ID)")
+	flags.StringVar(&opts.headerCase, "header-case", "as-is",
+		"Casing of the column headers and JSON keys: upper, lower, or as-is "+
+			"(fixed columns uppercase in csv, attributes named as in the Terraform schema)")
+	flags.BoolVar(&opts.stdout, "stdout", false,
+		"Write the csv, json, json-map, or chargeback output to stdout instead of the output directory "+
+			"(the output of each resource type is separated by a blank line)")
+	flags.BoolVar(&opts.findDuplicates, "find-duplicates", false,
+		"Instead of listing resources, print groups of resources of the same type across all accounts and regions "+
+			"that share the same name tag (Name, or the tag given via --name-tag) or value of --duplicates-by")
+	flags.StringVar(&opts.duplicatesBy, "duplicates-by", "",
+		"Attribute to group resources by for --find-duplicates (default: value of the name tag)")
+	flags.StringVar(&v.queryFile, "query", "",
+		"Path to a YAML file of a saved query (resource types, attributes, and filters; see query.go)")
+	flags.StringVar(&v.configFile, "config", "", "Path to a YAML or JSON file with the profiles, regions, "+
+		"resource types, attributes and provider version to use, unless given via flags (see config.go)")
+	flags.IntVar(&opts.parallel, "parallel", 10,
+		"Number of profile and region combinations to list resources of at the same time")
+	flags.StringVar(&v.expectedFile, "expected", "",
+		"Path to a csv file of resources that are expected to exist (with a TYPE column and a column named "+
+			"like --expected-key); missing and extra resources of the listed types are reported and the exit code is 1")
+	flags.StringVar(&opts.expectedKey, "expected-key", "id",
+		"Attribute (or id) to match listed resources against the expected ones by (see --expected)")
+	flags.StringVar(&s.accountID, "account-id", "",
+		"ID of an AWS account to list resources in, resolved to the profiles in ~/.aws/config with credentials "+
+			"for that account")
+	flags.StringVar(&v.createdBefore, "created-before", "",
+		"List only resources created before this time (RFC3339 or date, e.g., 2023-01-01)")
+	flags.StringVar(&v.createdAfter, "created-after", "",
+		"List only resources created after this time (RFC3339 or date, e.g., 2023-01-01)")
+	flags.Var(&v.createdWithin, "created-within",
+		"List only resources created within this duration until now (e.g., 7d or 24h)")
+	flags.BoolVar(&opts.keepUnknownCreated, "keep-unknown-created", false,
+		"Together with --created-before or --created-after, keep resources whose creation time is unknown")
+	flags.Var(&v.excludeTypes, "exclude", "Comma-separated list of glob patterns of resource types to skip, "+
+		"even if matched by the resource type glob pattern (exclude always wins)")
+	flags.StringVar(&s.assumeRoleARN, "assume-role-arn", "", "ARN of an IAM role to assume (with the credentials of "+
+		"each profile) for listing resources in the account of the role")
+	flags.StringVar(&s.externalID, "external-id", "", "External ID to pass when assuming the role "+
+		"of --assume-role-arn (e.g., for third-party access)")
+	flags.StringVar(&s.cacheDir, "cache-dir", "", "Cache fetched states in this directory, so that repeated runs "+
+		"only fetch the states of resources that aren't cached yet (e.g., ~/.awsls/cache)")
+	flags.DurationVar(&s.cacheTTL, "cache-ttl", time.Hour, "Time after which a cached state is fetched again")
+	flags.BoolVar(&s.noCache, "no-cache", false, "Neither read nor write cached states, even if --cache-dir is set")
+	flags.BoolVar(&s.refreshCache, "refresh", false, "Fetch all states again (ignoring cached ones) "+
+		"and cache them anew")
+	flags.BoolVar(&v.count, "count", false, "Print only the number of resources per type, profile and region "+
+		"(faster, as no state is fetched unless needed by filters)")
+	flags.BoolVar(&s.listSupported, "list-supported", false, "List all supported resource types and exit")
+	flags.BoolVar(&s.listTypes, "list-types", false, "List the supported resource types matching the given "+
+		"glob patterns (all if none are given) in alphabetical order and exit")
+	flags.CountVarP(&s.verbose, "verbose", "v", "Log more details: -v for info, -vv for debug logging; together with "+
+		"--list-supported or --list-types, show the AWS API operation used for listing each resource type")
+	flags.BoolVar(&s.quiet, "quiet", false, "Only print errors (no info or warning logs, and no summary at the end)")
+	flags.BoolVar(&s.version, "version", false, "Show application version")
+	flags.BoolVar(&v.noColor, "no-color", false, "Disable colored output (also disabled if the NO_COLOR "+
+		"environment variable is set or stderr isn't a terminal)")
+
+	return flags
+}
+
+// parseOptions parses the command-line arguments (without the program name) into options and validates them.
+// Returns flag.ErrHelp if the help is requested. Only the settings needed to list resource types are validated
+// if --version, --list-supported or --list-types is given.
+func parseOptions(args []string) (options, error) {
+	var opts options
+	var v flagValues
+
+	flags := newFlagSet(&opts, &v)
+
+	err := flags.Parse(args)
+	if err != nil {
+		return options{}, err
+	}
+
+	s := &opts.setup
+
+	// colored output is only written to stderr, so whether stdout is a terminal doesn't matter
+	color.NoColor = colorDisabled(v.noColor,
+		isatty.IsTerminal(os.Stderr.Fd()) || isatty.IsCygwinTerminal(os.Stderr.Fd()))
+
+	if s.quiet && (s.verbose > 0 || s.debug) {
+		return options{}, fmt.Errorf("--quiet can't be used together with --verbose or --debug")
+	}
+
+	// the values of the config file are only used for settings that aren't given on the command line
+	var fileConfig config
+
+	if v.configFile != "" {
+		c, err := loadConfig(v.configFile)
+		if err != nil {
+			return options{}, err
+		}
+
+		fileConfig = *c
+
+		if s.profiles == nil && len(c.Profiles) > 0 && !s.allProfiles && s.accountID == "" && !s.staticCredentials() {
+			s.profiles = c.Profiles
+		}
+
+		if s.regions == nil && len(c.Regions) > 0 && !s.allRegions && !s.defaultRegionOnly {
+			s.regions = c.Regions
+		}
+
+		if s.providerVersions == nil && c.ProviderVersion != "" {
+			s.providerVersions = []string{c.ProviderVersion}
+		}
+	}
+
+	if opts.flushEvery < 0 {
+		return options{}, fmt.Errorf("--flush-every must not be negative")
+	}
+
+	if !containsString([]string{"csv", "ids", "parquet", "json", "json-map", "chargeback", "protobuf", "ndjson"},
+		opts.output) {
+		return options{}, fmt.Errorf("unknown output format: %s", opts.output)
+	}
+
+	opts.delimiter, err = parseDelimiter(v.delimiter)
+	if err != nil {
+		return options{}, fmt.Errorf("invalid --delimiter: %s", err)
+	}
+
+	if !containsString([]string{"upper", "lower", "as-is"}, opts.headerCase) {
+		return options{}, fmt.Errorf("--header-case must be upper, lower, or as-is")
+	}
+
+	if opts.sortBy == "" {
+		return options{}, fmt.Errorf("--sort must not be empty")
+	}
+
+	if s.providerVersions == nil {
+		s.providerVersions = []string{awsls.DefaultProviderVersion}
+	}
+
+	for _, version := range s.providerVersions {
+		if !isSemver(version) {
+			return options{}, fmt.Errorf("invalid --provider-version: %s "+
+				"(must be a semantic version, e.g., 2.68.0)", version)
+		}
+	}
+
+	if opts.stdout && opts.output == "parquet" {
+		return options{}, fmt.Errorf("--stdout is not supported for parquet output")
+	}
+
+	if opts.group != "" && opts.group != jsonGroupByAccount {
+		return options{}, fmt.Errorf("--group must be %s", jsonGroupByAccount)
+	}
+
+	if opts.group != "" && opts.output != "json" {
+		return options{}, fmt.Errorf("--group requires --output json")
+	}
+
+	if opts.onDuplicateKey != "error" && opts.onDuplicateKey != "suffix" {
+		return options{}, fmt.Errorf("--on-duplicate-key must be error or suffix")
+	}
+
+	if v.count && (flags.Changed("output") || opts.findDuplicates || opts.stdout) {
+		return options{}, fmt.Errorf("--count can't be combined with --output, --find-duplicates or --stdout")
+	}
+
+	if v.count {
+		opts.counts = &countTable{}
+	}
+
+	if v.combined && (v.count || opts.findDuplicates || (opts.output != "csv" && opts.output != "json")) {
+		return options{}, fmt.Errorf("--combined is only supported for csv or json output, " +
+			"and can't be combined with --count or --find-duplicates")
+	}
+
+	if v.combined {
+		opts.combined = &combinedOutput{}
+	}
+
+	opts.s3Output, err = parseS3OutDir(opts.outDir)
+	if err != nil {
+		return options{}, fmt.Errorf("invalid --out-dir: %s", err)
+	}
+
+	if opts.s3Output != nil && (opts.output != "csv" || opts.stdout || v.count || opts.findDuplicates) {
+		return options{}, fmt.Errorf("--out-dir s3:// is only supported for csv output, " +
+			"and can't be combined with --stdout, --count or --find-duplicates")
+	}
+
+	if opts.expandTags && (opts.output != "csv" || opts.findDuplicates) {
+		return options{}, fmt.Errorf("--expand-tags is only supported for csv output")
+	}
+
+	if opts.validateOutput && (opts.stdout || !containsString([]string{"csv", "parquet", "json", "json-map"},
+		opts.output)) {
+		// the output can only be validated by re-reading the written files
+		return options{}, fmt.Errorf("--validate-output requires --output csv, parquet, json or json-map, " +
+			"and can't be combined with --stdout")
+	}
+
+	if opts.output == "ndjson" && (opts.findDuplicates || v.expectedFile != "" || opts.withQuota) {
+		// these need all resources of a type, which aren't kept when streamed
+		return options{}, fmt.Errorf("--output ndjson can't be combined with --find-duplicates, " +
+			"--expected or --with-quota")
+	}
+
+	if v.idFilter != "" {
+		opts.idFilter, err = regexp.Compile(v.idFilter)
+		if err != nil {
+			return options{}, fmt.Errorf("invalid --id-filter: %s", err)
+		}
+	}
+
+	if opts.parallel < 1 {
+		return options{}, fmt.Errorf("--parallel must be at least 1")
+	}
+
+	if v.concurrencyPerAccount < 0 {
+		return options{}, fmt.Errorf("--concurrency-per-account must not be negative")
+	}
+
+	if v.concurrencyPerAccount > 0 {
+		opts.accountSem = internal.NewKeyedSemaphore(v.concurrencyPerAccount)
+	}
+
+	if s.maxRetries < 0 {
+		return options{}, fmt.Errorf("--max-retries must not be negative")
+	}
+
+	if v.rateLimit < 0 {
+		return options{}, fmt.Errorf("--rate-limit must not be negative")
+	}
+
+	if v.rateLimit > 0 {
+		opts.rateLimiter = rate.NewLimiter(rate.Limit(v.rateLimit), 1)
+	}
+
+	if s.endpointURL != "" {
+		u, err := url.Parse(s.endpointURL)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return options{}, fmt.Errorf("invalid --endpoint-url: %s "+
+				"(must be an absolute URL, e.g., http://localhost:4566)", s.endpointURL)
+		}
+	}
+
+	for _, t := range v.tags {
+		f, err := awsls.ParseTagCondition(t)
+		if err != nil {
+			return options{}, fmt.Errorf("invalid --tags: %s", err)
+		}
+
+		opts.tags = append(opts.tags, f)
+	}
+
+	for _, t := range v.excludeTags {
+		f, err := awsls.ParseTagFilter(t)
+		if err != nil {
+			return options{}, fmt.Errorf("invalid --exclude-tag: %s", err)
+		}
+
+		opts.excludeTags = append(opts.excludeTags, f)
+	}
+
+	if v.execCommand != "" {
+		opts.onResource, err = execHook(v.execCommand)
+		if err != nil {
+			return options{}, fmt.Errorf("invalid --exec command: %s", err)
+		}
+	}
+
+	if len(flags.Args()) > 0 {
+		s.resourceTypePatterns = flags.Args()
+	}
+
+	if s.version || s.listSupported || s.listTypes {
+		return opts, nil
+	}
+
+	for _, f := range []struct {
+		name  string
+		value string
+		t     *time.Time
+	}{
+		{"created-before", v.createdBefore, &opts.createdBefore},
+		{"created-after", v.createdAfter, &opts.createdAfter},
+	} {
+		if f.value == "" {
+			continue
+		}
+
+		*f.t, err = parseTime(f.value)
+		if err != nil {
+			return options{}, fmt.Errorf("invalid --%s: %s", f.name, err)
+		}
+	}
+
+	if v.createdWithin > 0 {
+		opts.createdAfter, err = createdWithinCutoff(time.Duration(v.createdWithin), opts.createdAfter,
+			opts.createdBefore, time.Now())
+		if err != nil {
+			return options{}, fmt.Errorf("invalid --created-within: %s", err)
+		}
+	}
+
+	if v.excludeTypes != nil {
+		// validate the patterns early, before any API calls are made
+		_, err := resource.ExcludeTypes(nil, v.excludeTypes)
+		if err != nil {
+			return options{}, fmt.Errorf("invalid --exclude pattern: %s", err)
+		}
+
+		opts.excludeTypes = v.excludeTypes
+	}
+
+	if v.expectedFile != "" {
+		opts.expected, err = loadExpected(v.expectedFile, opts.expectedKey)
+		if err != nil {
+			return options{}, err
+		}
+	}
+
+	if v.queryFile != "" {
+		q, err := loadQuery(v.queryFile)
+		if err != nil {
+			return options{}, err
+		}
+
+		err = q.apply(&opts)
+		if err != nil {
+			return options{}, fmt.Errorf("invalid query file %s: %s", v.queryFile, err)
+		}
+
+		// the resource types and attributes given via the command line take precedence
+		if s.resourceTypePatterns == nil {
+			s.resourceTypePatterns = q.Types
+		}
+
+		if s.attributes == nil {
+			s.attributes = q.Attributes
+		}
+	}
+
+	if s.resourceTypePatterns == nil {
+		s.resourceTypePatterns = fileConfig.Types
+	}
+
+	if s.attributes == nil {
+		s.attributes = fileConfig.Attributes
+	}
+
+	if len(s.resourceTypePatterns) == 0 {
+		return options{}, fmt.Errorf("missing argument: resource type glob pattern")
+	}
+
+	s.resourceTypes, err = matchTypes(s.resourceTypePatterns, s.exact)
+	if err != nil {
+		return options{}, err
+	}
+
+	if v.instanceTypes != nil {
+		opts.attributeFilters = append(opts.attributeFilters, awsls.AttributeFilter{
+			Types:     []string{"aws_instance"},
+			Attribute: "instance_type",
+			Values:    v.instanceTypes,
+		})
+	}
+
+	if s.profiles != nil && s.allProfiles {
+		return options{}, fmt.Errorf("--profiles and --all-profiles flag cannot be used together")
+	}
+
+	if s.staticCredentials() {
+		if s.accessKeyID == "" || s.secretAccessKey == "" {
+			return options{}, fmt.Errorf("--access-key-id and --secret-access-key flag must be used together")
+		}
+
+		if s.profiles != nil || s.allProfiles {
+			return options{}, fmt.Errorf("credential flags cannot be used together " +
+				"with --profiles or --all-profiles flag")
+		}
+	}
+
+	if s.externalID != "" && s.assumeRoleARN == "" {
+		return options{}, fmt.Errorf("--external-id requires --assume-role-arn")
+	}
+
+	if s.accountID != "" && (s.profiles != nil || s.allProfiles || s.staticCredentials()) {
+		return options{}, fmt.Errorf("--account-id can't be used together with --profiles, " +
+			"--all-profiles, or static credentials")
+	}
+
+	if s.allRegions && (s.regions != nil || s.defaultRegionOnly) {
+		return options{}, fmt.Errorf("--all-regions can't be used together with --regions " +
+			"or --default-region-only")
+	}
+
+	if s.dryRun && (s.allRegions || s.accountID != "") {
+		// looking up the regions or profiles requires calling AWS
+		return options{}, fmt.Errorf("--dry-run can't be used together with --all-regions or --account-id")
+	}
+
+	if s.sampleRegions < 0 {
+		return options{}, fmt.Errorf("--sample-regions must not be negative")
+	}
+
+	if !flags.Changed("seed") {
+		s.seed = time.Now().UnixNano()
+	}
+
+	return opts, nil
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/jckuester/awsls/aws"
+	"github.com/jckuester/awsls/resource"
+	"github.com/xitongsys/parquet-go-source/local"
+	"github.com/xitongsys/parquet-go/reader"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+// printResourcesParquet writes the resources as a Parquet file into the output directory.
+// Returns the path of the written file.
+func printResourcesParquet(resourceType string, resources []aws.Resource, hasAttrs map[string]bool,
+	attributes []string, opts options) (string, error) {
+	filePath := filepath.Join(opts.outDir, resourceType+".parquet")
+	err := createOutDir(opts.outDir)
+	if err != nil {
+		return "", err
+	}
+	parquetFile, err := os.Create(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer parquetFile.Close()
+
+	err = writeResourcesParquet(parquetFile, resources, hasAttrs, attributes, opts)
+	if err != nil {
+		return "", err
+	}
+
+	_, _ = fmt.Printf("printed parquet file into %s \n", parquetFile.Name())
+
+	return filePath, nil
+}
+
+// validateParquetFile re-reads the given Parquet file and checks that it contains the expected number of rows.
+func validateParquetFile(path string, wantRows int) error {
+	f, err := local.NewLocalFileReader(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	pr, err := reader.NewParquetReader(f, nil, 1)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %s", path, err)
+	}
+	defer pr.ReadStop()
+
+	rows := pr.GetNumRows()
+	if rows != int64(wantRows) {
+		return fmt.Errorf("expected %d rows, but found %d", wantRows, rows)
+	}
+
+	return nil
+}
+
+// writeResourcesParquet writes a row for each resource in Parquet format. Unlike in csv format,
+// the creation time is typed as timestamp and attributes not supported by a resource type are null.
+func writeResourcesParquet(out io.Writer, resources []aws.Resource, hasAttrs map[string]bool,
+	attributes []string, opts options) error {
+	pw, err := writer.NewCSVWriterFromWriter(parquetSchema(attributes, opts), out, 1)
+	if err != nil {
+		return fmt.Errorf("failed to create parquet writer: %s", err)
+	}
+
+	for i := range resources {
+		err := pw.Write(parquetRecord(&resources[i], hasAttrs, attributes, opts))
+		if err != nil {
+			return fmt.Errorf("failed to write parquet record: %s", err)
+		}
+	}
+
+	err = pw.WriteStop()
+	if err != nil {
+		return fmt.Errorf("failed to write parquet footer: %s", err)
+	}
+
+	return nil
+}
+
+// parquetSchema returns the metadata of the Parquet columns.
+func parquetSchema(attributes []string, opts options) []string {
+	schema := []string{
+		parquetStringColumn("TYPE", true),
+		parquetStringColumn("ID", true),
+		"name=CREATED, type=TIMESTAMP_MILLIS, repetitiontype=OPTIONAL",
+		parquetStringColumn("ACCOUNT_ID", false),
+		parquetStringColumn("PROFILE", false),
+		parquetStringColumn("REGION", false),
+	}
+
+	if opts.nameTag != "" {
+		schema = append(schema, parquetStringColumn("NAME", false))
+	}
+
+	for _, attr := range attributes {
+		schema = append(schema, parquetStringColumn(attr, false))
+	}
+
+	return schema
+}
+
+// parquetStringColumn returns the metadata of a Parquet column of type string.
+func parquetStringColumn(name string, required bool) string {
+	repetitionType := "OPTIONAL"
+	if required {
+		repetitionType = "REQUIRED"
+	}
+
+	return fmt.Sprintf("name=%s, type=UTF8, repetitiontype=%s", name, repetitionType)
+}
+
+// parquetRecord returns the values of a Parquet row for the given resource.
+func parquetRecord(r *aws.Resource, hasAttrs map[string]bool, attributes []string, opts options) []interface{} {
+	var createdAt interface{}
+	if r.CreatedAt != nil {
+		createdAt = r.CreatedAt.UnixNano() / 1e6
+	}
+
+	record := []interface{}{r.Type, r.ID, createdAt, r.AccountID, r.Profile, r.Region}
+
+	if opts.nameTag != "" {
+		record = append(record, resource.GetTags(r)[opts.nameTag])
+	}
+
+	for _, attr := range attributes {
+		v, ok := attributeValue(attr, r, hasAttrs)
+		if !ok {
+			record = append(record, nil)
+			continue
+		}
+
+		record = append(record, v)
+	}
+
+	return record
+}
@@ -0,0 +1,4 @@
+// Package pb contains the protobuf messages written by `awsls --output protobuf`.
+package pb
+
+//go:generate protoc -I.. --go_out=paths=source_relative:.. ../pb/resource.proto
@@ -0,0 +1,145 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: pb/resource.proto
+
+package pb
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// Resource is a listed AWS resource. With `awsls --output protobuf`, resources are written to stdout
+// as a stream of length-delimited messages, i.e., each message is prefixed by its size as varint.
+type Resource struct {
+	// Terraform resource type, e.g., aws_instance.
+	Type      string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Id        string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Profile   string `protobuf:"bytes,3,opt,name=profile,proto3" json:"profile,omitempty"`
+	Region    string `protobuf:"bytes,4,opt,name=region,proto3" json:"region,omitempty"`
+	AccountId string `protobuf:"bytes,5,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	// Creation time; unset if unknown.
+	Created *timestamp.Timestamp `protobuf:"bytes,6,opt,name=created,proto3" json:"created,omitempty"`
+	// Printed attributes by name; attributes not supported by the resource type are absent.
+	Attributes           map[string]string `protobuf:"bytes,7,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *Resource) Reset()         { *m = Resource{} }
+func (m *Resource) String() string { return proto.CompactTextString(m) }
+func (*Resource) ProtoMessage()    {}
+func (*Resource) Descriptor() ([]byte, []int) {
+	return fileDescriptor_31eda07259c54a37, []int{0}
+}
+
+func (m *Resource) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Resource.Unmarshal(m, b)
+}
+func (m *Resource) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Resource.Marshal(b, m, deterministic)
+}
+func (m *Resource) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Resource.Merge(m, src)
+}
+func (m *Resource) XXX_Size() int {
+	return xxx_messageInfo_Resource.Size(m)
+}
+func (m *Resource) XXX_DiscardUnknown() {
+	xxx_messageInfo_Resource.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Resource proto.InternalMessageInfo
+
+func (m *Resource) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *Resource) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Resource) GetProfile() string {
+	if m != nil {
+		return m.Profile
+	}
+	return ""
+}
+
+func (m *Resource) GetRegion() string {
+	if m != nil {
+		return m.Region
+	}
+	return ""
+}
+
+func (m *Resource) GetAccountId() string {
+	if m != nil {
+		return m.AccountId
+	}
+	return ""
+}
+
+func (m *Resource) GetCreated() *timestamp.Timestamp {
+	if m != nil {
+		return m.Created
+	}
+	return nil
+}
+
+func (m *Resource) GetAttributes() map[string]string {
+	if m != nil {
+		return m.Attributes
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Resource)(nil), "awsls.Resource")
+	proto.RegisterMapType((map[string]string)(nil), "awsls.Resource.AttributesEntry")
+}
+
+func init() {
+	proto.RegisterFile("pb/resource.proto", fileDescriptor_31eda07259c54a37)
+}
+
+var fileDescriptor_31eda07259c54a37 = []byte{
+	// 288 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x5c, 0x8f, 0x4d, 0x4b, 0x33, 0x31,
+	0x10, 0xc7, 0xd9, 0xed, 0xdb, 0xd3, 0x29, 0x3c, 0x6a, 0x10, 0x09, 0x85, 0xd2, 0xe2, 0xa9, 0xa7,
+	0x2c, 0x54, 0x0f, 0x22, 0x88, 0x28, 0x78, 0xf0, 0xba, 0x78, 0xf2, 0x22, 0x49, 0x76, 0xba, 0xc6,
+	0x6e, 0x9b, 0x90, 0x4c, 0x94, 0x7e, 0x30, 0xbf, 0x9f, 0x98, 0xee, 0x8a, 0x78, 0x9b, 0xff, 0xcb,
+	0xc0, 0xef, 0x0f, 0x27, 0x4e, 0x15, 0x1e, 0x83, 0x8d, 0x5e, 0xa3, 0x70, 0xde, 0x92, 0x65, 0x03,
+	0xf9, 0x11, 0x9a, 0x30, 0x9d, 0xd7, 0xd6, 0xd6, 0x0d, 0x16, 0xc9, 0x54, 0x71, 0x5d, 0x90, 0xd9,
+	0x62, 0x20, 0xb9, 0x75, 0x87, 0xde, 0xf9, 0x67, 0x0e, 0xff, 0xca, 0xf6, 0x95, 0x31, 0xe8, 0xd3,
+	0xde, 0x21, 0xcf, 0x16, 0xd9, 0x72, 0x5c, 0xa6, 0x9b, 0xfd, 0x87, 0xdc, 0x54, 0x3c, 0x4f, 0x4e,
+	0x6e, 0x2a, 0xc6, 0x61, 0xe4, 0xbc, 0x5d, 0x9b, 0x06, 0x79, 0x2f, 0x99, 0x9d, 0x64, 0x67, 0x30,
+	0xf4, 0x58, 0x1b, 0xbb, 0xe3, 0xfd, 0x14, 0xb4, 0x8a, 0xcd, 0x00, 0xa4, 0xd6, 0x36, 0xee, 0xe8,
+	0xc5, 0x54, 0x7c, 0x90, 0xb2, 0x71, 0xeb, 0x3c, 0x56, 0xec, 0x12, 0x46, 0xda, 0xa3, 0x24, 0xac,
+	0xf8, 0x70, 0x91, 0x2d, 0x27, 0xab, 0xa9, 0x38, 0x40, 0x8b, 0x0e, 0x5a, 0x3c, 0x75, 0xd0, 0x65,
+	0x57, 0x65, 0xb7, 0x00, 0x92, 0xc8, 0x1b, 0x15, 0x09, 0x03, 0x1f, 0x2d, 0x7a, 0xcb, 0xc9, 0x6a,
+	0x2e, 0xd2, 0x68, 0xd1, 0xed, 0x11, 0x77, 0x3f, 0x8d, 0x87, 0x1d, 0xf9, 0x7d, 0xf9, 0xeb, 0x65,
+	0x7a, 0x03, 0x47, 0x7f, 0x62, 0x76, 0x0c, 0xbd, 0x0d, 0xee, 0xdb, 0xf5, 0xdf, 0x27, 0x3b, 0x85,
+	0xc1, 0xbb, 0x6c, 0x22, 0xb6, 0xfb, 0x0f, 0xe2, 0x3a, 0xbf, 0xca, 0xee, 0xe7, 0xcf, 0xb3, 0xda,
+	0xd0, 0x6b, 0x54, 0x42, 0xdb, 0x6d, 0xf1, 0xa6, 0x37, 0x11, 0x03, 0xa1, 0x2f, 0x12, 0x41, 0xe1,
+	0x94, 0x1a, 0x26, 0xfa, 0x8b, 0xaf, 0x01, 0x00, 0x18, 0x3e, 0x52, 0x21, 0x9c, 0x01, 0x00, 0x00,
+}
@@ -0,0 +1,25 @@
+package awsls
+
+import (
+	"context"
+
+	"github.com/jckuester/awsls/aws"
+	"github.com/jckuester/awsls/util"
+)
+
+// Exported filters for testing.
+var (
+	FilterByAttributes       = filterByAttributes
+	FilterByTags             = filterByTags
+	FilterByCreated          = filterByCreated
+	FilterUntagged           = filterUntagged
+	ExcludeByTags            = excludeByTags
+	FilterByIDRegexp         = filterByIDRegexp
+	WithoutListAPIAttributes = withoutListAPIAttributes
+)
+
+// Get exports get for testing.
+func (t *TaggedResources) Get(ctx context.Context, key util.AWSClientKey,
+	client *aws.Client) (map[string]map[string]string, error) {
+	return t.get(ctx, key, client)
+}
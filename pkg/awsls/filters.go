@@ -0,0 +1,358 @@
+package awsls
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/apex/log"
+	"github.com/jckuester/awsls/aws"
+	"github.com/jckuester/awsls/resource"
+	"github.com/jckuester/awsls/util"
+)
+
+// Filter selects which of the listed resources are returned. The zero value returns all resources.
+type Filter struct {
+	// ResourceGroupARNs are the ARNs of the members of a resource group per AWS client; if not nil,
+	// only the members are returned (none for a client without an entry).
+	ResourceGroupARNs map[util.AWSClientKey][]string
+	// IDPrefix keeps only resources whose ID starts with this prefix (if not empty).
+	IDPrefix string
+	// ID keeps only resources whose ID matches (if not nil).
+	ID *regexp.Regexp
+	// CreatedAfter and CreatedBefore keep only resources created within this time range (each is ignored if zero).
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	// KeepUnknownCreated keeps resources with unknown creation time when filtering by creation time.
+	KeepUnknownCreated bool
+	// Attributes keep only resources that match all filters that apply to their type.
+	Attributes []AttributeFilter
+	// Orphans keeps only resources that are not used by any other resource (see resource.HasOrphanCondition).
+	Orphans bool
+	// Tags keep only resources that match all of these tags.
+	Tags []TagFilter
+	// ExcludeTags drop resources that match any of these tags.
+	ExcludeTags []TagFilter
+	// Untagged keeps only resources without any tags.
+	Untagged bool
+}
+
+// TagFilter matches resources that carry a tag with the given key, and value (if HasValue is set),
+// or, if Absent is set, resources that don't carry a tag with the given key.
+type TagFilter struct {
+	Key      string
+	Value    string
+	HasValue bool
+	Absent   bool
+}
+
+// ParseTagFilter parses a tag filter given as key=value or key only.
+func ParseTagFilter(s string) (TagFilter, error) {
+	kv := strings.SplitN(s, "=", 2)
+	if kv[0] == "" {
+		return TagFilter{}, fmt.Errorf("missing tag key: %s", s)
+	}
+
+	if len(kv) == 1 {
+		return TagFilter{Key: kv[0]}, nil
+	}
+
+	return TagFilter{Key: kv[0], Value: kv[1], HasValue: true}, nil
+}
+
+// ParseTagCondition parses a tag condition that resources must meet given as key=value or key only,
+// where an empty value (e.g., Owner=) means that the tag must be absent.
+func ParseTagCondition(s string) (TagFilter, error) {
+	f, err := ParseTagFilter(s)
+	if err != nil {
+		return TagFilter{}, err
+	}
+
+	if f.HasValue && f.Value == "" {
+		return TagFilter{Key: f.Key, Absent: true}, nil
+	}
+
+	return f, nil
+}
+
+// Match returns true if the given tags contain the tag of the filter
+// (or don't contain it, if the filter is for an absent tag).
+func (f TagFilter) Match(tags map[string]string) bool {
+	v, ok := tags[f.Key]
+	if f.Absent {
+		return !ok
+	}
+
+	if !ok {
+		return false
+	}
+
+	return !f.HasValue || v == f.Value
+}
+
+// AttributeFilter keeps only resources whose attribute equals one of the given values.
+// Resources of other types than the given ones are not filtered.
+type AttributeFilter struct {
+	Types     []string
+	Attribute string
+	Values    []string
+}
+
+// AppliesTo returns true if the filter applies to resources of the given type.
+func (f AttributeFilter) AppliesTo(rType string) bool {
+	for _, t := range f.Types {
+		if t == rType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Match returns true if the attribute of the given resource equals one of the values of the filter.
+func (f AttributeFilter) Match(r *aws.Resource) bool {
+	v, err := resource.GetAttribute(f.Attribute, r)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"type": r.Type,
+			"id":   r.ID}).WithError(err).Debug("failed to get attribute to filter by")
+
+		return false
+	}
+
+	for _, value := range f.Values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// filterByAttributes returns only the resources that match all of the given filters.
+func filterByAttributes(resources []aws.Resource, filters []AttributeFilter) []aws.Resource {
+	if len(filters) == 0 {
+		return resources
+	}
+
+	var result []aws.Resource
+
+	for i := range resources {
+		matched := true
+		for _, f := range filters {
+			if !f.Match(&resources[i]) {
+				matched = false
+				break
+			}
+		}
+
+		if matched {
+			result = append(result, resources[i])
+		}
+	}
+
+	return result
+}
+
+// filterOrphans returns only the resources of a type that are not used by any other resource.
+func filterOrphans(ctx context.Context, rType string, client *aws.Client,
+	resources []aws.Resource) ([]aws.Resource, error) {
+	if len(resources) == 0 {
+		return nil, nil
+	}
+
+	orphanIDs, ok, err := resource.LookupOrphanIDs(ctx, rType, client)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []aws.Resource
+
+	for i := range resources {
+		if ok {
+			if orphanIDs[resources[i].ID] {
+				result = append(result, resources[i])
+			}
+
+			continue
+		}
+
+		orphan, err := resource.IsOrphan(&resources[i])
+		if err != nil {
+			log.WithFields(log.Fields{
+				"type": resources[i].Type,
+				"id":   resources[i].ID}).WithError(err).Debug("failed to check if resource is orphaned")
+
+			continue
+		}
+
+		if orphan {
+			result = append(result, resources[i])
+		}
+	}
+
+	return result, nil
+}
+
+// filterByTags returns only the resources that carry all tags of the given filters.
+func filterByTags(resources []aws.Resource, filters []TagFilter) []aws.Resource {
+	var result []aws.Resource
+
+	for i := range resources {
+		tags := resource.GetTags(&resources[i])
+
+		matched := true
+		for _, f := range filters {
+			if !f.Match(tags) {
+				matched = false
+				break
+			}
+		}
+
+		if matched {
+			result = append(result, resources[i])
+		}
+	}
+
+	return result
+}
+
+// filterByCreated returns only the resources created after and before the given times (each is ignored if zero).
+// Resources with unknown creation time are filtered out, unless keepUnknown is set.
+func filterByCreated(resources []aws.Resource, after, before time.Time, keepUnknown bool) []aws.Resource {
+	var result []aws.Resource
+
+	for _, r := range resources {
+		if r.CreatedAt == nil {
+			if keepUnknown {
+				result = append(result, r)
+			}
+
+			continue
+		}
+
+		if !after.IsZero() && !r.CreatedAt.After(after) {
+			continue
+		}
+
+		if !before.IsZero() && !r.CreatedAt.Before(before) {
+			continue
+		}
+
+		result = append(result, r)
+	}
+
+	return result
+}
+
+// filterUntagged returns only the resources that don't carry any tags.
+func filterUntagged(resources []aws.Resource) []aws.Resource {
+	var result []aws.Resource
+
+	for i := range resources {
+		if len(resource.GetTags(&resources[i])) == 0 {
+			result = append(result, resources[i])
+		}
+	}
+
+	return result
+}
+
+// excludeByTags returns only the resources that carry none of the tags of the given filters.
+func excludeByTags(resources []aws.Resource, filters []TagFilter) []aws.Resource {
+	var result []aws.Resource
+
+	for i := range resources {
+		tags := resource.GetTags(&resources[i])
+
+		excluded := false
+		for _, f := range filters {
+			if f.Match(tags) {
+				excluded = true
+				break
+			}
+		}
+
+		if !excluded {
+			result = append(result, resources[i])
+		}
+	}
+
+	return result
+}
+
+// filterByIDPrefix returns only the resources whose ID starts with the given prefix.
+func filterByIDPrefix(resources []aws.Resource, prefix string) []aws.Resource {
+	var result []aws.Resource
+
+	for _, r := range resources {
+		if strings.HasPrefix(r.ID, prefix) {
+			result = append(result, r)
+		}
+	}
+
+	return result
+}
+
+// filterByIDRegexp returns only the resources whose ID matches the given regular expression.
+func filterByIDRegexp(resources []aws.Resource, re *regexp.Regexp) []aws.Resource {
+	var result []aws.Resource
+
+	for _, r := range resources {
+		if re.MatchString(r.ID) {
+			result = append(result, r)
+		}
+	}
+
+	return result
+}
+
+// filterByARNs returns only the resources that are identified by one of the given ARNs.
+func filterByARNs(rType string, resources []aws.Resource, arns []string) []aws.Resource {
+	var result []aws.Resource
+
+	for _, r := range resources {
+		for _, arn := range arns {
+			if aws.MatchesARN(rType, r.ID, arn) {
+				result = append(result, r)
+				break
+			}
+		}
+	}
+
+	return result
+}
+
+// IsListAPIAttribute returns true if the value of the given attribute is also returned by the list API of a service.
+func IsListAPIAttribute(attr string) bool {
+	return attr == "tags"
+}
+
+// withoutListAPIAttributes returns the given attributes without those whose values have been returned
+// by the list API for all of the given resources, so that they don't need to be fetched from the Terraform state.
+func withoutListAPIAttributes(attributes []string, resources []aws.Resource) []string {
+	var result []string
+
+	for _, attr := range attributes {
+		if IsListAPIAttribute(attr) && allHaveListAPIValue(attr, resources) {
+			continue
+		}
+
+		result = append(result, attr)
+	}
+
+	return result
+}
+
+// allHaveListAPIValue returns true if the list API returned the given attribute for all resources.
+func allHaveListAPIValue(attr string, resources []aws.Resource) bool {
+	for _, r := range resources {
+		if attr == "tags" && r.Tags == nil {
+			return false
+		}
+	}
+
+	return true
+}
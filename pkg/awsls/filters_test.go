@@ -0,0 +1,283 @@
+package awsls_test
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/jckuester/awsls/aws"
+	"github.com/jckuester/awsls/pkg/awsls"
+	terradozerRes "github.com/jckuester/terradozer/pkg/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestFilterByTags(t *testing.T) {
+	resources := []aws.Resource{
+		{ID: "prod-owned", Tags: map[string]string{"Environment": "prod", "Owner": "team-a"}},
+		{ID: "prod-unowned", Tags: map[string]string{"Environment": "prod"}},
+		{ID: "dev-unowned", Tags: map[string]string{"Environment": "dev"}},
+		{ID: "untagged"},
+	}
+
+	tests := []struct {
+		name       string
+		conditions []string
+		want       []string
+	}{
+		{
+			name:       "key and value",
+			conditions: []string{"Environment=prod"},
+			want:       []string{"prod-owned", "prod-unowned"},
+		},
+		{
+			name:       "absent tag",
+			conditions: []string{"Owner="},
+			want:       []string{"prod-unowned", "dev-unowned", "untagged"},
+		},
+		{
+			name:       "conditions are ANDed",
+			conditions: []string{"Environment=prod", "Owner="},
+			want:       []string{"prod-unowned"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var filters []awsls.TagFilter
+			for _, c := range tt.conditions {
+				f, err := awsls.ParseTagCondition(c)
+				require.NoError(t, err)
+
+				filters = append(filters, f)
+			}
+
+			var got []string
+			for _, r := range awsls.FilterByTags(resources, filters) {
+				got = append(got, r.ID)
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFilterUntagged(t *testing.T) {
+	// the tags of the state win over the ones returned by the list API
+	untaggedState := cty.ObjectVal(map[string]cty.Value{
+		"id":   cty.StringVal("untagged-state"),
+		"tags": cty.MapValEmpty(cty.String),
+	})
+
+	resources := []aws.Resource{
+		{ID: "tagged", Tags: map[string]string{"Environment": "prod"}},
+		{ID: "no-tags"},
+		{ID: "empty-tags", Tags: map[string]string{}},
+		{ID: "untagged-state", Tags: map[string]string{"Environment": "prod"},
+			UpdatableResource: terradozerRes.NewWithState("aws_instance", "untagged-state", nil, &untaggedState)},
+	}
+
+	var actual []string
+	for _, r := range awsls.FilterUntagged(resources) {
+		actual = append(actual, r.ID)
+	}
+
+	assert.Equal(t, []string{"no-tags", "empty-tags", "untagged-state"}, actual)
+}
+
+func TestExcludeByTags(t *testing.T) {
+	resources := []aws.Resource{
+		{ID: "managed", Tags: map[string]string{"ManagedBy": "Terraform"}},
+		{ID: "temporary", Tags: map[string]string{"Temporary": "true"}},
+		{ID: "click-ops", Tags: map[string]string{"ManagedBy": "Console"}},
+		{ID: "untagged"},
+	}
+
+	tests := []struct {
+		name    string
+		filters []string
+		want    []string
+	}{
+		{
+			name:    "key and value",
+			filters: []string{"ManagedBy=Terraform"},
+			want:    []string{"temporary", "click-ops", "untagged"},
+		},
+		{
+			name:    "key only",
+			filters: []string{"ManagedBy"},
+			want:    []string{"temporary", "untagged"},
+		},
+		{
+			name:    "multiple filters",
+			filters: []string{"ManagedBy=Terraform", "Temporary=true"},
+			want:    []string{"click-ops", "untagged"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var filters []awsls.TagFilter
+			for _, s := range tt.filters {
+				f, err := awsls.ParseTagFilter(s)
+				require.NoError(t, err)
+
+				filters = append(filters, f)
+			}
+
+			var actual []string
+			for _, r := range awsls.ExcludeByTags(resources, filters) {
+				actual = append(actual, r.ID)
+			}
+
+			assert.Equal(t, tt.want, actual)
+		})
+	}
+}
+
+func TestWithoutListAPIAttributes(t *testing.T) {
+	tests := []struct {
+		name      string
+		resources []aws.Resource
+		want      []string
+	}{
+		{
+			name: "list API returned tags of all resources",
+			resources: []aws.Resource{
+				{ID: "foo", Tags: map[string]string{}},
+				{ID: "bar", Tags: map[string]string{"Name": "bar"}},
+			},
+			want: []string{"instance_type"},
+		},
+		{
+			name: "list API didn't return tags",
+			resources: []aws.Resource{
+				{ID: "foo", Tags: map[string]string{}},
+				{ID: "bar"},
+			},
+			want: []string{"instance_type", "tags"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, awsls.WithoutListAPIAttributes([]string{"instance_type", "tags"}, tt.resources))
+		})
+	}
+}
+
+func TestFilterByCreated(t *testing.T) {
+	before2020 := time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)
+	in2020 := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	after2020 := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	resources := []aws.Resource{
+		{ID: "before", CreatedAt: &before2020},
+		{ID: "in", CreatedAt: &in2020},
+		{ID: "after", CreatedAt: &after2020},
+		{ID: "unknown"},
+	}
+
+	after := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	got := awsls.FilterByCreated(resources, after, before, false)
+
+	require.Len(t, got, 1)
+	assert.Equal(t, "in", got[0].ID)
+
+	got = awsls.FilterByCreated(resources, after, before, true)
+
+	require.Len(t, got, 2)
+	assert.Equal(t, "in", got[0].ID)
+	assert.Equal(t, "unknown", got[1].ID)
+}
+
+func TestFilterByIDRegexp(t *testing.T) {
+	resources := []aws.Resource{
+		{ID: "prod-logs"},
+		{ID: "dev-logs"},
+		{ID: "my-prod-logs"},
+	}
+
+	got := awsls.FilterByIDRegexp(resources, regexp.MustCompile("^prod-"))
+
+	require.Len(t, got, 1)
+	assert.Equal(t, "prod-logs", got[0].ID)
+}
+
+func TestFilterByAttributes(t *testing.T) {
+	instance := func(id, instanceType string) aws.Resource {
+		state := cty.ObjectVal(map[string]cty.Value{
+			"instance_type": cty.StringVal(instanceType),
+			"monitoring":    cty.BoolVal(true),
+		})
+
+		return aws.Resource{
+			Type:              "aws_instance",
+			ID:                id,
+			UpdatableResource: terradozerRes.NewWithState("aws_instance", id, nil, &state),
+		}
+	}
+
+	resources := []aws.Resource{
+		instance("i-1", "t3.micro"),
+		instance("i-2", "t3.small"),
+		instance("i-3", "m5.large"),
+		// the state couldn't be fetched
+		{Type: "aws_instance", ID: "i-4"},
+	}
+
+	tests := []struct {
+		name    string
+		filters []awsls.AttributeFilter
+		want    []string
+	}{
+		{
+			name: "no filters",
+			want: []string{"i-1", "i-2", "i-3", "i-4"},
+		},
+		{
+			name: "single value",
+			filters: []awsls.AttributeFilter{
+				{Types: []string{"aws_instance"}, Attribute: "instance_type", Values: []string{"t3.micro"}},
+			},
+			want: []string{"i-1"},
+		},
+		{
+			name: "any of multiple values",
+			filters: []awsls.AttributeFilter{
+				{Types: []string{"aws_instance"}, Attribute: "instance_type", Values: []string{"t3.micro", "m5.large"}},
+			},
+			want: []string{"i-1", "i-3"},
+		},
+		{
+			name: "all of multiple filters",
+			filters: []awsls.AttributeFilter{
+				{Types: []string{"aws_instance"}, Attribute: "instance_type", Values: []string{"t3.micro", "t3.small"}},
+				{Types: []string{"aws_instance"}, Attribute: "monitoring", Values: []string{"true"}},
+			},
+			want: []string{"i-1", "i-2"},
+		},
+		{
+			name: "no value matches",
+			filters: []awsls.AttributeFilter{
+				{Types: []string{"aws_instance"}, Attribute: "instance_type", Values: []string{"c5.xlarge"}},
+			},
+		},
+		{
+			name: "unknown attribute",
+			filters: []awsls.AttributeFilter{
+				{Types: []string{"aws_instance"}, Attribute: "foo", Values: []string{"t3.micro"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, r := range awsls.FilterByAttributes(resources, tt.filters) {
+				got = append(got, r.ID)
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,559 @@
+// Package awsls lists AWS resources of all types supported by awsls, so that they can be used in other Go tools
+// instead of parsing the output of the awsls command.
+package awsls
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apex/log"
+	"github.com/jckuester/awsls/aws"
+	"github.com/jckuester/awsls/internal"
+	"github.com/jckuester/awsls/resource"
+	"github.com/jckuester/awsls/util"
+	"github.com/jckuester/terradozer/pkg/provider"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// DefaultProviderVersion is the version of the Terraform AWS Provider used to fetch attributes by default.
+	DefaultProviderVersion = "2.68.0"
+	// DefaultProviderDir is the directory in which the Terraform AWS Provider is installed by default.
+	DefaultProviderDir = "~/.awsls"
+	// DefaultParallel is the number of AWS clients that list resources at the same time by default.
+	DefaultParallel = 10
+)
+
+// Options configure which resources List returns.
+type Options struct {
+	// Profiles are the AWS profiles to list resources with. If empty, credentials are picked up via
+	// the default provider chain.
+	Profiles []string
+	// Regions to list resources in. If empty, the region is picked up via the environment or
+	// the default region of each profile.
+	Regions []string
+	// Types are glob patterns of resource types (e.g., aws_instance or aws_iam_*).
+	Types []string
+	// Exact matches Types as names of resource types instead of glob patterns.
+	Exact bool
+	// Attributes are fetched from the Terraform state of each resource; no state is fetched if empty
+	// (unless needed by Filter).
+	Attributes []string
+	// Filter selects which of the listed resources are returned.
+	Filter Filter
+	// ProviderVersion is the version of the Terraform AWS Provider used to fetch the states
+	// (DefaultProviderVersion if empty).
+	ProviderVersion string
+	// ProviderDir is the directory in which the Terraform AWS Provider is installed (DefaultProviderDir if empty),
+	// which is created if needed; ~ and environment variables are expanded.
+	ProviderDir string
+	// Clients list the resources instead of clients created for Profiles and Regions (if not empty).
+	Clients map[util.AWSClientKey]aws.Client
+	// Providers fetch the states of the resources listed via Clients (ignored if Clients is empty).
+	Providers map[util.AWSClientKey]provider.TerraformProvider
+	// Parallel is the number of AWS clients that list resources at the same time (DefaultParallel if zero).
+	Parallel int
+	// PerTypeTimeout stops listing a type after this duration, keeping only the resources of the clients
+	// completed so far (no timeout if zero).
+	PerTypeTimeout time.Duration
+	// IgnoreAccessDenied skips clients that aren't allowed to list a type instead of reporting an error.
+	IgnoreAccessDenied bool
+	// PreferListAPI doesn't fetch states of the resources of a client if the list API returned the values
+	// of all attributes already.
+	PreferListAPI bool
+	// WarnOver warns once more than this number of resources of a type have been listed, before they are
+	// filtered (no warning if zero).
+	WarnOver int
+	// AccountSem limits the number of in-flight requests per AWS account (no limit if nil).
+	AccountSem *internal.KeyedSemaphore
+	// RateLimiter limits the rate of the requests of the clients and of the state reads (no limit if nil).
+	RateLimiter *rate.Limiter
+	// StateCache caches fetched states (no caching if nil).
+	StateCache *resource.StateCache
+	// TaggedResources caches the tags looked up for types whose state doesn't contain tags
+	// (a new cache per type if nil).
+	TaggedResources *TaggedResources
+	// Progress returns the function to report the progress of listing via a client (optional).
+	Progress func(key util.AWSClientKey) aws.ProgressFunc
+	// OnResources is called with the resources of each client once they have been listed and filtered,
+	// one client after another (never concurrently). Resources of clients that didn't complete in time
+	// are never passed (see PerTypeTimeout).
+	OnResources func(rType string, resources []aws.Resource, hasAttrs map[string]bool)
+	// Stream passes the resources only to OnResources instead of also returning them.
+	Stream bool
+	// OnError is called with each error that doesn't stop listing (e.g., of listing a type via a single client),
+	// never concurrently. If nil, these errors are returned (combined).
+	OnError func(err error)
+	// OnWarning is called with each warning (e.g., about a type that timed out), never concurrently.
+	// Warnings are logged at debug level if nil.
+	OnWarning func(msg string)
+}
+
+// List returns the resources of all types matched by opts.Types in all profiles and regions, sorted by type,
+// region and ID. If attributes are given, the state of each resource is fetched so that the values can be read
+// via resource.GetAttribute.
+//
+// Listing stops once the context is done, returning the resources listed so far together with the context's
+// error. Errors of single types, profiles or regions don't stop listing the others, but are returned (combined)
+// together with all resources that could be listed.
+func List(ctx context.Context, opts Options) ([]aws.Resource, error) {
+	if len(opts.Types) == 0 {
+		return nil, fmt.Errorf("no resource type given")
+	}
+
+	if opts.Parallel < 0 {
+		return nil, fmt.Errorf("parallel must be positive: %d", opts.Parallel)
+	}
+
+	if opts.ProviderVersion == "" {
+		opts.ProviderVersion = DefaultProviderVersion
+	}
+
+	if opts.ProviderDir == "" {
+		opts.ProviderDir = DefaultProviderDir
+	}
+
+	rTypes, unmatched, err := MatchTypes(opts.Types, opts.Exact)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(unmatched) > 0 {
+		return nil, fmt.Errorf("no resource type found: %s", strings.Join(unmatched, ", "))
+	}
+
+	sort.Strings(rTypes)
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var errs []string
+
+	if opts.OnError == nil {
+		opts.OnError = func(err error) {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	if opts.TaggedResources == nil {
+		opts.TaggedResources = NewTaggedResources()
+	}
+
+	clients, providers := opts.Clients, opts.Providers
+
+	if len(clients) == 0 {
+		clients, err = util.NewAWSClientPool(opts.Profiles, opts.Regions)
+		if err != nil {
+			return nil, err
+		}
+
+		providers = nil
+
+		if len(opts.Attributes) > 0 || len(opts.Filter.Attributes) > 0 || opts.Filter.Orphans {
+			providerDir, err := util.PrepareProviderDir(opts.ProviderDir)
+			if err != nil {
+				return nil, err
+			}
+
+			clientKeys := make([]util.AWSClientKey, 0, len(clients))
+			for k := range clients {
+				clientKeys = append(clientKeys, k)
+			}
+
+			var providerErrs map[util.AWSClientKey]error
+
+			providers, providerErrs = util.NewProviderPool(clientKeys, opts.ProviderVersion, providerDir,
+				10*time.Second, util.ProviderConfig{})
+			defer func() {
+				for _, p := range providers {
+					_ = p.Close()
+				}
+			}()
+
+			for key, err := range providerErrs {
+				// resources of this client are still listed, but without attributes
+				opts.OnError(fmt.Errorf("failed to initialize the Terraform AWS Provider "+
+					"(profile=%s, region=%s): %s", key.Profile, key.Region, err))
+			}
+		}
+	}
+
+	var result []aws.Resource
+
+	for _, rType := range rTypes {
+		if ctx.Err() != nil {
+			break
+		}
+
+		if opts.Filter.Orphans && !resource.HasOrphanCondition(rType) {
+			log.WithField("type", rType).Debug("no orphan condition known for resource type")
+			continue
+		}
+
+		resources, _, err := ListType(ctx, rType, clients, providers, opts)
+		if err != nil {
+			opts.OnError(err)
+		}
+
+		result = append(result, resources...)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return result, err
+	}
+
+	if len(errs) > 0 {
+		return result, fmt.Errorf("%d error(s) occurred: %s", len(errs), strings.Join(errs, "; "))
+	}
+
+	return result, nil
+}
+
+// MatchTypes returns the supported resource types matched by any of the given glob patterns, in the order
+// of the patterns and without duplicates, together with the patterns that don't match any type.
+// If exact is set, each pattern must be the name of a supported type instead.
+func MatchTypes(patterns []string, exact bool) ([]string, []string, error) {
+	var result []string
+	var unmatched []string
+
+	seen := map[string]bool{}
+
+	for _, pattern := range patterns {
+		if exact {
+			rType, err := resource.SupportedType(pattern)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			if !seen[rType] {
+				seen[rType] = true
+				result = append(result, rType)
+			}
+
+			continue
+		}
+
+		matched, err := resource.MatchSupportedTypes(pattern)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid glob pattern %s: %s", pattern, err)
+		}
+
+		if len(matched) == 0 {
+			unmatched = append(unmatched, pattern)
+		}
+
+		for _, rType := range matched {
+			if !seen[rType] {
+				seen[rType] = true
+				result = append(result, rType)
+			}
+		}
+	}
+
+	return result, unmatched, nil
+}
+
+// ListType lists the resources of the given type across all clients, filters them (see opts.Filter) and
+// fetches their state via the provider of each client if some of the attributes are in the state of the type.
+// Returns the resources sorted by region and ID, and which of the attributes (including the ones filtered by)
+// are in the state of the type.
+//
+// If listing takes longer than opts.PerTypeTimeout (or the context is done), the requests in flight are
+// canceled and only the resources of clients that have been completed so far are returned. Errors of single
+// clients don't stop listing the other clients (see opts.OnError); an error is returned if the type can't be
+// listed at all.
+func ListType(ctx context.Context, rType string, clients map[util.AWSClientKey]aws.Client,
+	providers map[util.AWSClientKey]provider.TerraformProvider, opts Options) ([]aws.Resource, map[string]bool, error) {
+	var mu sync.Mutex
+	var resources []aws.Resource
+	var errs []string
+
+	// errors and warnings are reported one after another, so that they can be printed as they occur
+	var reportMu sync.Mutex
+
+	reportError := func(format string, a ...interface{}) {
+		reportMu.Lock()
+		defer reportMu.Unlock()
+
+		err := fmt.Errorf(format, a...)
+		if opts.OnError == nil {
+			errs = append(errs, err.Error())
+			return
+		}
+
+		opts.OnError(err)
+	}
+
+	warn := func(format string, a ...interface{}) {
+		reportMu.Lock()
+		defer reportMu.Unlock()
+
+		msg := fmt.Sprintf(format, a...)
+		if opts.OnWarning == nil {
+			log.WithField("type", rType).Debug(msg)
+			return
+		}
+
+		opts.OnWarning(msg)
+	}
+
+	var filters []AttributeFilter
+	// the state of resources must also be fetched for attributes that are only filtered by
+	requiredAttributes := append([]string{}, opts.Attributes...)
+	for _, f := range opts.Filter.Attributes {
+		if f.AppliesTo(rType) {
+			filters = append(filters, f)
+			requiredAttributes = append(requiredAttributes, f.Attribute)
+		}
+	}
+
+	if opts.Filter.Orphans {
+		if orphanAttr, ok := resource.OrphanAttribute(rType); ok {
+			requiredAttributes = append(requiredAttributes, orphanAttr)
+		}
+	}
+
+	if len(opts.Filter.ExcludeTags) > 0 || len(opts.Filter.Tags) > 0 || opts.Filter.Untagged {
+		// filter by the tags of the state, which may be more up-to-date than the ones returned by the list API,
+		// if returned at all
+		requiredAttributes = append(requiredAttributes, "tags")
+	}
+
+	// the attributes in the state are the same for all clients, as they depend only on the schema of the type
+	// (the states of single clients may still not be fetched, see PreferListAPI)
+	hasAttrs, err := typeAttributes(requiredAttributes, rType, providers)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: failed to check if resource type has attribute: %s", rType, err)
+	}
+
+	taggedResources := opts.TaggedResources
+	if taggedResources == nil {
+		taggedResources = NewTaggedResources()
+	}
+
+	parallel := opts.Parallel
+	if parallel == 0 {
+		parallel = DefaultParallel
+	}
+
+	// canceled once the per-type timeout is exceeded, which stops all work on this type
+	typeCtx, cancel := context.WithCancel(ctx)
+	if opts.PerTypeTimeout > 0 {
+		typeCtx, cancel = context.WithTimeout(ctx, opts.PerTypeTimeout)
+	}
+	defer cancel()
+
+	// number of resources listed across all clients, before any filters are applied
+	listed := 0
+	warned := false
+
+	var wg sync.WaitGroup
+	var onResourcesMu sync.Mutex
+
+	sem := internal.NewSemaphore(parallel)
+
+	for key, client := range clients {
+		wg.Add(1)
+
+		go func(key util.AWSClientKey, client aws.Client) {
+			defer wg.Done()
+
+			sem.Acquire()
+			defer sem.Release()
+
+			if typeCtx.Err() != nil {
+				return
+			}
+
+			err := client.SetAccountID()
+			if err != nil {
+				reportError("%s (profile=%s, region=%s): %s", rType, key.Profile, key.Region, err)
+				return
+			}
+
+			var progress aws.ProgressFunc
+			if opts.Progress != nil {
+				progress = opts.Progress(key)
+			}
+
+			opts.AccountSem.Acquire(client.AccountID)
+			res, err := aws.ListResourcesByType(typeCtx, &client, rType, progress)
+			opts.AccountSem.Release(client.AccountID)
+
+			if err != nil {
+				if typeCtx.Err() != nil {
+					// interrupted or timed out, which is reported once for the type
+					return
+				}
+
+				if opts.IgnoreAccessDenied && aws.IsAccessDenied(err) {
+					log.WithFields(log.Fields{
+						"type":    rType,
+						"profile": key.Profile,
+						"region":  key.Region}).WithError(err).Debug("access denied")
+
+					return
+				}
+
+				reportError("%s (profile=%s, region=%s): %s", rType, key.Profile, key.Region, err)
+				return
+			}
+
+			mu.Lock()
+			listed += len(res)
+			warnNow := opts.WarnOver > 0 && listed > opts.WarnOver && !warned
+			if warnNow {
+				warned = true
+			}
+			mu.Unlock()
+
+			if warnNow {
+				warn("more than %d resources of type %s found, consider using filters as fetching "+
+					"attributes can be slow and the output large", opts.WarnOver, rType)
+			}
+
+			if opts.Filter.ResourceGroupARNs != nil {
+				res = filterByARNs(rType, res, opts.Filter.ResourceGroupARNs[key])
+			}
+
+			if opts.Filter.IDPrefix != "" {
+				res = filterByIDPrefix(res, opts.Filter.IDPrefix)
+			}
+
+			if opts.Filter.ID != nil {
+				// before fetching states, so that filtered out resources don't slow down listing
+				res = filterByIDRegexp(res, opts.Filter.ID)
+			}
+
+			if !opts.Filter.CreatedAfter.IsZero() || !opts.Filter.CreatedBefore.IsZero() {
+				res = filterByCreated(res, opts.Filter.CreatedAfter, opts.Filter.CreatedBefore,
+					opts.Filter.KeepUnknownCreated)
+			}
+
+			terraformProvider, ok := providers[key]
+			if ok && len(hasAttrs) > 0 {
+				fetchAttrs := hasAttrs
+				if opts.PreferListAPI {
+					fetchAttrs, err = resource.HasAttributes(withoutListAPIAttributes(requiredAttributes, res),
+						rType, &terraformProvider)
+					if err != nil {
+						reportError("%s: failed to check if resource type has attribute: %s", rType, err)
+						return
+					}
+				}
+
+				if len(fetchAttrs) > 0 {
+					// for performance reasons:
+					// only fetch state if some attributes are needed for this resource type
+					res = resource.GetStates(typeCtx, res, providers, opts.AccountSem, opts.RateLimiter,
+						opts.StateCache)
+				}
+			}
+
+			if containsString(requiredAttributes, "tags") && !hasAttrs["tags"] {
+				// the Terraform schema of this type doesn't expose tags, so look up the tags
+				// of resources without tags from the list API via the Resource Groups Tagging API
+				tagsByARN, err := taggedResources.get(ctx, key, &client)
+				if err != nil {
+					warn("tags of resources whose state doesn't contain tags are unknown "+
+						"(profile=%s, region=%s): %s", key.Profile, key.Region, err)
+				}
+
+				aws.SetTaggedResourceTags(rType, res, tagsByARN)
+			}
+
+			res = filterByAttributes(res, filters)
+
+			if opts.Filter.Orphans {
+				res, err = filterOrphans(typeCtx, rType, &client, res)
+				if err != nil {
+					reportError("%s (profile=%s, region=%s): %s", rType, key.Profile, key.Region, err)
+					return
+				}
+			}
+
+			if len(opts.Filter.Tags) > 0 {
+				res = filterByTags(res, opts.Filter.Tags)
+			}
+
+			if len(opts.Filter.ExcludeTags) > 0 {
+				res = excludeByTags(res, opts.Filter.ExcludeTags)
+			}
+
+			if opts.Filter.Untagged {
+				res = filterUntagged(res)
+			}
+
+			// once timed out (or interrupted), the resources of this client are discarded
+			// and must not be passed to OnResources
+			onResourcesMu.Lock()
+			defer onResourcesMu.Unlock()
+
+			if typeCtx.Err() != nil {
+				return
+			}
+
+			if opts.OnResources != nil {
+				opts.OnResources(rType, res, hasAttrs)
+			}
+
+			if opts.Stream {
+				return
+			}
+
+			mu.Lock()
+			resources = append(resources, res...)
+			mu.Unlock()
+		}(key, client)
+	}
+
+	wg.Wait()
+
+	if typeCtx.Err() != nil && ctx.Err() == nil {
+		warn("listing %s timed out after %s, returning partial results only", rType, opts.PerTypeTimeout)
+	}
+
+	// the order must not depend on the order in which the clients have been listed
+	sort.SliceStable(resources, func(i, j int) bool {
+		if resources[i].Region != resources[j].Region {
+			return resources[i].Region < resources[j].Region
+		}
+
+		return resources[i].ID < resources[j].ID
+	})
+
+	if len(errs) > 0 {
+		sort.Strings(errs)
+
+		return resources, hasAttrs, fmt.Errorf("%d error(s) occurred: %s", len(errs), strings.Join(errs, "; "))
+	}
+
+	return resources, hasAttrs, nil
+}
+
+// typeAttributes returns the given attributes that are in the state of the given resource type,
+// which is looked up via any of the given providers (none if there is no provider).
+func typeAttributes(attributes []string, rType string,
+	providers map[util.AWSClientKey]provider.TerraformProvider) (map[string]bool, error) {
+	for _, p := range providers {
+		return resource.HasAttributes(attributes, rType, &p)
+	}
+
+	return nil, nil
+}
+
+// containsString returns true if the given list contains the string.
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
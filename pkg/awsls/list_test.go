@@ -0,0 +1,112 @@
+package awsls_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	awsSDK "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/external"
+	"github.com/jckuester/awsls/aws"
+	"github.com/jckuester/awsls/pkg/awsls"
+	"github.com/jckuester/awsls/test"
+	"github.com/jckuester/awsls/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestList_invalidOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    awsls.Options
+		wantErr string
+	}{
+		{
+			name:    "no types",
+			opts:    awsls.Options{},
+			wantErr: "no resource type given",
+		},
+		{
+			name:    "negative parallel",
+			opts:    awsls.Options{Types: []string{"aws_instance"}, Parallel: -1},
+			wantErr: "parallel must be positive: -1",
+		},
+		{
+			name:    "invalid glob pattern",
+			opts:    awsls.Options{Types: []string{"aws_[instance"}},
+			wantErr: "invalid glob pattern aws_[instance",
+		},
+		{
+			name:    "unsupported type",
+			opts:    awsls.Options{Types: []string{"aws_foo"}},
+			wantErr: "no resource type found: aws_foo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := awsls.List(context.Background(), tc.opts)
+			require.Error(t, err)
+
+			assert.Contains(t, err.Error(), tc.wantErr)
+		})
+	}
+}
+
+func TestList_canceledContext(t *testing.T) {
+	err := test.UnsetAWSEnvs()
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	got, err := awsls.List(ctx, awsls.Options{Types: []string{"aws_instance"}, Regions: []string{"us-test-1"}})
+	require.Equal(t, context.Canceled, err)
+
+	assert.Empty(t, got)
+}
+
+func TestList_keepsResourcesOnCancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.FormValue("Action") {
+		case "GetCallerIdentity":
+			_, _ = w.Write([]byte(`<GetCallerIdentityResponse><GetCallerIdentityResult>` +
+				`<Account>000000000000</Account></GetCallerIdentityResult></GetCallerIdentityResponse>`))
+		case "DescribeSubnets":
+			_, _ = w.Write([]byte(`<DescribeSubnetsResponse><subnetSet>` +
+				`<item><subnetId>subnet-1</subnetId><ownerId>000000000000</ownerId></item>` +
+				`<item><subnetId>subnet-2</subnetId><ownerId>000000000000</ownerId></item>` +
+				`</subnetSet></DescribeSubnetsResponse>`))
+		default:
+			t.Errorf("unexpected request: %s", r.FormValue("Action"))
+		}
+	}))
+	defer server.Close()
+
+	clients, err := util.NewAWSClientPool(nil, []string{"us-test-1"},
+		external.WithCredentialsProvider{CredentialsProvider: awsSDK.NewStaticCredentialsProvider("test", "test", "")},
+		util.WithEndpointURL(server.URL),
+		util.WithMaxRetries(0))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	got, err := awsls.List(ctx, awsls.Options{
+		Types:   []string{"aws_subnet", "aws_vpc"},
+		Exact:   true,
+		Clients: clients,
+		Filter:  awsls.Filter{ID: regexp.MustCompile("-2$")},
+		OnResources: func(rType string, resources []aws.Resource, hasAttrs map[string]bool) {
+			// interrupted before listing the next type
+			cancel()
+		},
+	})
+	require.Equal(t, context.Canceled, err)
+
+	require.Len(t, got, 1)
+	assert.Equal(t, "subnet-2", got[0].ID)
+	assert.Equal(t, "000000000000", got[0].AccountID)
+}
@@ -0,0 +1,54 @@
+package awsls
+
+import (
+	"context"
+	"sync"
+
+	"github.com/jckuester/awsls/aws"
+	"github.com/jckuester/awsls/util"
+)
+
+// TaggedResources caches the tags of all resources per AWS client, as returned by the Resource Groups Tagging API.
+// They are looked up once per client, and only for types whose Terraform state doesn't contain tags.
+// All methods can be called concurrently and on a nil cache, which never looks up any tags.
+type TaggedResources struct {
+	mu       sync.Mutex
+	byClient map[util.AWSClientKey]*clientTags
+}
+
+type clientTags struct {
+	once      sync.Once
+	tagsByARN map[string]map[string]string
+}
+
+// NewTaggedResources returns an empty cache.
+func NewTaggedResources() *TaggedResources {
+	return &TaggedResources{
+		byClient: map[util.AWSClientKey]*clientTags{},
+	}
+}
+
+// get returns the tags of all resources of the given client by ARN. The error of a failed lookup
+// is returned once, after which the client is treated as having no tagged resources.
+func (t *TaggedResources) get(ctx context.Context, key util.AWSClientKey,
+	client *aws.Client) (map[string]map[string]string, error) {
+	if t == nil {
+		return nil, nil
+	}
+
+	t.mu.Lock()
+	c, ok := t.byClient[key]
+	if !ok {
+		c = &clientTags{}
+		t.byClient[key] = c
+	}
+	t.mu.Unlock()
+
+	var err error
+
+	c.once.Do(func() {
+		c.tagsByARN, err = client.ListTaggedResources(ctx)
+	})
+
+	return c.tagsByARN, err
+}
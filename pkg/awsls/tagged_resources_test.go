@@ -0,0 +1,55 @@
+package awsls_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	awsSDK "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/external"
+	"github.com/jckuester/awsls/pkg/awsls"
+	"github.com/jckuester/awsls/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaggedResources_get(t *testing.T) {
+	lookups := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lookups++
+
+		_, _ = w.Write([]byte(`{"ResourceTagMappingList": [{"ResourceARN": "arn:aws:sqs:us-test-1:123456789012:foo",` +
+			` "Tags": [{"Key": "env", "Value": "prod"}]}]}`))
+	}))
+	defer server.Close()
+
+	clients, err := util.NewAWSClientPool(nil, []string{"us-test-1"},
+		external.WithCredentialsProvider{CredentialsProvider: awsSDK.NewStaticCredentialsProvider("test", "test", "")},
+		util.WithEndpointURL(server.URL),
+		util.WithMaxRetries(0))
+	require.NoError(t, err)
+
+	key := util.AWSClientKey{Region: "us-test-1"}
+	client := clients[key]
+
+	cache := awsls.NewTaggedResources()
+
+	want := map[string]map[string]string{"arn:aws:sqs:us-test-1:123456789012:foo": {"env": "prod"}}
+
+	for i := 0; i < 2; i++ {
+		got, err := cache.Get(context.Background(), key, &client)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+
+	assert.Equal(t, 1, lookups, "tags must be looked up once per client")
+
+	var disabled *awsls.TaggedResources
+
+	got, err := disabled.Get(context.Background(), key, &client)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+	assert.Equal(t, 1, lookups)
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/jckuester/awsls/aws"
+	"github.com/jckuester/awsls/pb"
+)
+
+// writeResourcesProtobuf writes each resource as length-delimited protobuf message (see pb/resource.proto),
+// i.e., prefixed by its size as varint, so that consumers can decode the stream message by message.
+func writeResourcesProtobuf(out io.Writer, resources []aws.Resource, hasAttrs map[string]bool,
+	attributes []string) error {
+	for i := range resources {
+		msg, err := newProtobufResource(&resources[i], hasAttrs, attributes)
+		if err != nil {
+			return err
+		}
+
+		b, err := proto.Marshal(msg)
+		if err != nil {
+			return fmt.Errorf("failed to marshal resource %s: %s", resources[i].ID, err)
+		}
+
+		_, err = out.Write(append(proto.EncodeVarint(uint64(len(b))), b...))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// newProtobufResource returns the protobuf message of the given resource.
+// Attributes that are not supported by the resource type are left out.
+func newProtobufResource(r *aws.Resource, hasAttrs map[string]bool, attributes []string) (*pb.Resource, error) {
+	result := &pb.Resource{
+		Type:       r.Type,
+		Id:         r.ID,
+		Profile:    r.Profile,
+		Region:     r.Region,
+		AccountId:  r.AccountID,
+		Attributes: map[string]string{},
+	}
+
+	if r.CreatedAt != nil {
+		created, err := ptypes.TimestampProto(*r.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("invalid creation time of resource %s: %s", r.ID, err)
+		}
+
+		result.Created = created
+	}
+
+	for _, attr := range attributes {
+		v, ok := attributeValue(attr, r, hasAttrs)
+		if ok {
+			result.Attributes[attr] = v
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jckuester/awsls/resource"
+	"github.com/jckuester/awsls/util"
+	"github.com/jckuester/terradozer/pkg/provider"
+)
+
+// attributeSupport maps each resource type to the attributes a provider version supports for it;
+// the map of a resource type is nil if the provider version doesn't support the type at all.
+type attributeSupport map[string]map[string]bool
+
+// compareProviderVersions launches a Terraform AWS Provider of each given version and prints the resource types
+// and attributes whose support differs between the versions.
+func compareProviderVersions(out io.Writer, versions []string, rTypes, attributes []string,
+	clientKey util.AWSClientKey, providerDir string, providerConfig util.ProviderConfig) error {
+	support := make([]attributeSupport, 0, len(versions))
+
+	for _, version := range versions {
+		providers, errs := util.NewProviderPool([]util.AWSClientKey{clientKey}, version, providerDir,
+			10*time.Second, providerConfig)
+		if err, ok := errs[clientKey]; ok {
+			return fmt.Errorf("provider version %s: %s", version, err)
+		}
+
+		p := providers[clientKey]
+		support = append(support, providerAttributeSupport(rTypes, attributes, &p))
+		_ = p.Close()
+	}
+
+	printSupportDiff(out, versions, support, rTypes, attributes)
+
+	return nil
+}
+
+// providerAttributeSupport returns which of the given resource types and attributes the provider supports.
+func providerAttributeSupport(rTypes, attributes []string, p *provider.TerraformProvider) attributeSupport {
+	result := attributeSupport{}
+
+	for _, rType := range rTypes {
+		attrs, err := resource.HasAttributes(attributes, rType, p)
+		if err != nil {
+			result[rType] = nil
+			continue
+		}
+
+		result[rType] = attrs
+	}
+
+	return result
+}
+
+// printSupportDiff prints a table of the resource types and attributes whose support differs between
+// the provider versions (an attribute column of "-" stands for the resource type itself).
+func printSupportDiff(out io.Writer, versions []string, support []attributeSupport, rTypes, attributes []string) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	_, _ = fmt.Fprintf(w, "TYPE\tATTRIBUTE\t%s\n", strings.Join(versions, "\t"))
+
+	differences := 0
+
+	for _, rType := range rTypes {
+		typeSupport := make([]bool, len(support))
+		for i := range support {
+			typeSupport[i] = support[i][rType] != nil
+		}
+
+		if !allEqual(typeSupport) {
+			_, _ = fmt.Fprintf(w, "%s\t-\t%s\n", rType, supportColumns(typeSupport))
+			differences++
+
+			continue
+		}
+
+		for _, attr := range attributes {
+			attrSupport := make([]bool, len(support))
+			for i := range support {
+				attrSupport[i] = support[i][rType][attr]
+			}
+
+			if !allEqual(attrSupport) {
+				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", rType, attr, supportColumns(attrSupport))
+				differences++
+			}
+		}
+	}
+
+	_ = w.Flush()
+
+	_, _ = fmt.Fprintf(out, "\n%d difference(s) in support between provider versions %s\n",
+		differences, strings.Join(versions, ", "))
+}
+
+func allEqual(values []bool) bool {
+	for _, v := range values {
+		if v != values[0] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func supportColumns(values []bool) string {
+	columns := make([]string, 0, len(values))
+	for _, v := range values {
+		if v {
+			columns = append(columns, "yes")
+		} else {
+			columns = append(columns, "no")
+		}
+	}
+
+	return strings.Join(columns, "\t")
+}
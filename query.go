@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/jckuester/awsls/pkg/awsls"
+	"gopkg.in/yaml.v2"
+)
+
+// query is a reusable inventory query loaded via --query from a YAML file, for example:
+//
+//	types: [aws_instance, "aws_iam_*"]
+//	attributes: [instance_type, tags]
+//	attribute_filters:
+//	  - types: [aws_instance]
+//	    attribute: instance_type
+//	    values: [t3.micro, t3.small]
+//	tags: [Team=platform, Owner]
+//	exclude_tags: [Environment=dev]
+//	created_after: 2020-01-01
+//	created_before: 2021-01-01T00:00:00Z
+type query struct {
+	// Types are glob patterns of the resource types to list.
+	Types []string `yaml:"types"`
+	// Attributes are the attributes to print for each resource.
+	Attributes       []string               `yaml:"attributes"`
+	AttributeFilters []queryAttributeFilter `yaml:"attribute_filters"`
+	// Tags are key=value (or key only) tags that resources must all carry; key= means the tag must be absent.
+	Tags []string `yaml:"tags"`
+	// ExcludeTags are key=value (or key only) tags of resources to exclude.
+	ExcludeTags []string `yaml:"exclude_tags"`
+	// CreatedAfter and CreatedBefore are given in RFC3339 format or as date (e.g., 2020-01-01).
+	CreatedAfter  string `yaml:"created_after"`
+	CreatedBefore string `yaml:"created_before"`
+}
+
+// queryAttributeFilter keeps only resources of the given types whose attribute equals one of the values.
+type queryAttributeFilter struct {
+	Types     []string `yaml:"types"`
+	Attribute string   `yaml:"attribute"`
+	Values    []string `yaml:"values"`
+}
+
+// loadQuery reads a query from the given YAML file. Unknown keys are an error.
+func loadQuery(path string) (*query, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read query file: %s", err)
+	}
+
+	var q query
+
+	err = yaml.UnmarshalStrict(data, &q)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse query file %s: %s", path, err)
+	}
+
+	return &q, nil
+}
+
+// apply adds the filters of the query to the given options.
+func (q *query) apply(opts *options) error {
+	for _, f := range q.AttributeFilters {
+		if f.Attribute == "" {
+			return fmt.Errorf("attribute filter without attribute")
+		}
+
+		opts.attributeFilters = append(opts.attributeFilters, awsls.AttributeFilter{
+			Types:     f.Types,
+			Attribute: f.Attribute,
+			Values:    f.Values,
+		})
+	}
+
+	for _, t := range q.Tags {
+		f, err := awsls.ParseTagCondition(t)
+		if err != nil {
+			return fmt.Errorf("invalid tag: %s", err)
+		}
+
+		opts.tags = append(opts.tags, f)
+	}
+
+	for _, t := range q.ExcludeTags {
+		f, err := awsls.ParseTagFilter(t)
+		if err != nil {
+			return fmt.Errorf("invalid exclude tag: %s", err)
+		}
+
+		opts.excludeTags = append(opts.excludeTags, f)
+	}
+
+	if q.CreatedAfter != "" {
+		t, err := parseTime(q.CreatedAfter)
+		if err != nil {
+			return fmt.Errorf("invalid created_after: %s", err)
+		}
+
+		opts.createdAfter = t
+	}
+
+	if q.CreatedBefore != "" {
+		t, err := parseTime(q.CreatedBefore)
+		if err != nil {
+			return fmt.Errorf("invalid created_before: %s", err)
+		}
+
+		opts.createdBefore = t
+	}
+
+	return nil
+}
+
+// parseTime parses a time given in RFC3339 format or as date (e.g., 2020-01-01, interpreted as UTC).
+func parseTime(s string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, s)
+	if err == nil {
+		return t, nil
+	}
+
+	t, err = time.Parse("2006-01-02", s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("must be in RFC3339 format or a date (e.g., 2020-01-01): %s", s)
+	}
+
+	return t, nil
+}
@@ -0,0 +1,118 @@
+package resource
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jckuester/awsls/aws"
+	goHomeDir "github.com/mitchellh/go-homedir"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// StateCache stores the Terraform states fetched by GetStates on disk, so that repeated runs only need to fetch
+// the states of resources that aren't cached or whose cached state is older than the TTL.
+// A nil *StateCache caches nothing.
+type StateCache struct {
+	dir string
+	ttl time.Duration
+	// refresh ignores all cached states, but still caches the fetched ones
+	refresh bool
+}
+
+// cachedState is the file format of a cached state.
+type cachedState struct {
+	Type  json.RawMessage `json:"type"`
+	State json.RawMessage `json:"state"`
+}
+
+// NewStateCache returns a cache that stores states in the given directory (which is created if needed) for ttl.
+// If refresh is true, cached states are ignored (i.e., all states are fetched again and cached anew).
+func NewStateCache(dir string, ttl time.Duration, refresh bool) (*StateCache, error) {
+	expandedDir, err := goHomeDir.Expand(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.MkdirAll(expandedDir, 0700)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %s", err)
+	}
+
+	return &StateCache{dir: expandedDir, ttl: ttl, refresh: refresh}, nil
+}
+
+// path returns the file of the cached state of the given resource, keyed by profile, region, type and ID.
+func (c *StateCache) path(r *aws.Resource) string {
+	key := sha256.Sum256([]byte(r.Profile + "\x00" + r.Region + "\x00" + r.Type + "\x00" + r.ID))
+
+	return filepath.Join(c.dir, hex.EncodeToString(key[:])+".json")
+}
+
+// Get returns the cached state of the given resource, if there is a fresh one.
+func (c *StateCache) Get(r *aws.Resource) (*cty.Value, bool) {
+	if c == nil || c.refresh {
+		return nil, false
+	}
+
+	path := c.path(r)
+
+	info, err := os.Stat(path)
+	if err != nil || time.Since(info.ModTime()) > c.ttl {
+		return nil, false
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+
+	var cached cachedState
+
+	err = json.Unmarshal(data, &cached)
+	if err != nil {
+		return nil, false
+	}
+
+	ty, err := ctyjson.UnmarshalType(cached.Type)
+	if err != nil {
+		return nil, false
+	}
+
+	state, err := ctyjson.Unmarshal(cached.State, ty)
+	if err != nil {
+		return nil, false
+	}
+
+	return &state, true
+}
+
+// Put caches the given state of a resource.
+func (c *StateCache) Put(r *aws.Resource, state cty.Value) error {
+	if c == nil {
+		return nil
+	}
+
+	ty, err := ctyjson.MarshalType(state.Type())
+	if err != nil {
+		return err
+	}
+
+	value, err := ctyjson.Marshal(state, state.Type())
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(cachedState{Type: ty, State: value})
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(c.path(r), data, 0600)
+}
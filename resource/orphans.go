@@ -0,0 +1,110 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jckuester/awsls/aws"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// orphanAttributes maps resource types to the attribute of their Terraform state that is empty
+// if a resource isn't used by (i.e., attached to or associated with) any other resource.
+//
+//nolint:gochecknoglobals
+var orphanAttributes = map[string]string{
+	// Elastic IP not associated with an instance or network interface
+	"aws_eip": "association_id",
+	// classic load balancer without any registered instances
+	"aws_elb": "instances",
+	// network interface not attached to an instance
+	"aws_network_interface": "attachment",
+}
+
+// orphanLookups maps resource types, for which the state of the Terraform AWS Provider doesn't tell
+// if they are attached to an instance or network interface, to a lookup of their orphaned resources
+// via the API of the service.
+//
+//nolint:gochecknoglobals
+var orphanLookups = map[string]func(ctx context.Context, client *aws.Client) (map[string]bool, error){
+	// EBS volume not attached to an instance (i.e., in state available)
+	"aws_ebs_volume": func(ctx context.Context, client *aws.Client) (map[string]bool, error) {
+		return client.ListAvailableVolumeIDs(ctx)
+	},
+	// security group not associated with any network interface
+	"aws_security_group": func(ctx context.Context, client *aws.Client) (map[string]bool, error) {
+		return client.ListUnusedSecurityGroupIDs(ctx)
+	},
+}
+
+// HasOrphanCondition returns true if it's known when a resource of the given type is orphaned,
+// either by its state (see OrphanAttribute) or via the API of the service (see LookupOrphanIDs).
+func HasOrphanCondition(terraformType string) bool {
+	_, ok := orphanAttributes[terraformType]
+	_, lookup := orphanLookups[terraformType]
+
+	return ok || lookup
+}
+
+// LookupOrphanIDs returns the IDs of the orphaned resources of the given type via the API of the service,
+// or false if the orphan condition of the type depends on the state (see IsOrphan).
+func LookupOrphanIDs(ctx context.Context, terraformType string, client *aws.Client) (map[string]bool, bool, error) {
+	lookup, ok := orphanLookups[terraformType]
+	if !ok {
+		return nil, false, nil
+	}
+
+	ids, err := lookup(ctx, client)
+
+	return ids, true, err
+}
+
+// OrphanAttribute returns the attribute that determines if a resource of the given type is orphaned,
+// or false if there is no known orphan condition for the type.
+func OrphanAttribute(terraformType string) (string, bool) {
+	attr, ok := orphanAttributes[terraformType]
+
+	return attr, ok
+}
+
+// IsOrphan returns true if the given resource isn't used by any other resource.
+// The state of the resource must have been fetched before.
+func IsOrphan(r *aws.Resource) (bool, error) {
+	attr, ok := OrphanAttribute(r.Type)
+	if !ok {
+		return false, fmt.Errorf("no orphan condition known for resource type: %s", r.Type)
+	}
+
+	if r.UpdatableResource == nil || r.State() == nil {
+		return false, fmt.Errorf("state is nil")
+	}
+
+	state := r.State()
+
+	if state.IsNull() || !state.IsWhollyKnown() || !state.CanIterateElements() {
+		return false, fmt.Errorf("state is null or not wholly known")
+	}
+
+	attrValue, ok := state.AsValueMap()[attr]
+	if !ok {
+		return false, fmt.Errorf("attribute not found: %s", attr)
+	}
+
+	return isEmpty(attrValue), nil
+}
+
+// isEmpty returns true if the given value is null, an empty string, or an empty collection.
+func isEmpty(v cty.Value) bool {
+	if v.IsNull() {
+		return true
+	}
+
+	switch {
+	case v.Type() == cty.String:
+		return v.AsString() == ""
+	case v.Type().IsListType() || v.Type().IsSetType() || v.Type().IsMapType():
+		return v.LengthInt() == 0
+	default:
+		return false
+	}
+}
@@ -0,0 +1,167 @@
+package resource_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	awsSDK "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/external"
+	"github.com/jckuester/awsls/aws"
+	"github.com/jckuester/awsls/resource"
+	"github.com/jckuester/awsls/util"
+	terradozerRes "github.com/jckuester/terradozer/pkg/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestIsOrphan(t *testing.T) {
+	tests := []struct {
+		name    string
+		rType   string
+		state   cty.Value
+		want    bool
+		wantErr bool
+	}{
+		{
+			name:  "unassociated Elastic IP",
+			rType: "aws_eip",
+			state: cty.ObjectVal(map[string]cty.Value{
+				"association_id": cty.StringVal(""),
+			}),
+			want: true,
+		},
+		{
+			name:  "associated Elastic IP",
+			rType: "aws_eip",
+			state: cty.ObjectVal(map[string]cty.Value{
+				"association_id": cty.StringVal("eipassoc-1234"),
+			}),
+			want: false,
+		},
+		{
+			name:  "unattached network interface",
+			rType: "aws_network_interface",
+			state: cty.ObjectVal(map[string]cty.Value{
+				"attachment": cty.SetValEmpty(cty.Object(map[string]cty.Type{
+					"instance": cty.String,
+				})),
+			}),
+			want: true,
+		},
+		{
+			name:  "attached network interface",
+			rType: "aws_network_interface",
+			state: cty.ObjectVal(map[string]cty.Value{
+				"attachment": cty.SetVal([]cty.Value{
+					cty.ObjectVal(map[string]cty.Value{
+						"instance": cty.StringVal("i-1234"),
+					}),
+				}),
+			}),
+			want: false,
+		},
+		{
+			name:    "instance profile with null role",
+			rType:   "aws_iam_instance_profile",
+			state:   cty.ObjectVal(map[string]cty.Value{"role": cty.NullVal(cty.String)}),
+			wantErr: true,
+		},
+		{
+			name:    "no orphan condition for resource type",
+			rType:   "aws_vpc",
+			state:   cty.ObjectVal(map[string]cty.Value{}),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &aws.Resource{
+				Type:              tt.rType,
+				ID:                "foo",
+				UpdatableResource: terradozerRes.NewWithState(tt.rType, "foo", nil, &tt.state),
+			}
+
+			got, err := resource.IsOrphan(r)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLookupOrphanIDs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.FormValue("Action") {
+		case "DescribeVolumes":
+			if r.FormValue("Filter.1.Name") != "status" || r.FormValue("Filter.1.Value.1") != "available" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			_, _ = w.Write([]byte(`<DescribeVolumesResponse><volumeSet>` +
+				`<item><volumeId>vol-1</volumeId></item></volumeSet></DescribeVolumesResponse>`))
+		case "DescribeSecurityGroups":
+			_, _ = w.Write([]byte(`<DescribeSecurityGroupsResponse><securityGroupInfo>` +
+				`<item><groupId>sg-default</groupId><groupName>default</groupName></item>` +
+				`<item><groupId>sg-used</groupId><groupName>web</groupName></item>` +
+				`<item><groupId>sg-unused</groupId><groupName>old</groupName></item>` +
+				`</securityGroupInfo></DescribeSecurityGroupsResponse>`))
+		case "DescribeNetworkInterfaces":
+			_, _ = w.Write([]byte(`<DescribeNetworkInterfacesResponse><networkInterfaceSet><item><groupSet>` +
+				`<item><groupId>sg-used</groupId></item></groupSet></item>` +
+				`</networkInterfaceSet></DescribeNetworkInterfacesResponse>`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	clients, err := util.NewAWSClientPool(nil, []string{"us-test-1"},
+		external.WithCredentialsProvider{CredentialsProvider: awsSDK.NewStaticCredentialsProvider("test", "test", "")},
+		util.WithEndpointURL(server.URL),
+		util.WithMaxRetries(0))
+	require.NoError(t, err)
+
+	client := clients[util.AWSClientKey{Region: "us-test-1"}]
+
+	tests := []struct {
+		name       string
+		rType      string
+		want       map[string]bool
+		wantLookup bool
+	}{
+		{
+			name:       "unattached EBS volumes",
+			rType:      "aws_ebs_volume",
+			want:       map[string]bool{"vol-1": true},
+			wantLookup: true,
+		},
+		{
+			name:       "security groups not associated with any network interface",
+			rType:      "aws_security_group",
+			want:       map[string]bool{"sg-unused": true},
+			wantLookup: true,
+		},
+		{
+			name:  "orphan condition depends on state",
+			rType: "aws_eip",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, lookup, err := resource.LookupOrphanIDs(context.Background(), tt.rType, &client)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantLookup, lookup)
+			assert.Equal(t, tt.want, got)
+			assert.True(t, resource.HasOrphanCondition(tt.rType))
+		})
+	}
+}
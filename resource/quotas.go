@@ -0,0 +1,34 @@
+package resource
+
+// Quota identifies a quota in the Service Quotas API that limits the number of resources of a type.
+type Quota struct {
+	ServiceCode string
+	QuotaCode   string
+	// Global is true if the quota limits the number of resources per account across all regions.
+	Global bool
+}
+
+// quotas maps resource types to the quota that limits their number per account and region (or per account).
+//
+//nolint:gochecknoglobals
+var quotas = map[string]Quota{
+	"aws_autoscaling_group":    {"autoscaling", "L-CDE20ADC", false},
+	"aws_cloudformation_stack": {"cloudformation", "L-0485CB21", false},
+	"aws_db_instance":          {"rds", "L-7B6409FD", false},
+	"aws_dynamodb_table":       {"dynamodb", "L-F98FE922", false},
+	"aws_eip":                  {"ec2", "L-0263D0A3", false},
+	"aws_internet_gateway":     {"vpc", "L-A4707A72", false},
+	"aws_launch_configuration": {"autoscaling", "L-6B80B8FA", false},
+	"aws_network_interface":    {"vpc", "L-DF5E4CA3", false},
+	"aws_s3_bucket":            {"s3", "L-DC2B2D3D", true},
+	"aws_security_group":       {"vpc", "L-E79EC296", false},
+	"aws_vpc":                  {"vpc", "L-F678F1CE", false},
+}
+
+// GetQuota returns the quota that limits the number of resources of the given type,
+// or false if no such quota is known.
+func GetQuota(terraformType string) (Quota, bool) {
+	q, ok := quotas[terraformType]
+
+	return q, ok
+}
@@ -0,0 +1,42 @@
+package resource_test
+
+import (
+	"testing"
+
+	"github.com/jckuester/awsls/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetQuota(t *testing.T) {
+	tests := []struct {
+		name   string
+		rType  string
+		want   resource.Quota
+		wantOk bool
+	}{
+		{
+			name:   "quota per account and region",
+			rType:  "aws_vpc",
+			want:   resource.Quota{ServiceCode: "vpc", QuotaCode: "L-F678F1CE"},
+			wantOk: true,
+		},
+		{
+			name:   "quota per account",
+			rType:  "aws_s3_bucket",
+			want:   resource.Quota{ServiceCode: "s3", QuotaCode: "L-DC2B2D3D", Global: true},
+			wantOk: true,
+		},
+		{
+			name:  "no known quota",
+			rType: "aws_iam_role",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := resource.GetQuota(tt.rType)
+
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -1,9 +1,11 @@
 package resource
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
-	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -11,7 +13,9 @@ import (
 	"github.com/jckuester/awsls/util"
 
 	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
 	"github.com/zclconf/go-cty/cty/gocty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
 
 	"github.com/apex/log"
 	"github.com/fatih/color"
@@ -20,6 +24,7 @@ import (
 	"github.com/jckuester/awsls/internal"
 	"github.com/jckuester/terradozer/pkg/provider"
 	terradozerRes "github.com/jckuester/terradozer/pkg/resource"
+	"golang.org/x/time/rate"
 )
 
 // IsType returns true if the given string is a Terraform AWS resource type.
@@ -70,6 +75,51 @@ func MatchSupportedTypes(globPattern string) ([]string, error) {
 	return result, nil
 }
 
+// SupportedType returns the supported resource type that equals the given name without any glob expansion
+// (see --exact). Like for MatchSupportedTypes, the name can also be given without the aws_ prefix.
+func SupportedType(name string) (string, error) {
+	for _, rType := range []string{name, "aws_" + name} {
+		if IsSupportedType(rType) {
+			return rType, nil
+		}
+	}
+
+	return "", fmt.Errorf("not a supported resource type: %s", name)
+}
+
+// ExcludeTypes returns the given resource types without the ones that match any of the given glob patterns.
+// Like for MatchSupportedTypes, patterns can also be given without the aws_ prefix.
+func ExcludeTypes(rTypes []string, globPatterns []string) ([]string, error) {
+	var compiled []glob.Glob
+
+	for _, pattern := range globPatterns {
+		g, err := glob.Compile(pattern)
+		if err != nil {
+			return nil, err
+		}
+
+		compiled = append(compiled, g)
+	}
+
+	var result []string
+
+	for _, rType := range rTypes {
+		excluded := false
+		for _, g := range compiled {
+			if g.Match(rType) || g.Match(strings.TrimPrefix(rType, "aws_")) {
+				excluded = true
+				break
+			}
+		}
+
+		if !excluded {
+			result = append(result, rType)
+		}
+	}
+
+	return result, nil
+}
+
 // SupportsTags returns true if the given resource type supports tags.
 func SupportsTags(s string) bool {
 	for _, t := range TypesWithTags {
@@ -89,7 +139,14 @@ type resourcesThreadSafe struct {
 
 // GetStates fetches the Terraform state for each resource via the Terraform AWS Provider.
 // Returns only resources which still exist (i.e. state isn't of type cty.Nil after update).
-func GetStates(resources []aws.Resource, providers map[util.AWSClientKey]provider.TerraformProvider) []aws.Resource {
+// Resources whose state couldn't be refreshed are marked as StateStale.
+// Requests are additionally limited per AWS account by the given accountSem and, in total, to the rate
+// of the given limiter (both can be nil). The limiter is waited for once per resource read, as the requests
+// the Terraform AWS Provider sends for it can't be limited one by one.
+// Fresh states in the given cache (which can be nil) are used instead of fetching them, and fetched states are cached.
+// Once the context is done, no more states are fetched and the remaining resources are marked as StateStale.
+func GetStates(ctx context.Context, resources []aws.Resource,
+	providers map[util.AWSClientKey]provider.TerraformProvider, accountSem *internal.KeyedSemaphore, limiter *rate.Limiter, cache *StateCache) []aws.Resource {
 	var wg sync.WaitGroup
 
 	result := &resourcesThreadSafe{
@@ -108,22 +165,74 @@ func GetStates(resources []aws.Resource, providers map[util.AWSClientKey]provide
 
 			r := &resources[i]
 
+			if ctx.Err() != nil {
+				r.StateStale = true
+				return
+			}
+
 			key := util.AWSClientKey{
 				Profile: r.Profile,
 				Region:  r.Region,
 			}
 
+			// only use the provider configured with the region of the resource (never any other one),
+			// so that its state is refreshed via the API endpoint of that region
 			p, ok := providers[key]
 
+			if state, cached := cache.Get(r); cached {
+				var pr *provider.TerraformProvider
+				if ok {
+					pr = &p
+				}
+
+				r.UpdatableResource = terradozerRes.NewWithState(r.Type, r.ID, pr, state)
+
+				result.Lock()
+				result.resources = append(result.resources, *r)
+				result.Unlock()
+
+				return
+			}
+
 			if !ok {
-				panic(fmt.Sprintf("could not find Terraform AWS Provider for key: %v", key))
+				// state can't be fetched, e.g. because the provider for this key failed to launch
+				log.WithFields(log.Fields{
+					"type":    r.Type,
+					"id":      r.ID,
+					"profile": key.Profile,
+					"region":  key.Region}).Debug("could not find Terraform AWS Provider for resource")
+
+				r.StateStale = true
+
+				result.Lock()
+				result.resources = append(result.resources, *r)
+				result.Unlock()
+
+				return
 			}
 
 			r.UpdatableResource = terradozerRes.New(r.Type, r.ID, nil, &p)
 
+			if limiter != nil {
+				err := limiter.Wait(ctx)
+				if err != nil {
+					r.StateStale = true
+
+					result.Lock()
+					result.resources = append(result.resources, *r)
+					result.Unlock()
+
+					return
+				}
+			}
+
+			accountSem.Acquire(r.AccountID)
 			err := r.UpdateState()
+			accountSem.Release(r.AccountID)
 			if err != nil {
 				fmt.Fprint(os.Stderr, color.RedString("Error: %s\n", err))
+
+				r.StateStale = true
 			}
 
 			// filter out resources that don't exist anymore
@@ -132,6 +241,13 @@ func GetStates(resources []aws.Resource, providers map[util.AWSClientKey]provide
 				return
 			}
 
+			if !r.StateStale && r.State() != nil {
+				err := cache.Put(r, *r.State())
+				if err != nil {
+					log.WithField("id", r.ID).WithError(err).Debug("failed to cache state")
+				}
+			}
+
 			result.Lock()
 			result.resources = append(result.resources, *r)
 			result.Unlock()
@@ -145,18 +261,20 @@ func GetStates(resources []aws.Resource, providers map[util.AWSClientKey]provide
 }
 
 // HasAttributes returns only the attributes that the given Terraform resource type supports out of a given
-// list of attributes.
+// list of attributes. An attribute can also be a dot-separated path to a nested value
+// (e.g., root_block_device.0.volume_size), which is supported if it exists in the schema of the type.
 func HasAttributes(attributes []string, terraformType string, provider *provider.TerraformProvider) (map[string]bool, error) {
 	schema, err := provider.GetSchemaForResource(terraformType)
 	if err != nil {
 		return nil, err
 	}
 
+	stateType := schema.Block.ImpliedType()
+
 	result := map[string]bool{}
 
 	for _, attr := range attributes {
-		_, ok := schema.Block.Attributes[attr]
-		if ok {
+		if hasPath(stateType, strings.Split(attr, ".")) {
 			result[attr] = true
 		}
 	}
@@ -164,7 +282,72 @@ func HasAttributes(attributes []string, terraformType string, provider *provider
 	return result, nil
 }
 
-// GetAttribute returns any Terraform attribute of a resource by name.
+// hasPath returns true if a value of the given type can have a nested value at the given path,
+// whose steps are names of object attributes, keys of maps, or indexes of lists.
+func hasPath(ty cty.Type, path []string) bool {
+	for _, step := range path {
+		switch {
+		case ty.IsObjectType():
+			if !ty.HasAttribute(step) {
+				return false
+			}
+
+			ty = ty.AttributeType(step)
+		case ty.IsListType():
+			if _, err := strconv.Atoi(step); err != nil {
+				return false
+			}
+
+			ty = ty.ElementType()
+		case ty.IsMapType():
+			ty = ty.ElementType()
+		default:
+			// elements of sets can't be addressed
+			return false
+		}
+	}
+
+	return true
+}
+
+// ErrAttributeNotFound is returned if an attribute (or the nested value at an attribute path)
+// doesn't exist in the state of a resource.
+var ErrAttributeNotFound = errors.New("attribute not found")
+
+// valueAtPath returns the nested value at the given attribute path (see hasPath) of a state.
+func valueAtPath(state cty.Value, name string) (cty.Value, error) {
+	v := state
+
+	for _, step := range strings.Split(name, ".") {
+		if v.IsNull() {
+			return cty.NilVal, fmt.Errorf("%w: %s", ErrAttributeNotFound, name)
+		}
+
+		ty := v.Type()
+
+		switch {
+		case ty.IsObjectType() && ty.HasAttribute(step):
+			v = v.GetAttr(step)
+		case ty.IsListType():
+			i, err := strconv.Atoi(step)
+			if err != nil || i < 0 || i >= v.LengthInt() {
+				return cty.NilVal, fmt.Errorf("%w: %s", ErrAttributeNotFound, name)
+			}
+
+			v = v.Index(cty.NumberIntVal(int64(i)))
+		case ty.IsMapType() && v.HasIndex(cty.StringVal(step)).True():
+			v = v.Index(cty.StringVal(step))
+		default:
+			return cty.NilVal, fmt.Errorf("%w: %s", ErrAttributeNotFound, name)
+		}
+	}
+
+	return v, nil
+}
+
+// GetAttribute returns any Terraform attribute of a resource by name, or the nested value at a dot-separated
+// attribute path (e.g., root_block_device.0.volume_size). If the path doesn't resolve in the state of the
+// resource, the returned error is ErrAttributeNotFound.
 func GetAttribute(name string, r *aws.Resource) (string, error) {
 	if r.UpdatableResource == nil {
 		return "", fmt.Errorf("resource is nil")
@@ -192,9 +375,9 @@ func GetAttribute(name string, r *aws.Resource) (string, error) {
 		return "", fmt.Errorf("cannot iterate: %s", *state)
 	}
 
-	attrValue, ok := state.AsValueMap()[name]
-	if !ok {
-		return "", fmt.Errorf("attribute not found: %s", name)
+	attrValue, err := valueAtPath(*state, name)
+	if err != nil {
+		return "", err
 	}
 
 	switch attrValue.Type() {
@@ -225,27 +408,101 @@ func GetAttribute(name string, r *aws.Resource) (string, error) {
 
 		return v, nil
 
-	case cty.Map(cty.String):
-		var v map[string]string
-		err := gocty.FromCtyValue(attrValue, &v)
+	default:
+		if attrValue.Type().IsPrimitiveType() {
+			return "", fmt.Errorf("currently unhandled type: %s", attrValue.Type().FriendlyName())
+		}
+
+		// lists, sets, maps and nested objects are rendered as compact JSON, so that they can be parsed again
+		v, err := ctyjson.Marshal(attrValue, attrValue.Type())
 		if err != nil {
 			return "", err
 		}
 
-		if len(v) > 0 {
-			var list []string
+		return string(v), nil
+	}
+}
 
-			for k, v := range v {
-				list = append(list, fmt.Sprintf("%s=%s", k, v))
-			}
+// FormatTags renders tags (or any other map of strings) as a compact JSON object with sorted keys,
+// the same way GetAttribute renders the tags of a state. No tags (nil) are rendered as empty string.
+func FormatTags(tags map[string]string) string {
+	if tags == nil {
+		return ""
+	}
+
+	// marshaling a map of strings can't fail; keys are sorted by encoding/json
+	v, _ := json.Marshal(tags)
+
+	return string(v)
+}
 
-			sort.Strings(list)
+// GetTags returns the tags of a resource from its state, if the state has been fetched and the resource type
+// exposes tags via its Terraform schema. Otherwise, the tags returned by the list API of the service are returned.
+func GetTags(r *aws.Resource) map[string]string {
+	if r.UpdatableResource == nil || r.State() == nil {
+		return r.Tags
+	}
+
+	state := r.State()
+
+	if state.IsNull() || !state.IsWhollyKnown() || !state.CanIterateElements() {
+		return r.Tags
+	}
+
+	attrValue, ok := state.AsValueMap()["tags"]
+	if !ok || attrValue.IsNull() || attrValue.Type() != cty.Map(cty.String) {
+		return r.Tags
+	}
 
-			return strings.Join(list, ","), nil
+	var tags map[string]string
+	err := gocty.FromCtyValue(attrValue, &tags)
+	if err != nil {
+		return r.Tags
+	}
+
+	return tags
+}
+
+// GetAttributeElements returns the elements of a list or set attribute of primitive values
+// (e.g., the security groups of an instance) as strings.
+func GetAttributeElements(name string, r *aws.Resource) ([]string, error) {
+	if r.UpdatableResource == nil || r.State() == nil {
+		return nil, fmt.Errorf("state is nil")
+	}
+
+	state := r.State()
+
+	if state.IsNull() || !state.IsWhollyKnown() || !state.CanIterateElements() {
+		return nil, fmt.Errorf("state is null or not wholly known")
+	}
+
+	attrValue, err := valueAtPath(*state, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if !attrValue.Type().IsListType() && !attrValue.Type().IsSetType() {
+		return nil, fmt.Errorf("attribute is not a list or set: %s", name)
+	}
+
+	if attrValue.IsNull() {
+		return nil, nil
+	}
+
+	var result []string
+
+	for _, v := range attrValue.AsValueSlice() {
+		if v.IsNull() {
+			continue
 		}
 
-		return "", nil
-	default:
-		return "", fmt.Errorf("currently unhandled type: %s", attrValue.Type().FriendlyName())
+		s, err := convert.Convert(v, cty.String)
+		if err != nil {
+			return nil, fmt.Errorf("currently unhandled element type: %s", v.Type().FriendlyName())
+		}
+
+		result = append(result, s.AsString())
 	}
+
+	return result, nil
 }
@@ -1,11 +1,22 @@
 package resource_test
 
 import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
 	"reflect"
 	"testing"
+	"time"
 
+	"github.com/jckuester/awsls/aws"
 	"github.com/jckuester/awsls/resource"
+	"github.com/jckuester/awsls/util"
+	"github.com/jckuester/terradozer/pkg/provider"
+	terradozerRes "github.com/jckuester/terradozer/pkg/resource"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
 )
 
 func TestIsType(t *testing.T) {
@@ -107,6 +118,89 @@ func TestMatchSupportedTypes(t *testing.T) {
 	}
 }
 
+func TestSupportedType(t *testing.T) {
+	tests := []struct {
+		name    string
+		arg     string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "supported resource type",
+			arg:  "aws_iam_user",
+			want: "aws_iam_user",
+		},
+		{
+			name: "without prefix",
+			arg:  "iam_user",
+			want: "aws_iam_user",
+		},
+		{
+			name:    "glob pattern",
+			arg:     "aws_iam_*",
+			wantErr: "not a supported resource type: aws_iam_*",
+		},
+		{
+			name:    "not supported resource type",
+			arg:     "aws_default_vpc",
+			wantErr: "not a supported resource type: aws_default_vpc",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resource.SupportedType(tt.arg)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestExcludeTypes(t *testing.T) {
+	rTypes := []string{"aws_iam_policy", "aws_iam_role", "aws_iam_user", "aws_instance"}
+
+	tests := []struct {
+		name     string
+		patterns []string
+		want     []string
+		wantErr  string
+	}{
+		{
+			name: "no patterns",
+			want: rTypes,
+		},
+		{
+			name:     "exact type",
+			patterns: []string{"aws_iam_policy"},
+			want:     []string{"aws_iam_role", "aws_iam_user", "aws_instance"},
+		},
+		{
+			name:     "multiple patterns, with wildcard and without prefix",
+			patterns: []string{"iam_*", "aws_instance"},
+		},
+		{
+			name:     "invalid glob pattern",
+			patterns: []string{"aws_["},
+			wantErr:  "unexpected end of input",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resource.ExcludeTypes(rTypes, tt.patterns)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func TestSupportsTags(t *testing.T) {
 	tests := []struct {
 		name string
@@ -132,3 +226,311 @@ func TestSupportsTags(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatTags(t *testing.T) {
+	tests := []struct {
+		name string
+		arg  map[string]string
+		want string
+	}{
+		{
+			name: "no tags",
+			arg:  nil,
+			want: "",
+		},
+		{
+			name: "tags are sorted by key",
+			arg:  map[string]string{"foo": "bar", "bar": "baz"},
+			want: `{"bar":"baz","foo":"bar"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, resource.FormatTags(tt.arg))
+		})
+	}
+}
+
+func TestGetStates_providerOfOtherRegionNotUsed(t *testing.T) {
+	providers := map[util.AWSClientKey]provider.TerraformProvider{
+		{Profile: "myaccount", Region: "us-west-2"}:    {},
+		{Profile: "otheraccount", Region: "us-east-1"}: {},
+	}
+
+	resources := []aws.Resource{
+		{
+			Type:    "aws_vpc",
+			ID:      "vpc-123",
+			Profile: "myaccount",
+			Region:  "us-east-1",
+		},
+	}
+
+	actual := resource.GetStates(context.Background(), resources, providers, nil, nil, nil)
+
+	require.Len(t, actual, 1)
+	// the state is not refreshed by any provider whose profile and region don't match the resource
+	assert.Nil(t, actual[0].UpdatableResource)
+}
+
+func TestGetTags(t *testing.T) {
+	stateWithTags := cty.ObjectVal(map[string]cty.Value{
+		"tags": cty.MapVal(map[string]cty.Value{"Name": cty.StringVal("from-state")}),
+	})
+	stateWithoutTags := cty.ObjectVal(map[string]cty.Value{
+		"id": cty.StringVal("foo"),
+	})
+
+	tests := []struct {
+		name  string
+		state *cty.Value
+		want  map[string]string
+	}{
+		{
+			name: "no state",
+			want: map[string]string{"Name": "from-list"},
+		},
+		{
+			name:  "tags from state",
+			state: &stateWithTags,
+			want:  map[string]string{"Name": "from-state"},
+		},
+		{
+			name:  "state without tags",
+			state: &stateWithoutTags,
+			want:  map[string]string{"Name": "from-list"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &aws.Resource{
+				Type: "aws_vpc",
+				ID:   "foo",
+				Tags: map[string]string{"Name": "from-list"},
+			}
+			if tt.state != nil {
+				r.UpdatableResource = terradozerRes.NewWithState("aws_vpc", "foo", nil, tt.state)
+			}
+
+			assert.Equal(t, tt.want, resource.GetTags(r))
+		})
+	}
+}
+
+func TestGetAttribute(t *testing.T) {
+	state := cty.ObjectVal(map[string]cty.Value{
+		"instance_type":  cty.StringVal("t2.micro"),
+		"cpu_core_count": cty.NumberIntVal(2),
+		"ebs_optimized":  cty.True,
+		"tags": cty.MapVal(map[string]cty.Value{
+			"Name":        cty.StringVal("foo"),
+			"Environment": cty.StringVal("prod"),
+		}),
+		"ingress": cty.ListVal([]cty.Value{
+			cty.ObjectVal(map[string]cty.Value{
+				"from_port":   cty.NumberIntVal(443),
+				"cidr_blocks": cty.ListVal([]cty.Value{cty.StringVal("0.0.0.0/0")}),
+			}),
+		}),
+		"no_tags": cty.NullVal(cty.Map(cty.String)),
+	})
+
+	tests := []struct {
+		name    string
+		arg     string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "string",
+			arg:  "instance_type",
+			want: "t2.micro",
+		},
+		{
+			name: "number",
+			arg:  "cpu_core_count",
+			want: "2",
+		},
+		{
+			name: "bool",
+			arg:  "ebs_optimized",
+			want: "true",
+		},
+		{
+			name: "map of strings",
+			arg:  "tags",
+			want: `{"Environment":"prod","Name":"foo"}`,
+		},
+		{
+			name: "list of objects",
+			arg:  "ingress",
+			want: `[{"cidr_blocks":["0.0.0.0/0"],"from_port":443}]`,
+		},
+		{
+			name: "null map",
+			arg:  "no_tags",
+			want: "null",
+		},
+		{
+			name: "path into list of objects",
+			arg:  "ingress.0.from_port",
+			want: "443",
+		},
+		{
+			name: "path into nested list",
+			arg:  "ingress.0.cidr_blocks.0",
+			want: "0.0.0.0/0",
+		},
+		{
+			name: "path into map",
+			arg:  "tags.Name",
+			want: "foo",
+		},
+		{
+			name:    "index out of range",
+			arg:     "ingress.1.from_port",
+			wantErr: true,
+		},
+		{
+			name:    "path into null map",
+			arg:     "no_tags.Name",
+			wantErr: true,
+		},
+		{
+			name:    "attribute not found",
+			arg:     "foo",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &aws.Resource{
+				Type:              "aws_instance",
+				ID:                "i-123",
+				UpdatableResource: terradozerRes.NewWithState("aws_instance", "i-123", nil, &state),
+			}
+
+			got, err := resource.GetAttribute(tt.arg, r)
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, resource.ErrAttributeNotFound))
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGetAttributeElements(t *testing.T) {
+	state := cty.ObjectVal(map[string]cty.Value{
+		"security_groups": cty.SetVal([]cty.Value{cty.StringVal("sg-1"), cty.StringVal("sg-2")}),
+		"ports":           cty.ListVal([]cty.Value{cty.NumberIntVal(80), cty.NumberIntVal(443)}),
+		"no_groups":       cty.NullVal(cty.Set(cty.String)),
+		"instance_type":   cty.StringVal("t2.micro"),
+	})
+
+	tests := []struct {
+		name    string
+		arg     string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "set of strings",
+			arg:  "security_groups",
+			want: []string{"sg-1", "sg-2"},
+		},
+		{
+			name: "list of numbers",
+			arg:  "ports",
+			want: []string{"80", "443"},
+		},
+		{
+			name: "null set",
+			arg:  "no_groups",
+		},
+		{
+			name:    "not a list",
+			arg:     "instance_type",
+			wantErr: true,
+		},
+		{
+			name:    "attribute not found",
+			arg:     "foo",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &aws.Resource{
+				Type:              "aws_instance",
+				ID:                "i-123",
+				UpdatableResource: terradozerRes.NewWithState("aws_instance", "i-123", nil, &state),
+			}
+
+			got, err := resource.GetAttributeElements(tt.arg, r)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGetStates_cachedState(t *testing.T) {
+	dir, err := ioutil.TempDir("", "awsls")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	cache, err := resource.NewStateCache(dir, time.Hour, false)
+	require.NoError(t, err)
+
+	cached := aws.Resource{Type: "aws_vpc", ID: "vpc-123", Profile: "myaccount", Region: "us-east-1"}
+	state := cty.ObjectVal(map[string]cty.Value{
+		"id":         cty.StringVal("vpc-123"),
+		"cidr_block": cty.StringVal("10.0.0.0/16"),
+	})
+
+	err = cache.Put(&cached, state)
+	require.NoError(t, err)
+
+	// no provider is needed for a cached state
+	actual := resource.GetStates(context.Background(), []aws.Resource{cached}, nil, nil, nil, cache)
+
+	require.Len(t, actual, 1)
+	require.NotNil(t, actual[0].UpdatableResource)
+	assert.False(t, actual[0].StateStale)
+
+	cidr, err := resource.GetAttribute("cidr_block", &actual[0])
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.0/16", cidr)
+
+	// a refreshing cache ignores cached states
+	refreshing, err := resource.NewStateCache(dir, time.Hour, true)
+	require.NoError(t, err)
+
+	_, ok := refreshing.Get(&cached)
+	assert.False(t, ok)
+
+	// expired
+	expired, err := resource.NewStateCache(dir, -time.Second, false)
+	require.NoError(t, err)
+
+	_, ok = expired.Get(&cached)
+	assert.False(t, ok)
+}
+
+func TestGetStates_canceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	actual := resource.GetStates(ctx, []aws.Resource{{Type: "aws_vpc", ID: "vpc-123"}}, nil, nil, nil, nil)
+
+	require.Len(t, actual, 1)
+	assert.Nil(t, actual[0].UpdatableResource)
+	assert.True(t, actual[0].StateStale)
+}
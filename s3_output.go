@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/jckuester/awsls/util"
+)
+
+// s3Scheme is the prefix of an --out-dir that points to an S3 bucket (e.g., s3://my-bucket/prefix/).
+const s3Scheme = "s3://"
+
+// s3Output uploads the output files to an S3 bucket (see --out-dir s3://bucket/prefix/). The files are first
+// written into a local staging directory, which is used as output directory instead.
+type s3Output struct {
+	bucket string
+	// prefix is prepended to the name of each file to get the key of its object (empty or ending with a slash)
+	prefix string
+	client *s3.Client
+}
+
+// parseS3OutDir returns the S3 output for the given output directory, or nil if it isn't an S3 URL,
+// in which case the output is written into the local directory.
+func parseS3OutDir(outDir string) (*s3Output, error) {
+	if !strings.HasPrefix(outDir, s3Scheme) {
+		return nil, nil
+	}
+
+	bucketAndPrefix := strings.SplitN(strings.TrimPrefix(outDir, s3Scheme), "/", 2)
+
+	bucket := bucketAndPrefix[0]
+	if bucket == "" {
+		return nil, fmt.Errorf("missing bucket name: %s (e.g., s3://my-bucket/prefix/)", outDir)
+	}
+
+	var prefix string
+	if len(bucketAndPrefix) == 2 {
+		prefix = strings.Trim(bucketAndPrefix[1], "/")
+	}
+
+	if prefix != "" {
+		prefix += "/"
+	}
+
+	return &s3Output{bucket: bucket, prefix: prefix}, nil
+}
+
+// upload puts each of the given local files as an object named after the file under the prefix into the bucket.
+// Returns the S3 URLs of the uploaded objects.
+func (o *s3Output) upload(ctx context.Context, files []string) ([]string, error) {
+	var result []string
+
+	for _, f := range files {
+		key := path.Join(o.prefix, filepath.Base(f))
+
+		err := o.putObject(ctx, f, key)
+		if err != nil {
+			return result, fmt.Errorf("failed to upload %s to %s: %s", filepath.Base(f), o.url(key), err)
+		}
+
+		result = append(result, o.url(key))
+	}
+
+	return result, nil
+}
+
+func (o *s3Output) putObject(ctx context.Context, file, key string) error {
+	body, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	req := o.client.PutObjectRequest(&s3.PutObjectInput{
+		Bucket: &o.bucket,
+		Key:    &key,
+		Body:   body,
+	})
+
+	_, err = req.Send(ctx)
+
+	return err
+}
+
+// url returns the S3 URL of the object with the given key.
+func (o *s3Output) url(key string) string {
+	return s3Scheme + o.bucket + "/" + key
+}
+
+// firstClientKey returns the key of the first AWS client, ordered by profile and region, so that the same client
+// is picked on each run.
+func firstClientKey(keys []util.AWSClientKey) util.AWSClientKey {
+	first := keys[0]
+
+	for _, k := range keys[1:] {
+		if k.Profile < first.Profile || (k.Profile == first.Profile && k.Region < first.Region) {
+			first = k
+		}
+	}
+
+	return first
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/jckuester/awsls/aws"
+	"github.com/jckuester/awsls/util"
+)
+
+// runSummary counts the resources listed during a run by type and region, which are printed
+// as a summary at the end of the run. All methods can be called concurrently and on a nil summary.
+type runSummary struct {
+	mu       sync.Mutex
+	byType   map[string]int
+	byRegion map[string]int
+	total    int
+}
+
+// newRunSummary returns an empty summary.
+func newRunSummary() *runSummary {
+	return &runSummary{
+		byType:   map[string]int{},
+		byRegion: map[string]int{},
+	}
+}
+
+// add counts the given resources of a type.
+func (s *runSummary) add(rType string, resources []aws.Resource) {
+	if s == nil || len(resources) == 0 {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.byType[rType] += len(resources)
+	for _, r := range resources {
+		s.byRegion[r.Region]++
+	}
+	s.total += len(resources)
+}
+
+// write prints a line with the total number of resources and their number per type, followed by a line
+// with their number per region if multiple regions have been queried by the given clients.
+func (s *runSummary) write(out io.Writer, clientKeys []util.AWSClientKey) error {
+	if s == nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	profiles := map[string]bool{}
+	regions := map[string]bool{}
+
+	for _, k := range clientKeys {
+		profiles[k.Profile] = true
+		regions[k.Region] = true
+	}
+
+	line := fmt.Sprintf("found %s across %s, %s", plural(s.total, "resource"), plural(len(regions), "region"),
+		plural(len(profiles), "profile"))
+	if s.total > 0 {
+		line += " (" + formatCounts(s.byType) + ")"
+	}
+
+	_, err := fmt.Fprintln(out, line)
+	if err != nil {
+		return err
+	}
+
+	if len(regions) > 1 && s.total > 0 {
+		_, err = fmt.Fprintf(out, "by region: %s\n", formatCounts(s.byRegion))
+	}
+
+	return err
+}
+
+// formatCounts returns the counts as a comma-separated list of key: count, sorted by key.
+func formatCounts(counts map[string]int) string {
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	result := make([]string, 0, len(keys))
+	for _, k := range keys {
+		result = append(result, fmt.Sprintf("%s: %d", k, counts[k]))
+	}
+
+	return strings.Join(result, ", ")
+}
+
+// plural returns the count followed by the noun, which gets an s unless the count is 1.
+func plural(count int, noun string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, noun)
+	}
+
+	return fmt.Sprintf("%d %ss", count, noun)
+}
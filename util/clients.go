@@ -1,12 +1,31 @@
 package util
 
 import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
 	"sync"
 
+	"github.com/apex/log"
+	awsSDK "github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/aws/external"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/aws-sdk-go-v2/aws/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
 	"github.com/jckuester/awsls/aws"
+	"golang.org/x/time/rate"
 )
 
+// roleSessionName identifies the sessions of assumed roles (e.g., in CloudTrail).
+const roleSessionName = "awsls"
+
+// defaultLookupRegion is the region in which account-wide information is looked up if no region is configured.
+const defaultLookupRegion = "us-east-1"
+
 // awsClientPoolThreadSafe is a concurrent map implementation to store multiple AWS clients.
 type awsClientPoolThreadSafe struct {
 	sync.Mutex
@@ -17,11 +36,20 @@ type AWSClientKey struct {
 	Profile, Region string
 }
 
+// AssumeRole is a config that makes NewAWSClientPool create clients with the temporary credentials of an IAM role,
+// which is assumed via STS with the credentials of each profile (or the ones of the default provider chain).
+type AssumeRole struct {
+	RoleARN string
+	// ExternalID is passed to STS if set, as required by the trust policies of roles for third-party access.
+	ExternalID string
+}
+
 // NewAWSClientPool creates an AWS client for each permutation of the given profiles and regions.
 // If profiles, regions, or both are empty, credentials and regions are picked up via the usual default provider chain,
 // respectively. For example, if regions are empty, the region is first looked for via the according region environment variable
 // or second the default region for each profile is used from `~/.aws/config`.
-func NewAWSClientPool(profiles []string, regions []string) (map[AWSClientKey]aws.Client, error) {
+// Any additional configs (e.g., static credentials, ProfileCredentials or an AssumeRole) are applied to every client.
+func NewAWSClientPool(profiles []string, regions []string, configs ...external.Config) (map[AWSClientKey]aws.Client, error) {
 	errors := make(chan error)
 	wgDone := make(chan bool)
 
@@ -40,9 +68,9 @@ func NewAWSClientPool(profiles []string, regions []string) (map[AWSClientKey]aws
 				go func(p string, r string) {
 					defer wg.Done()
 
-					client, err := aws.NewClient(
+					client, err := newClient(append([]external.Config{
 						external.WithSharedConfigProfile(p),
-						external.WithRegion(r))
+						external.WithRegion(r)}, configs...)...)
 					if err != nil {
 						errors <- err
 						return
@@ -61,7 +89,8 @@ func NewAWSClientPool(profiles []string, regions []string) (map[AWSClientKey]aws
 			go func(p string) {
 				defer wg.Done()
 
-				client, err := aws.NewClient(external.WithSharedConfigProfile(p))
+				client, err := newClient(append([]external.Config{
+					external.WithSharedConfigProfile(p)}, configs...)...)
 				if err != nil {
 					errors <- err
 					return
@@ -79,7 +108,8 @@ func NewAWSClientPool(profiles []string, regions []string) (map[AWSClientKey]aws
 			go func(r string) {
 				defer wg.Done()
 
-				client, err := aws.NewClient(external.WithRegion(r))
+				client, err := newClient(append([]external.Config{
+					external.WithRegion(r)}, configs...)...)
 				if err != nil {
 					errors <- err
 					return
@@ -91,7 +121,7 @@ func NewAWSClientPool(profiles []string, regions []string) (map[AWSClientKey]aws
 			}(region)
 		}
 	} else {
-		client, err := aws.NewClient()
+		client, err := newClient(configs...)
 		if err != nil {
 			return nil, err
 		}
@@ -114,3 +144,223 @@ func NewAWSClientPool(profiles []string, regions []string) (map[AWSClientKey]aws
 
 	return clientPool.clients, nil
 }
+
+// newClient creates an AWS client with the given configs. If these contain credentials for the client's profile
+// (see ProfileCredentials), those are used instead of the ones configured for the profile. If the configs contain
+// an AssumeRole, the client uses the credentials of the assumed role instead.
+func newClient(configs ...external.Config) (*aws.Client, error) {
+	profile := ""
+
+	for _, c := range configs {
+		if p, ok := c.(external.WithSharedConfigProfile); ok {
+			profile = string(p)
+			break
+		}
+	}
+
+	for _, c := range configs {
+		profileCreds, ok := c.(ProfileCredentials)
+		if !ok {
+			continue
+		}
+
+		if creds, ok := profileCreds[profile]; ok {
+			// the first credentials provider found in the configs is used
+			configs = append([]external.Config{external.WithCredentialsProvider{
+				CredentialsProvider: awsSDK.NewStaticCredentialsProvider(creds.AccessKeyID, creds.SecretAccessKey,
+					creds.SessionToken)}}, configs...)
+		}
+
+		break
+	}
+
+	for _, c := range configs {
+		role, ok := c.(AssumeRole)
+		if !ok {
+			continue
+		}
+
+		cfg, err := external.LoadDefaultAWSConfig(configs...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load config: %s", err)
+		}
+
+		creds := stscreds.NewAssumeRoleProvider(sts.New(cfg), role.RoleARN,
+			func(o *stscreds.AssumeRoleProviderOptions) {
+				o.RoleSessionName = roleSessionName
+				if role.ExternalID != "" {
+					o.ExternalID = awsSDK.String(role.ExternalID)
+				}
+			})
+
+		// the first credentials provider found in the configs is used
+		return aws.NewClient(append([]external.Config{
+			external.WithCredentialsProvider{CredentialsProvider: creds}}, configs...)...)
+	}
+
+	return aws.NewClient(configs...)
+}
+
+// EnabledRegions returns all regions (sorted) that are enabled for the account of the given profile,
+// which are looked up via the EC2 API in the profile's default region (or us-east-1 if there is none).
+// Regions that the account hasn't opted in to are skipped.
+func EnabledRegions(profile string, configs ...external.Config) ([]string, error) {
+	if profile != "" {
+		configs = append([]external.Config{external.WithSharedConfigProfile(profile)}, configs...)
+	}
+
+	client, err := newClient(configs...)
+	if err != nil {
+		return nil, err
+	}
+
+	if client.Region == "" {
+		client, err = newClient(append([]external.Config{external.WithRegion(defaultLookupRegion)}, configs...)...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	req := client.Ec2conn.DescribeRegionsRequest(&ec2.DescribeRegionsInput{})
+
+	resp, err := req.Send(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up enabled regions: %s", err)
+	}
+
+	var result []string
+
+	for _, r := range resp.Regions {
+		if awsSDK.StringValue(r.OptInStatus) == "not-opted-in" {
+			continue
+		}
+
+		result = append(result, awsSDK.StringValue(r.RegionName))
+	}
+
+	sort.Strings(result)
+
+	return result, nil
+}
+
+// ProfilesOfAccount returns the profiles (sorted) out of the given ones whose credentials belong to
+// the AWS account with the given ID, which is looked up via the STS API in the given region.
+// The credentials of a profile are resolved like the ones of the clients in NewAWSClientPool (i.e., including
+// SSO credentials and an assumed role given via configs). Profiles whose account can't be looked up
+// (e.g., due to expired credentials) are skipped.
+func ProfilesOfAccount(profiles []string, accountID, region string, configs ...external.Config) []string {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+
+	var result []string
+
+	wg.Add(len(profiles))
+
+	for _, profile := range profiles {
+		go func(p string) {
+			defer wg.Done()
+
+			client, err := newClient(append([]external.Config{
+				external.WithSharedConfigProfile(p),
+				external.WithRegion(region)}, configs...)...)
+			if err == nil {
+				err = client.SetAccountID()
+			}
+			if err != nil {
+				log.WithField("profile", p).WithError(err).Debug("failed to look up account of profile")
+				return
+			}
+
+			if client.AccountID == accountID {
+				mu.Lock()
+				result = append(result, p)
+				mu.Unlock()
+			}
+		}(profile)
+	}
+
+	wg.Wait()
+
+	sort.Strings(result)
+
+	return result
+}
+
+// WithUserAgent returns a config that adds the given product token (e.g., awsls/0.8.0) to the User-Agent header
+// of all requests made by a client, so that these can be identified in CloudTrail.
+func WithUserAgent(userAgent string) external.Config {
+	return external.WithHandlersFunc(func(handlers awsSDK.Handlers) awsSDK.Handlers {
+		handlers.Build.PushBack(awsSDK.MakeAddToUserAgentFreeFormHandler(userAgent))
+
+		return handlers
+	})
+}
+
+// WithMaxRetries returns a config that retries requests failing because of throttling or transient errors
+// (e.g., 5xx status codes) up to the given number of times, with exponential backoff and jitter.
+// Other errors are returned without retry.
+func WithMaxRetries(maxRetries int) external.Config {
+	return aws.WithRetryer{Retryer: retry.NewStandard(func(o *retry.StandardOptions) {
+		o.MaxAttempts = maxRetries + 1
+		// the SDK's retry quota is shared by all requests of a client and quickly used up when many are
+		// throttled at once, after which requests would fail without any retry
+		o.RateLimiter = noRetryQuota{}
+	})}
+}
+
+// noRetryQuota is a retry quota that never runs out.
+type noRetryQuota struct{}
+
+// GetToken returns a token to retry a request, which is always available.
+func (noRetryQuota) GetToken(context.Context, uint) (func() error, error) {
+	return func() error { return nil }, nil
+}
+
+// AddTokens does nothing, as there is no quota to refill.
+func (noRetryQuota) AddTokens(uint) error {
+	return nil
+}
+
+// WithEndpointURL returns a config that sends the requests of all services to the given endpoint URL
+// (e.g., http://localhost:4566 of LocalStack) instead of the AWS endpoint of the service and region.
+func WithEndpointURL(endpointURL string) external.Config {
+	return external.WithEndpointResolverFunc(func(awsSDK.EndpointResolver) awsSDK.EndpointResolver {
+		return awsSDK.ResolveWithEndpointURL(endpointURL)
+	})
+}
+
+// WithInsecureSkipVerify returns a config that doesn't verify the TLS certificate of endpoints,
+// e.g., self-signed ones of local endpoints (see WithEndpointURL).
+func WithInsecureSkipVerify() external.Config {
+	return aws.WithHTTPClient{HTTPClient: awsSDK.NewBuildableHTTPClient().WithTransportOptions(
+		func(tr *http.Transport) {
+			if tr.TLSClientConfig == nil {
+				tr.TLSClientConfig = &tls.Config{}
+			}
+
+			tr.TLSClientConfig.InsecureSkipVerify = true //nolint:gosec // explicitly requested (see --no-verify-ssl)
+		})}
+}
+
+// WithRateLimit returns a config that waits for the given limiter before each request of a client.
+// Sharing the limiter between clients limits the rate of their requests in total.
+func WithRateLimit(limiter *rate.Limiter) external.Config {
+	return aws.WithRateLimiter{Limiter: limiter}
+}
+
+// WithSharedConfigFiles returns a config that loads profiles from the given shared config and credentials files.
+// An empty path falls back to the file set via the according environment variable (AWS_CONFIG_FILE,
+// AWS_SHARED_CREDENTIALS_FILE) or else to the default location (~/.aws/config, ~/.aws/credentials).
+func WithSharedConfigFiles(configFile, credentialsFile string) external.Config {
+	configFile = sharedConfigFilename(configFile)
+
+	if credentialsFile == "" {
+		credentialsFile = os.Getenv("AWS_SHARED_CREDENTIALS_FILE")
+		if credentialsFile == "" {
+			credentialsFile = external.DefaultSharedCredentialsFilename()
+		}
+	}
+
+	// same order as the SDK's default: values in the config file take precedence over the credentials file
+	return external.WithSharedConfigFiles{credentialsFile, configFile}
+}
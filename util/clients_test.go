@@ -1,13 +1,26 @@
 package util_test
 
 import (
+	"context"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	awsSDK "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/awserr"
+	"github.com/aws/aws-sdk-go-v2/aws/external"
+	"github.com/aws/aws-sdk-go-v2/aws/stscreds"
 	"github.com/jckuester/awsls/test"
 
 	"github.com/jckuester/awsls/util"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
 )
 
 func TestNewAWSClientPool(t *testing.T) {
@@ -136,3 +149,157 @@ func TestNewAWSClientPool(t *testing.T) {
 		})
 	}
 }
+
+func TestNewAWSClientPool_withSharedConfigFiles(t *testing.T) {
+	err := test.UnsetAWSEnvs()
+	require.NoError(t, err)
+
+	got, err := util.NewAWSClientPool([]string{"profile1", "profile2"}, nil,
+		util.WithSharedConfigFiles("../test/test-fixtures/aws-config", ""))
+	require.NoError(t, err)
+
+	require.Len(t, got, 2)
+	assert.Contains(t, got, util.AWSClientKey{Profile: "profile1", Region: "us-test-1"})
+	assert.Contains(t, got, util.AWSClientKey{Profile: "profile2", Region: "us-test-2"})
+}
+
+func TestNewAWSClientPool_withAssumeRole(t *testing.T) {
+	err := test.UnsetAWSEnvs()
+	require.NoError(t, err)
+
+	got, err := util.NewAWSClientPool([]string{"profile1"}, []string{"us-test-1"},
+		util.WithSharedConfigFiles("../test/test-fixtures/aws-config", ""),
+		util.AssumeRole{RoleARN: "arn:aws:iam::123456789012:role/audit", ExternalID: "foo"})
+	require.NoError(t, err)
+
+	client, ok := got[util.AWSClientKey{Profile: "profile1", Region: "us-test-1"}]
+	require.True(t, ok)
+
+	assert.IsType(t, &stscreds.AssumeRoleProvider{}, client.Stsconn.Credentials)
+}
+
+func TestWithMaxRetries(t *testing.T) {
+	err := test.UnsetAWSEnvs()
+	require.NoError(t, err)
+
+	got, err := util.NewAWSClientPool([]string{"profile1"}, []string{"us-test-1"},
+		util.WithSharedConfigFiles("../test/test-fixtures/aws-config", ""),
+		util.WithMaxRetries(2))
+	require.NoError(t, err)
+
+	client, ok := got[util.AWSClientKey{Profile: "profile1", Region: "us-test-1"}]
+	require.True(t, ok)
+
+	retryer := client.Ec2conn.Retryer
+	assert.Equal(t, 3, retryer.MaxAttempts())
+
+	assert.True(t, retryer.IsErrorRetryable(awserr.New("RequestLimitExceeded", "", nil)))
+	assert.True(t, retryer.IsErrorRetryable(awserr.New("ThrottlingException", "", nil)))
+	assert.False(t, retryer.IsErrorRetryable(awserr.New("AccessDenied", "", nil)))
+
+	// the retry quota never runs out
+	for i := 0; i < 1000; i++ {
+		_, err := retryer.GetRetryToken(context.Background(), awserr.New("ThrottlingException", "", nil))
+		require.NoError(t, err)
+	}
+}
+
+func TestWithEndpointURL(t *testing.T) {
+	err := test.UnsetAWSEnvs()
+	require.NoError(t, err)
+
+	// a local endpoint with a self-signed certificate
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`<GetCallerIdentityResponse><GetCallerIdentityResult>` +
+			`<Account>000000000000</Account></GetCallerIdentityResult></GetCallerIdentityResponse>`))
+	}))
+	server.Config.ErrorLog = log.New(ioutil.Discard, "", 0)
+	defer server.Close()
+
+	configs := []external.Config{
+		external.WithCredentialsProvider{CredentialsProvider: awsSDK.NewStaticCredentialsProvider("test", "test", "")},
+		util.WithEndpointURL(server.URL),
+		util.WithMaxRetries(0),
+	}
+
+	got, err := util.NewAWSClientPool(nil, []string{"us-test-1"}, configs...)
+	require.NoError(t, err)
+
+	client := got[util.AWSClientKey{Region: "us-test-1"}]
+
+	err = client.SetAccountID()
+	require.Error(t, err, "the self-signed certificate must not be trusted by default")
+
+	got, err = util.NewAWSClientPool(nil, []string{"us-test-1"}, append(configs, util.WithInsecureSkipVerify())...)
+	require.NoError(t, err)
+
+	client = got[util.AWSClientKey{Region: "us-test-1"}]
+
+	err = client.SetAccountID()
+	require.NoError(t, err)
+
+	assert.Equal(t, "000000000000", client.AccountID)
+}
+
+func TestWithRateLimit(t *testing.T) {
+	err := test.UnsetAWSEnvs()
+	require.NoError(t, err)
+
+	var requests int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		_, _ = w.Write([]byte(`<GetCallerIdentityResponse><GetCallerIdentityResult>` +
+			`<Account>000000000000</Account></GetCallerIdentityResult></GetCallerIdentityResponse>`))
+	}))
+	defer server.Close()
+
+	// a single limiter shared by the clients of both regions
+	limiter := rate.NewLimiter(10, 1)
+
+	got, err := util.NewAWSClientPool(nil, []string{"us-test-1", "us-test-2"},
+		external.WithCredentialsProvider{CredentialsProvider: awsSDK.NewStaticCredentialsProvider("test", "test", "")},
+		util.WithEndpointURL(server.URL),
+		util.WithMaxRetries(0),
+		util.WithRateLimit(limiter))
+	require.NoError(t, err)
+
+	start := time.Now()
+
+	for i := 0; i < 2; i++ {
+		for _, client := range got {
+			err = client.SetAccountID()
+			require.NoError(t, err)
+		}
+	}
+
+	assert.Equal(t, int32(4), atomic.LoadInt32(&requests))
+	// the first request is allowed right away, the other three wait 100ms each
+	assert.True(t, time.Since(start) >= 250*time.Millisecond, "requests were not rate-limited: %s",
+		time.Since(start))
+}
+
+func TestProfilesOfAccount_withProfileCredentials(t *testing.T) {
+	err := test.UnsetAWSEnvs()
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		account := "000000000000"
+		if strings.Contains(r.Header.Get("Authorization"), "Credential=AKID/") {
+			account = "123456789012"
+		}
+
+		_, _ = w.Write([]byte(`<GetCallerIdentityResponse><GetCallerIdentityResult>` +
+			`<Account>` + account + `</Account></GetCallerIdentityResult></GetCallerIdentityResponse>`))
+	}))
+	defer server.Close()
+
+	// only profile1 has credentials, which are resolved beforehand (e.g., via AWS SSO)
+	got := util.ProfilesOfAccount([]string{"profile1", "profile2"}, "123456789012", "us-test-1",
+		util.WithSharedConfigFiles("../test/test-fixtures/aws-config", ""),
+		util.ProfileCredentials{"profile1": awsSDK.Credentials{AccessKeyID: "AKID", SecretAccessKey: "SECRET"}},
+		util.WithEndpointURL(server.URL),
+		util.WithMaxRetries(0))
+
+	assert.Equal(t, []string{"profile1"}, got)
+}
@@ -0,0 +1,15 @@
+package util
+
+// InstallProvider exports installProvider for testing.
+var InstallProvider = installProvider
+
+// SetProviderRegistry sets the registry from which providers are downloaded and the key with which the checksums
+// of provider releases are signed. Returns a function which restores the defaults.
+func SetProviderRegistry(url, key string) func() {
+	oldURL, oldKey := registryURL, signingKey
+	registryURL, signingKey = url, key
+
+	return func() {
+		registryURL, signingKey = oldURL, oldKey
+	}
+}
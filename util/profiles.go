@@ -0,0 +1,56 @@
+package util
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// SelectProfiles lets the user interactively pick one or more of the given profiles
+// by entering their numbers as a comma-separated list (or "all" to pick every profile).
+func SelectProfiles(in io.Reader, out io.Writer, profiles []string) ([]string, error) {
+	if len(profiles) == 0 {
+		return nil, fmt.Errorf("no profiles to select from")
+	}
+
+	_, _ = fmt.Fprintln(out, "Select the profiles to list resources for:")
+	for i, p := range profiles {
+		_, _ = fmt.Fprintf(out, "  %d) %s\n", i+1, p)
+	}
+	_, _ = fmt.Fprint(out, "Enter comma-separated numbers (or 'all'): ")
+
+	line, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to read selection: %s", err)
+	}
+
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return nil, fmt.Errorf("no profile selected")
+	}
+
+	if line == "all" {
+		return profiles, nil
+	}
+
+	var result []string
+	seen := map[int]bool{}
+
+	for _, field := range strings.Split(line, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil || n < 1 || n > len(profiles) {
+			return nil, fmt.Errorf("invalid selection: %s", strings.TrimSpace(field))
+		}
+
+		if seen[n] {
+			continue
+		}
+		seen[n] = true
+
+		result = append(result, profiles[n-1])
+	}
+
+	return result, nil
+}
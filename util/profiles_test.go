@@ -0,0 +1,62 @@
+package util_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/jckuester/awsls/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectProfiles(t *testing.T) {
+	profiles := []string{"profile1", "profile2", "profile3"}
+
+	tests := []struct {
+		name    string
+		input   string
+		want    []string
+		wantErr string
+	}{
+		{
+			name:  "single profile",
+			input: "2\n",
+			want:  []string{"profile2"},
+		},
+		{
+			name:  "multiple profiles, duplicates ignored",
+			input: "3, 1,3\n",
+			want:  []string{"profile3", "profile1"},
+		},
+		{
+			name:  "all profiles",
+			input: "all\n",
+			want:  profiles,
+		},
+		{
+			name:    "empty selection",
+			input:   "\n",
+			wantErr: "no profile selected",
+		},
+		{
+			name:    "out of range",
+			input:   "4\n",
+			wantErr: "invalid selection: 4",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+
+			got, err := util.SelectProfiles(strings.NewReader(tt.input), &out, profiles)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package util
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/hashicorp/terraform/plugin/discovery"
+	"golang.org/x/crypto/openpgp"
+)
+
+// registryURL is the base URL of the Terraform Registry from which providers are downloaded.
+var registryURL = "https://registry.terraform.io"
+
+// signingKey is the public key with which the published checksums (SHA256SUMS) of provider releases are signed.
+var signingKey = discovery.HashicorpPublicKey
+
+// providerDownload describes where to download a provider release for a platform, as returned by the registry.
+type providerDownload struct {
+	Filename            string `json:"filename"`
+	DownloadURL         string `json:"download_url"`
+	ShasumsURL          string `json:"shasums_url"`
+	ShasumsSignatureURL string `json:"shasums_signature_url"`
+	Shasum              string `json:"shasum"`
+}
+
+// downloadProvider downloads the release archive of a provider version for the current platform and extracts it
+// into installDir. The archive is only extracted if its SHA256 checksum matches the one published in the signed
+// SHA256SUMS of the release.
+func downloadProvider(name, version, installDir string) error {
+	var d providerDownload
+
+	err := getJSON(fmt.Sprintf("%s/v1/providers/hashicorp/%s/%s/download/%s/%s",
+		registryURL, name, version, runtime.GOOS, runtime.GOARCH), &d)
+	if err != nil {
+		return fmt.Errorf("failed to look up provider download: %s", err)
+	}
+
+	published, err := publishedChecksum(d)
+	if err != nil {
+		return err
+	}
+
+	archive, err := ioutil.TempFile(installDir, ".download-")
+	if err != nil {
+		return fmt.Errorf("failed to download provider: %s", err)
+	}
+	defer os.Remove(archive.Name())
+	defer archive.Close()
+
+	h := sha256.New()
+
+	err = getFile(d.DownloadURL, io.MultiWriter(archive, h))
+	if err != nil {
+		return fmt.Errorf("failed to download provider: %s", err)
+	}
+
+	if actual := hex.EncodeToString(h.Sum(nil)); actual != published {
+		return fmt.Errorf("checksum of downloaded provider %s (%s) doesn't match the published checksum (%s)",
+			d.Filename, actual, published)
+	}
+
+	return extractZip(archive.Name(), installDir)
+}
+
+// publishedChecksum returns the SHA256 checksum of the release archive as published in the SHA256SUMS
+// of the release, after verifying their signature and that the checksum agrees with the registry.
+func publishedChecksum(d providerDownload) (string, error) {
+	var shasums, signature bytes.Buffer
+
+	err := getFile(d.ShasumsURL, &shasums)
+	if err != nil {
+		return "", fmt.Errorf("failed to download provider checksums: %s", err)
+	}
+
+	err = getFile(d.ShasumsSignatureURL, &signature)
+	if err != nil {
+		return "", fmt.Errorf("failed to download signature of provider checksums: %s", err)
+	}
+
+	keyRing, err := openpgp.ReadArmoredKeyRing(strings.NewReader(signingKey))
+	if err != nil {
+		return "", fmt.Errorf("failed to read signing key: %s", err)
+	}
+
+	_, err = openpgp.CheckDetachedSignature(keyRing, bytes.NewReader(shasums.Bytes()), &signature)
+	if err != nil {
+		return "", fmt.Errorf("failed to verify signature of provider checksums: %s", err)
+	}
+
+	checksums, err := parseChecksums(&shasums)
+	if err != nil {
+		return "", err
+	}
+
+	published, ok := checksums[d.Filename]
+	if !ok {
+		return "", fmt.Errorf("no published checksum for provider %s", d.Filename)
+	}
+
+	if published != d.Shasum {
+		return "", fmt.Errorf("published checksum of provider %s (%s) doesn't match the registry (%s)",
+			d.Filename, published, d.Shasum)
+	}
+
+	return published, nil
+}
+
+// extractZip extracts the files of a zip archive into dir. Each file is written under a temporary name first,
+// so that an interrupted extraction never leaves a partial file behind.
+func extractZip(archive, dir string) error {
+	r, err := zip.OpenReader(archive)
+	if err != nil {
+		return fmt.Errorf("failed to open provider archive: %s", err)
+	}
+	defer r.Close()
+
+	for _, f := range r.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+
+		err := extractZipFile(f, filepath.Join(dir, filepath.Base(f.Name)))
+		if err != nil {
+			return fmt.Errorf("failed to extract %s from provider archive: %s", f.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func extractZipFile(f *zip.File, path string) error {
+	src, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := ioutil.TempFile(filepath.Dir(path), ".extract-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(dst.Name())
+
+	_, err = io.Copy(dst, src)
+	if err != nil {
+		_ = dst.Close()
+		return err
+	}
+
+	err = dst.Close()
+	if err != nil {
+		return err
+	}
+
+	err = os.Chmod(dst.Name(), 0755)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(dst.Name(), path)
+}
+
+// getJSON decodes the JSON response of a GET request to the given URL into v.
+func getJSON(url string, v interface{}) error {
+	var body bytes.Buffer
+
+	err := getFile(url, &body)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(body.Bytes(), v)
+}
+
+// getFile writes the response of a GET request to the given URL into w.
+func getFile(url string, w io.Writer) error {
+	resp, err := http.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: %s", url, resp.Status)
+	}
+
+	_, err = io.Copy(w, resp.Body)
+
+	return err
+}
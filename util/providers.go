@@ -1,103 +1,375 @@
 package util
 
 import (
+	"bufio"
+	"encoding/hex"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
-	"github.com/fatih/color"
+	"github.com/apex/log"
+	"github.com/hashicorp/terraform/plugin/discovery"
 	"github.com/jckuester/terradozer/pkg/provider"
+	goHomeDir "github.com/mitchellh/go-homedir"
 	"github.com/zclconf/go-cty/cty"
 )
 
-// providerPoolThreadSafe is a concurrent map implementation to store multiple Terraform AWS Providers.
+// checksumsFile is the name of the file in the provider install directory which stores the SHA256 checksums
+// of all provider binaries that have been downloaded (and verified against the registry) successfully.
+const checksumsFile = "SHA256SUMS"
+
+// providerPoolThreadSafe is a concurrent map implementation to store multiple Terraform AWS Providers,
+// and the errors of those that failed to launch.
 type providerPoolThreadSafe struct {
 	sync.Mutex
 	providers map[AWSClientKey]provider.TerraformProvider
+	errors    map[AWSClientKey]error
+}
+
+// ProviderConfig holds settings that apply to every Terraform AWS Provider of a pool,
+// in addition to the AWS profile and region of each provider.
+type ProviderConfig struct {
+	// AccessKey, SecretKey and Token are static credentials; if unset,
+	// the provider looks up credentials via the usual default provider chain.
+	AccessKey, SecretKey, Token string
+	// SharedCredentialsFile is the path to a custom shared credentials file; if unset,
+	// the provider uses ~/.aws/credentials.
+	SharedCredentialsFile string
+	// AssumeRoleARN is the ARN of an IAM role that the provider assumes (see AssumeRole);
+	// ExternalID is passed along if set.
+	AssumeRoleARN, ExternalID string
+	// ProfileCredentials are used instead of the credentials configured for a profile (e.g., resolved via SSO).
+	ProfileCredentials ProfileCredentials
+	// EndpointURL is a custom endpoint (e.g., of LocalStack) to which the provider sends the requests of all services.
+	EndpointURL string
+	// Insecure skips verifying the TLS certificate of endpoints.
+	Insecure bool
 }
 
 // NewProviderPool launches a set of Terraform AWS Providers with the configuration of the given clientKeys
 // (combination of AWS profile and region).
-func NewProviderPool(clientKeys []AWSClientKey, version, installDir string, timeout time.Duration) (
-	map[AWSClientKey]provider.TerraformProvider, error) {
+// A provider failing to launch doesn't affect the others: the pool contains all providers that launched successfully,
+// and the error of each one that failed is returned by its client key.
+func NewProviderPool(clientKeys []AWSClientKey, version, installDir string, timeout time.Duration,
+	providerConfig ProviderConfig) (map[AWSClientKey]provider.TerraformProvider, map[AWSClientKey]error) {
+	providerPool := &providerPoolThreadSafe{
+		providers: make(map[AWSClientKey]provider.TerraformProvider),
+		errors:    make(map[AWSClientKey]error),
+	}
 
-	metaPlugin, err := provider.Install("aws", version, installDir)
+	metaPlugin, err := installProvider("aws", version, installDir)
 	if err != nil {
-		fmt.Fprint(os.Stderr, color.RedString("failed to install provider (%s): %s", "aws", err))
-	}
+		for _, clientKey := range clientKeys {
+			providerPool.errors[clientKey] = fmt.Errorf("failed to install provider (%s): %s", "aws", err)
+		}
 
-	errors := make(chan error)
-	wgDone := make(chan bool)
+		return providerPool.providers, providerPool.errors
+	}
 
 	var wg sync.WaitGroup
 
-	providerPool := &providerPoolThreadSafe{
-		providers: make(map[AWSClientKey]provider.TerraformProvider),
+	wg.Add(len(clientKeys))
+
+	for _, clientKey := range clientKeys {
+		go func(p string, r string) {
+			defer wg.Done()
+
+			pr, err := launchProvider(metaPlugin, p, r, timeout, providerConfig)
+
+			providerPool.Lock()
+			defer providerPool.Unlock()
+
+			if err != nil {
+				providerPool.errors[AWSClientKey{p, r}] = err
+				return
+			}
+
+			providerPool.providers[AWSClientKey{p, r}] = *pr
+		}(clientKey.Profile, clientKey.Region)
 	}
 
-	if len(clientKeys) > 0 {
-		wg.Add(len(clientKeys))
+	wg.Wait()
 
-		for _, clientKey := range clientKeys {
-			go func(p string, r string) {
-				defer wg.Done()
-
-				pr, err := provider.Launch(metaPlugin.Path, timeout)
-				if err != nil {
-					errors <- fmt.Errorf("failed to launch provider (%s): %s", metaPlugin.Path, err)
-					return
-				}
-
-				config := cty.ObjectVal(map[string]cty.Value{
-					"profile":                     cty.StringVal(p),
-					"region":                      cty.StringVal(r),
-					"access_key":                  cty.UnknownVal(cty.DynamicPseudoType),
-					"allowed_account_ids":         cty.UnknownVal(cty.DynamicPseudoType),
-					"assume_role":                 cty.UnknownVal(cty.DynamicPseudoType),
-					"endpoints":                   cty.UnknownVal(cty.DynamicPseudoType),
-					"forbidden_account_ids":       cty.UnknownVal(cty.DynamicPseudoType),
-					"insecure":                    cty.UnknownVal(cty.DynamicPseudoType),
-					"max_retries":                 cty.UnknownVal(cty.DynamicPseudoType),
-					"s3_force_path_style":         cty.UnknownVal(cty.DynamicPseudoType),
-					"secret_key":                  cty.UnknownVal(cty.DynamicPseudoType),
-					"shared_credentials_file":     cty.UnknownVal(cty.DynamicPseudoType),
-					"skip_credentials_validation": cty.UnknownVal(cty.DynamicPseudoType),
-					"skip_get_ec2_platforms":      cty.UnknownVal(cty.DynamicPseudoType),
-					"skip_metadata_api_check":     cty.UnknownVal(cty.DynamicPseudoType),
-					"skip_region_validation":      cty.UnknownVal(cty.DynamicPseudoType),
-					"skip_requesting_account_id":  cty.UnknownVal(cty.DynamicPseudoType),
-					"token":                       cty.UnknownVal(cty.DynamicPseudoType),
-					"ignore_tag_prefixes":         cty.UnknownVal(cty.DynamicPseudoType),
-					"ignore_tags":                 cty.UnknownVal(cty.DynamicPseudoType),
-				})
-
-				err = pr.Configure(config)
-				if err != nil {
-					errors <- fmt.Errorf("failed to configure provider (name=%s, version=%s): %s",
-						metaPlugin.Name, metaPlugin.Version, err)
-					return
-				}
-
-				providerPool.Lock()
-				providerPool.providers[AWSClientKey{p, r}] = *pr
-				providerPool.Unlock()
-			}(clientKey.Profile, clientKey.Region)
+	return providerPool.providers, providerPool.errors
+}
+
+// launchProvider launches and configures a Terraform AWS Provider for the given profile and region.
+func launchProvider(metaPlugin discovery.PluginMeta, profile, region string, timeout time.Duration,
+	providerConfig ProviderConfig) (*provider.TerraformProvider, error) {
+	pr, err := provider.Launch(metaPlugin.Path, timeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch provider (%s): %s", metaPlugin.Path, err)
+	}
+
+	if creds, ok := providerConfig.ProfileCredentials[profile]; ok {
+		providerConfig.AccessKey = creds.AccessKeyID
+		providerConfig.SecretKey = creds.SecretAccessKey
+		providerConfig.Token = creds.SessionToken
+	}
+
+	endpoints, err := endpointsVal(pr, providerConfig.EndpointURL)
+	if err != nil {
+		_ = pr.Close()
+
+		return nil, fmt.Errorf("failed to configure endpoints of provider (name=%s, version=%s): %s",
+			metaPlugin.Name, metaPlugin.Version, err)
+	}
+
+	// a custom endpoint (e.g., LocalStack) usually neither has real credentials nor serves the metadata API
+	skipChecks := boolValOrUnknown(providerConfig.EndpointURL != "")
+
+	config := cty.ObjectVal(map[string]cty.Value{
+		"profile":                     cty.StringVal(profile),
+		"region":                      cty.StringVal(region),
+		"access_key":                  stringValOrUnknown(providerConfig.AccessKey),
+		"allowed_account_ids":         cty.UnknownVal(cty.DynamicPseudoType),
+		"assume_role":                 assumeRoleVal(providerConfig),
+		"endpoints":                   endpoints,
+		"forbidden_account_ids":       cty.UnknownVal(cty.DynamicPseudoType),
+		"insecure":                    boolValOrUnknown(providerConfig.Insecure),
+		"max_retries":                 cty.UnknownVal(cty.DynamicPseudoType),
+		"s3_force_path_style":         skipChecks,
+		"secret_key":                  stringValOrUnknown(providerConfig.SecretKey),
+		"shared_credentials_file":     stringValOrUnknown(providerConfig.SharedCredentialsFile),
+		"skip_credentials_validation": skipChecks,
+		"skip_get_ec2_platforms":      skipChecks,
+		"skip_metadata_api_check":     skipChecks,
+		"skip_region_validation":      cty.UnknownVal(cty.DynamicPseudoType),
+		"skip_requesting_account_id":  skipChecks,
+		"token":                       stringValOrUnknown(providerConfig.Token),
+		"ignore_tag_prefixes":         cty.UnknownVal(cty.DynamicPseudoType),
+		"ignore_tags":                 cty.UnknownVal(cty.DynamicPseudoType),
+	})
+
+	err = pr.Configure(config)
+	if err != nil {
+		_ = pr.Close()
+
+		return nil, fmt.Errorf("failed to configure provider (name=%s, version=%s): %s",
+			metaPlugin.Name, metaPlugin.Version, err)
+	}
+
+	return pr, nil
+}
+
+// stringValOrUnknown returns the given string as cty value, or an unknown value if the string is empty,
+// so that the provider falls back to its defaults.
+func stringValOrUnknown(s string) cty.Value {
+	if s == "" {
+		return cty.UnknownVal(cty.DynamicPseudoType)
+	}
+
+	return cty.StringVal(s)
+}
+
+// boolValOrUnknown returns true as cty value, or an unknown value if false, so that the provider falls back
+// to its defaults.
+func boolValOrUnknown(b bool) cty.Value {
+	if !b {
+		return cty.UnknownVal(cty.DynamicPseudoType)
+	}
+
+	return cty.True
+}
+
+// endpointsVal returns the endpoints block of the provider configuration, which sets the given endpoint URL
+// for every service, or an unknown value if no endpoint URL is given. The services are taken from the schema
+// of the provider, as the block must set each of them.
+func endpointsVal(pr *provider.TerraformProvider, endpointURL string) (cty.Value, error) {
+	if endpointURL == "" {
+		return cty.UnknownVal(cty.DynamicPseudoType), nil
+	}
+
+	schema := pr.GetSchema()
+	if schema.Diagnostics.HasErrors() {
+		return cty.NilVal, schema.Diagnostics.Err()
+	}
+
+	block, ok := schema.Provider.Block.BlockTypes["endpoints"]
+	if !ok {
+		return cty.NilVal, fmt.Errorf("provider doesn't support custom endpoints")
+	}
+
+	services := map[string]cty.Value{}
+
+	for name, attr := range block.Attributes {
+		if attr.Type != cty.String {
+			services[name] = cty.NullVal(attr.Type)
+			continue
 		}
+
+		services[name] = cty.StringVal(endpointURL)
 	}
 
-	go func() {
-		wg.Wait()
-		close(wgDone)
-	}()
+	return cty.SetVal([]cty.Value{cty.ObjectVal(services)}), nil
+}
+
+// assumeRoleVal returns the assume_role block of the provider configuration, or an unknown value if no role is
+// to be assumed.
+func assumeRoleVal(providerConfig ProviderConfig) cty.Value {
+	if providerConfig.AssumeRoleARN == "" {
+		return cty.UnknownVal(cty.DynamicPseudoType)
+	}
+
+	return cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+		"role_arn":     cty.StringVal(providerConfig.AssumeRoleARN),
+		"session_name": cty.StringVal(roleSessionName),
+		"external_id":  stringValOrUnknown(providerConfig.ExternalID),
+		"policy":       cty.UnknownVal(cty.DynamicPseudoType),
+	})})
+}
+
+// PrepareProviderDir expands environment variables and ~ in the given directory into which providers are installed,
+// creates the directory if it doesn't exist yet, and checks that it is writable. Returns the expanded directory.
+func PrepareProviderDir(dir string) (string, error) {
+	expandedDir, err := goHomeDir.Expand(os.ExpandEnv(dir))
+	if err != nil {
+		return "", err
+	}
 
-	select {
-	case <-wgDone:
-		break
-	case err := <-errors:
-		close(errors)
-		return nil, err
+	err = os.MkdirAll(expandedDir, 0755)
+	if err != nil {
+		return "", fmt.Errorf("failed to create provider directory: %s", err)
+	}
+
+	// a provider is downloaded into the directory only if it isn't installed yet, so fail early
+	// rather than once a (new) provider version needs to be installed
+	f, err := ioutil.TempFile(expandedDir, ".write-test-")
+	if err != nil {
+		return "", fmt.Errorf("provider directory is not writable: %s", err)
+	}
+
+	_ = f.Close()
+	_ = os.Remove(f.Name())
+
+	return expandedDir, nil
+}
+
+// installProvider installs a Terraform provider of the given version into installDir.
+//
+// A provider is downloaded from the Terraform Registry and only installed if its release archive matches the SHA256
+// checksum published (and signed) for the release. As the registry publishes checksums only for release archives,
+// the checksum of each installed provider binary is recorded, and a cached binary is only reused if it still matches
+// this checksum. Otherwise (e.g., a previous download got interrupted, or the binary has been cached by an older
+// version of awsls that didn't record checksums), the binary is downloaded and verified again.
+func installProvider(name, version, installDir string) (discovery.PluginMeta, error) {
+	expandedInstallDir, err := goHomeDir.Expand(installDir)
+	if err != nil {
+		return discovery.PluginMeta{}, err
+	}
+
+	checksums, err := readChecksums(expandedInstallDir)
+	if err != nil {
+		return discovery.PluginMeta{}, err
+	}
+
+	v, err := discovery.VersionStr(version).Parse()
+	if err != nil {
+		return discovery.PluginMeta{}, fmt.Errorf("failed to parse provider version: %s", err)
+	}
+
+	plugins := discovery.FindPlugins("provider", []string{expandedInstallDir}).WithName(name).WithVersion(v)
+
+	for p := range plugins {
+		recorded, ok := checksums[filepath.Base(p.Path)]
+		if !ok {
+			// replaced by the verified download
+			log.WithField("path", p.Path).Debug("no checksum recorded for cached provider binary, downloading it again")
+			continue
+		}
+
+		actual, err := p.SHA256()
+		if err == nil && hex.EncodeToString(actual) == recorded {
+			return p, nil
+		}
+
+		log.WithField("path", p.Path).Debug("cached provider binary failed checksum verification, downloading it again")
+
+		err = os.Remove(p.Path)
+		if err != nil {
+			return discovery.PluginMeta{}, fmt.Errorf("failed to remove corrupt provider binary: %s", err)
+		}
+	}
+
+	err = downloadProvider(name, version, expandedInstallDir)
+	if err != nil {
+		return discovery.PluginMeta{}, err
+	}
+
+	plugins = discovery.FindPlugins("provider", []string{expandedInstallDir}).WithName(name).WithVersion(v)
+	if plugins.Count() != 1 {
+		return discovery.PluginMeta{}, fmt.Errorf("failed to find installed provider binary (name=%s, version=%s)",
+			name, version)
+	}
+
+	meta := plugins.Newest()
+
+	actual, err := meta.SHA256()
+	if err != nil {
+		return discovery.PluginMeta{}, fmt.Errorf("failed to compute checksum of provider binary: %s", err)
+	}
+
+	checksums[filepath.Base(meta.Path)] = hex.EncodeToString(actual)
+
+	err = writeChecksums(expandedInstallDir, checksums)
+	if err != nil {
+		return discovery.PluginMeta{}, err
+	}
+
+	return meta, nil
+}
+
+// readChecksums reads the checksums of verified provider binaries in installDir.
+func readChecksums(installDir string) (map[string]string, error) {
+	f, err := os.Open(filepath.Join(installDir, checksumsFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]string{}, nil
+		}
+
+		return nil, fmt.Errorf("failed to read provider checksums: %s", err)
+	}
+	defer f.Close()
+
+	return parseChecksums(f)
+}
+
+// parseChecksums parses checksums by file name in the format of SHA256SUMS ("<sha256>  <filename>" per line).
+func parseChecksums(r io.Reader) (map[string]string, error) {
+	result := map[string]string{}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+
+		result[fields[1]] = fields[0]
+	}
+
+	return result, scanner.Err()
+}
+
+// writeChecksums writes the checksums of verified provider binaries into installDir.
+func writeChecksums(installDir string, checksums map[string]string) error {
+	var lines []string
+	for fileName, checksum := range checksums {
+		lines = append(lines, fmt.Sprintf("%s  %s\n", checksum, fileName))
+	}
+
+	sort.Strings(lines)
+
+	err := ioutil.WriteFile(filepath.Join(installDir, checksumsFile), []byte(strings.Join(lines, "")), 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write provider checksums: %s", err)
 	}
 
-	return providerPool.providers, nil
+	return nil
 }
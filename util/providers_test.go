@@ -0,0 +1,215 @@
+package util_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/jckuester/awsls/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+)
+
+func TestPrepareProviderDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "awsls")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	oldCache := os.Getenv("AWSLS_TEST_CACHE")
+	defer os.Setenv("AWSLS_TEST_CACHE", oldCache)
+
+	err = os.Setenv("AWSLS_TEST_CACHE", dir)
+	require.NoError(t, err)
+
+	got, err := util.PrepareProviderDir("$AWSLS_TEST_CACHE/providers")
+	require.NoError(t, err)
+
+	assert.Equal(t, filepath.Join(dir, "providers"), got)
+
+	info, err := os.Stat(got)
+	require.NoError(t, err)
+	assert.True(t, info.IsDir())
+
+	files, err := ioutil.ReadDir(got)
+	require.NoError(t, err)
+	assert.Empty(t, files, "no file is left behind by the write test")
+}
+
+func TestPrepareProviderDir_notADirectory(t *testing.T) {
+	f, err := ioutil.TempFile("", "awsls")
+	require.NoError(t, err)
+	defer os.Remove(f.Name())
+	_ = f.Close()
+
+	_, err = util.PrepareProviderDir(filepath.Join(f.Name(), "providers"))
+	require.Error(t, err)
+
+	assert.Contains(t, err.Error(), "failed to create provider directory")
+}
+
+// providerBinary is the name of the provider binary in the release archive served by the test registry.
+const providerBinary = "terraform-provider-aws_v3.0.0_x5"
+
+func TestInstallProvider(t *testing.T) {
+	release := []byte("#!/bin/sh\n# provider v3.0.0\n")
+
+	tests := []struct {
+		name string
+		// cached is the content of a provider binary installed before (nil if none)
+		cached []byte
+		// recorded is the checksum recorded for the cached binary (empty if none)
+		recorded string
+		// published is the checksum published for the release archive (empty for the actual one)
+		published     string
+		wantDownloads int
+		wantErr       string
+	}{
+		{
+			name:          "not cached",
+			wantDownloads: 1,
+		},
+		{
+			name:          "cached binary matches recorded checksum",
+			cached:        release,
+			recorded:      checksum(release),
+			wantDownloads: 0,
+		},
+		{
+			name:          "cached binary doesn't match recorded checksum",
+			cached:        []byte("corrupt"),
+			recorded:      checksum(release),
+			wantDownloads: 1,
+		},
+		{
+			name:          "legacy cache without recorded checksum",
+			cached:        []byte("unverified"),
+			wantDownloads: 1,
+		},
+		{
+			name:          "release archive doesn't match published checksum",
+			published:     checksum([]byte("other")),
+			wantDownloads: 1,
+			wantErr:       "doesn't match the published checksum",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "awsls")
+			require.NoError(t, err)
+			defer os.RemoveAll(dir)
+
+			if tt.cached != nil {
+				err := ioutil.WriteFile(filepath.Join(dir, providerBinary), tt.cached, 0755)
+				require.NoError(t, err)
+			}
+
+			if tt.recorded != "" {
+				err := ioutil.WriteFile(filepath.Join(dir, "SHA256SUMS"),
+					[]byte(tt.recorded+"  "+providerBinary+"\n"), 0644)
+				require.NoError(t, err)
+			}
+
+			downloads := 0
+			server, key := newTestRegistry(t, release, tt.published, &downloads)
+			defer server.Close()
+
+			defer util.SetProviderRegistry(server.URL, key)()
+
+			meta, err := util.InstallProvider("aws", "3.0.0", dir)
+			assert.Equal(t, tt.wantDownloads, downloads)
+
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+
+				_, err := os.Stat(filepath.Join(dir, providerBinary))
+				assert.True(t, os.IsNotExist(err), "unverified provider must not be installed")
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, filepath.Join(dir, providerBinary), meta.Path)
+
+			installed, err := ioutil.ReadFile(meta.Path)
+			require.NoError(t, err)
+			assert.Equal(t, release, installed)
+
+			checksums, err := ioutil.ReadFile(filepath.Join(dir, "SHA256SUMS"))
+			require.NoError(t, err)
+			assert.Equal(t, checksum(release)+"  "+providerBinary+"\n", string(checksums))
+		})
+	}
+}
+
+// newTestRegistry returns a registry serving a release of the AWS provider v3.0.0 that contains the given binary,
+// and the armored public key with which the checksums of the release are signed. If published is set, it's the
+// checksum of the release archive in the (signed) checksums, instead of the actual one. Each download
+// of the release archive is counted.
+func newTestRegistry(t *testing.T, binary []byte, published string, downloads *int) (*httptest.Server, string) {
+	var archive bytes.Buffer
+
+	zw := zip.NewWriter(&archive)
+	f, err := zw.Create(providerBinary)
+	require.NoError(t, err)
+	_, err = f.Write(binary)
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+
+	filename := fmt.Sprintf("terraform-provider-aws_3.0.0_%s_%s.zip", runtime.GOOS, runtime.GOARCH)
+
+	if published == "" {
+		published = checksum(archive.Bytes())
+	}
+
+	shasums := []byte(published + "  " + filename + "\n")
+
+	entity, err := openpgp.NewEntity("test", "", "test@example.com", nil)
+	require.NoError(t, err)
+
+	var signature bytes.Buffer
+	require.NoError(t, openpgp.DetachSign(&signature, entity, bytes.NewReader(shasums), nil))
+
+	var key bytes.Buffer
+	w, err := armor.Encode(&key, openpgp.PublicKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, entity.Serialize(w))
+	require.NoError(t, w.Close())
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case fmt.Sprintf("/v1/providers/hashicorp/aws/3.0.0/download/%s/%s", runtime.GOOS, runtime.GOARCH):
+			_, _ = fmt.Fprintf(w, `{"filename": %q, "download_url": %q, "shasums_url": %q,
+				"shasums_signature_url": %q, "shasum": %q}`,
+				filename, server.URL+"/"+filename, server.URL+"/SHA256SUMS", server.URL+"/SHA256SUMS.sig", published)
+		case "/" + filename:
+			*downloads++
+			_, _ = w.Write(archive.Bytes())
+		case "/SHA256SUMS":
+			_, _ = w.Write(shasums)
+		case "/SHA256SUMS.sig":
+			_, _ = w.Write(signature.Bytes())
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	return server, key.String()
+}
+
+func checksum(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
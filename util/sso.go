@@ -0,0 +1,286 @@
+package util
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	awsSDK "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/defaults"
+	"github.com/aws/aws-sdk-go-v2/aws/external"
+	"github.com/aws/aws-sdk-go-v2/service/sso"
+	"github.com/aws/aws-sdk-go-v2/service/ssooidc"
+	goHomeDir "github.com/mitchellh/go-homedir"
+)
+
+// ssoCacheDir is the directory in which the AWS CLI caches the access tokens of `aws sso login`.
+const ssoCacheDir = "~/.aws/sso/cache"
+
+// ProfileCredentials is a config that makes NewAWSClientPool use the given credentials (by profile name) instead
+// of the ones configured for a profile (e.g., credentials resolved via SSOCredentials).
+type ProfileCredentials map[string]awsSDK.Credentials
+
+// ssoProfile is the AWS SSO configuration of a profile in the shared config file.
+type ssoProfile struct {
+	startURL, region, accountID, roleName string
+}
+
+// ssoToken is an access token cached by the AWS CLI, with everything needed to refresh it.
+type ssoToken struct {
+	StartURL     string `json:"startUrl"`
+	Region       string `json:"region"`
+	AccessToken  string `json:"accessToken"`
+	ExpiresAt    string `json:"expiresAt"`
+	ClientID     string `json:"clientId,omitempty"`
+	ClientSecret string `json:"clientSecret,omitempty"`
+	RefreshToken string `json:"refreshToken,omitempty"`
+}
+
+// SSOCredentials resolves the credentials of all given profiles that are configured for AWS SSO
+// (i.e., have sso_start_url and sso_account_id set in the shared config file). The empty profile stands for
+// the one set via AWS_PROFILE (or the default profile). Profiles that don't use SSO are skipped.
+//
+// Credentials are fetched with the access token cached by `aws sso login`, which is refreshed via SSO OIDC
+// if it has expired (and the cache contains a refresh token). An error is returned if no valid token is found.
+func SSOCredentials(configFile string, profiles []string) (ProfileCredentials, error) {
+	result := ProfileCredentials{}
+
+	for _, profile := range profiles {
+		name := profile
+		if name == "" {
+			name = os.Getenv("AWS_PROFILE")
+			if name == "" {
+				name = "default"
+			}
+		}
+
+		p, err := readSSOProfile(sharedConfigFilename(configFile), name)
+		if err != nil {
+			return nil, err
+		}
+
+		if p == nil {
+			continue
+		}
+
+		creds, err := p.credentials()
+		if err != nil {
+			return nil, fmt.Errorf("%s; please run: aws sso login --profile %s", err, name)
+		}
+
+		result[profile] = creds
+	}
+
+	return result, nil
+}
+
+// readSSOProfile returns the SSO configuration of the given profile in the shared config file,
+// or nil if the profile doesn't use SSO.
+func readSSOProfile(configFile, profile string) (*ssoProfile, error) {
+	f, err := os.Open(configFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to read shared config file: %s", err)
+	}
+	defer f.Close()
+
+	values := map[string]string{}
+	inSection := false
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section := strings.TrimSpace(line[1 : len(line)-1])
+			inSection = section == "profile "+profile || section == profile
+			continue
+		}
+
+		kv := strings.SplitN(line, "=", 2)
+		if inSection && len(kv) == 2 {
+			values[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read shared config file: %s", err)
+	}
+
+	if values["sso_start_url"] == "" || values["sso_account_id"] == "" {
+		return nil, nil
+	}
+
+	return &ssoProfile{
+		startURL:  values["sso_start_url"],
+		region:    values["sso_region"],
+		accountID: values["sso_account_id"],
+		roleName:  values["sso_role_name"],
+	}, nil
+}
+
+// credentials fetches the credentials of the profile's role with the cached SSO access token.
+func (p ssoProfile) credentials() (awsSDK.Credentials, error) {
+	token, err := cachedSSOToken(p.startURL)
+	if err != nil {
+		return awsSDK.Credentials{}, err
+	}
+
+	cfg := defaults.Config()
+	cfg.Region = p.region
+
+	req := sso.New(cfg).GetRoleCredentialsRequest(&sso.GetRoleCredentialsInput{
+		AccessToken: awsSDK.String(token.AccessToken),
+		AccountId:   awsSDK.String(p.accountID),
+		RoleName:    awsSDK.String(p.roleName),
+	})
+
+	resp, err := req.Send(context.Background())
+	if err != nil {
+		return awsSDK.Credentials{}, fmt.Errorf("failed to get SSO role credentials: %s", err)
+	}
+
+	return awsSDK.Credentials{
+		AccessKeyID:     awsSDK.StringValue(resp.RoleCredentials.AccessKeyId),
+		SecretAccessKey: awsSDK.StringValue(resp.RoleCredentials.SecretAccessKey),
+		SessionToken:    awsSDK.StringValue(resp.RoleCredentials.SessionToken),
+		Source:          "SSOProvider",
+		CanExpire:       true,
+		Expires:         time.Unix(0, awsSDK.Int64Value(resp.RoleCredentials.Expiration)*int64(time.Millisecond)),
+	}, nil
+}
+
+// cachedSSOToken returns the cached access token for the given start URL, which is refreshed if it has expired.
+func cachedSSOToken(startURL string) (*ssoToken, error) {
+	cacheDir, err := goHomeDir.Expand(ssoCacheDir)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := sha1.Sum([]byte(startURL)) //nolint:gosec // the AWS CLI names cache files by the SHA1 of the URL
+	path := filepath.Join(cacheDir, hex.EncodeToString(hash[:])+".json")
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("no cached SSO token found for %s", startURL)
+	}
+
+	var token ssoToken
+
+	err = json.Unmarshal(data, &token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse cached SSO token (%s): %s", path, err)
+	}
+
+	expiresAt, err := parseSSOExpiry(token.ExpiresAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse expiry of cached SSO token (%s): %s", path, err)
+	}
+
+	if time.Now().Before(expiresAt) {
+		return &token, nil
+	}
+
+	if token.RefreshToken == "" || token.ClientID == "" || token.ClientSecret == "" {
+		return nil, fmt.Errorf("cached SSO token for %s has expired", startURL)
+	}
+
+	err = token.refresh()
+	if err != nil {
+		return nil, err
+	}
+
+	// keep the fields of the cache file that are unknown to awsls
+	var cached map[string]interface{}
+
+	err = json.Unmarshal(data, &cached)
+	if err != nil {
+		return nil, err
+	}
+
+	cached["accessToken"] = token.AccessToken
+	cached["expiresAt"] = token.ExpiresAt
+	cached["refreshToken"] = token.RefreshToken
+
+	data, err = json.Marshal(cached)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ioutil.WriteFile(path, data, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update cached SSO token: %s", err)
+	}
+
+	return &token, nil
+}
+
+// refresh replaces the expired access token with a new one via SSO OIDC.
+func (t *ssoToken) refresh() error {
+	cfg := defaults.Config()
+	cfg.Region = t.Region
+
+	req := ssooidc.New(cfg).CreateTokenRequest(&ssooidc.CreateTokenInput{
+		ClientId:     awsSDK.String(t.ClientID),
+		ClientSecret: awsSDK.String(t.ClientSecret),
+		GrantType:    awsSDK.String("refresh_token"),
+		RefreshToken: awsSDK.String(t.RefreshToken),
+		// required by the API, but unused for refreshing
+		DeviceCode: awsSDK.String("unused"),
+	})
+
+	resp, err := req.Send(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to refresh expired SSO token: %s", err)
+	}
+
+	t.AccessToken = awsSDK.StringValue(resp.AccessToken)
+	t.ExpiresAt = time.Now().UTC().Add(time.Duration(awsSDK.Int64Value(resp.ExpiresIn)) * time.Second).
+		Format(time.RFC3339)
+
+	if resp.RefreshToken != nil {
+		t.RefreshToken = *resp.RefreshToken
+	}
+
+	return nil
+}
+
+// parseSSOExpiry parses the expiry of a cached SSO token, which older versions of the AWS CLI
+// wrote with a "UTC" suffix instead of RFC3339.
+func parseSSOExpiry(s string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, s)
+	if err == nil {
+		return t, nil
+	}
+
+	return time.Parse("2006-01-02T15:04:05UTC", s)
+}
+
+// sharedConfigFilename returns the given path to the shared config file, or else the one set via AWS_CONFIG_FILE
+// or the default location (~/.aws/config).
+func sharedConfigFilename(configFile string) string {
+	if configFile != "" {
+		return configFile
+	}
+
+	configFile = os.Getenv("AWS_CONFIG_FILE")
+	if configFile == "" {
+		configFile = external.DefaultSharedConfigFilename()
+	}
+
+	return configFile
+}
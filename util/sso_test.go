@@ -0,0 +1,74 @@
+package util_test
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	awsSDK "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/jckuester/awsls/test"
+	"github.com/jckuester/awsls/util"
+	goHomeDir "github.com/mitchellh/go-homedir"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSSOCredentials_noSSOProfile(t *testing.T) {
+	got, err := util.SSOCredentials("../test/test-fixtures/aws-config-sso", []string{"profile1"})
+	require.NoError(t, err)
+
+	assert.Empty(t, got)
+}
+
+func TestSSOCredentials_expiredToken(t *testing.T) {
+	home, err := ioutil.TempDir("", "awsls")
+	require.NoError(t, err)
+	defer os.RemoveAll(home)
+
+	goHomeDir.DisableCache = true
+	defer func() { goHomeDir.DisableCache = false }()
+
+	oldHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", oldHome)
+
+	err = os.Setenv("HOME", home)
+	require.NoError(t, err)
+
+	cacheDir := filepath.Join(home, ".aws", "sso", "cache")
+	err = os.MkdirAll(cacheDir, 0700)
+	require.NoError(t, err)
+
+	hash := sha1.Sum([]byte("https://example.awsapps.com/start"))
+	err = ioutil.WriteFile(filepath.Join(cacheDir, hex.EncodeToString(hash[:])+".json"),
+		[]byte(`{"startUrl":"https://example.awsapps.com/start","region":"us-test-1",`+
+			`"accessToken":"foo","expiresAt":"2020-01-01T00:00:00UTC"}`), 0600)
+	require.NoError(t, err)
+
+	_, err = util.SSOCredentials("../test/test-fixtures/aws-config-sso", []string{"sso"})
+	require.Error(t, err)
+
+	assert.Contains(t, err.Error(), "has expired")
+	assert.Contains(t, err.Error(), "aws sso login --profile sso")
+}
+
+func TestNewAWSClientPool_withProfileCredentials(t *testing.T) {
+	err := test.UnsetAWSEnvs()
+	require.NoError(t, err)
+
+	got, err := util.NewAWSClientPool([]string{"profile1", "profile2"}, nil,
+		util.WithSharedConfigFiles("../test/test-fixtures/aws-config", ""),
+		util.ProfileCredentials{"profile1": awsSDK.Credentials{AccessKeyID: "AKID", SecretAccessKey: "SECRET"}})
+	require.NoError(t, err)
+
+	client, ok := got[util.AWSClientKey{Profile: "profile1", Region: "us-test-1"}]
+	require.True(t, ok)
+
+	creds, err := client.Stsconn.Credentials.Retrieve(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, "AKID", creds.AccessKeyID)
+}